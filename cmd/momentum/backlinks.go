@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+// backlinksCmd represents the backlinks command
+var backlinksCmd = &cobra.Command{
+	Use:   "backlinks <entry>",
+	Short: "List entries that link to an entry",
+	Long: `List the journal entries containing a [[wiki-link]] to the given entry. A link
+names an entry, like [[2024-05-01T08:30-morning-pages]], or a date, like
+[[2024-05-01]], which points at that day's first entry.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		filePath, err := journalManager.ResolveEntryPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		target, err := journalManager.ReadEntry(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry: %w", err)
+		}
+
+		entries, err := journalManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+
+		linking := journal.Backlinks(entries, target)
+		if len(linking) == 0 {
+			fmt.Fprintf(out, "No entries link to %s.\n", target.FileName)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tTIME\tWORDS\tPROGRESS\tCOMPLETE\tFILE")
+		fmt.Fprintln(w, "----\t----\t-----\t--------\t--------\t----")
+		for _, entry := range linking {
+			printEntryRow(w, journalManager, entry, "")
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backlinksCmd)
+}
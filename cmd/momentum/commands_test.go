@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// resetFlags puts every flag back to its default, since cobra keeps the
+// values from one Execute to the next
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		f.Value.Set(f.DefValue)
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, child := range cmd.Commands() {
+		resetFlags(child)
+	}
+}
+
+// runCommand runs the momentum CLI with args against the journal in home and
+// returns what it wrote to its output
+func runCommand(t *testing.T, home, stdin string, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv(config.HomeEnv, home)
+	resetFlags(rootCmd)
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(io.Discard)
+	rootCmd.SetIn(strings.NewReader(stdin))
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   [][]string // commands run first, with "words words" as stdin
+		args    []string
+		stdin   string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "list with no entries",
+			args: []string{"list"},
+			want: []string{"No journal entries found."},
+		},
+		{
+			name:  "list after new --stdin",
+			setup: [][]string{{"new", "--stdin", "--date", "2024-03-05T09:30"}},
+			args:  []string{"list"},
+			want:  []string{"DATE", "2024-03-05", "09:30"},
+		},
+		{
+			name:  "list --contains without a match",
+			setup: [][]string{{"new", "--stdin"}},
+			args:  []string{"list", "--contains", "zebra"},
+			want:  []string{`No journal entries contain "zebra".`},
+		},
+		{
+			name:  "list --contains reads indexed entries",
+			setup: [][]string{{"new", "--stdin"}, {"list"}},
+			args:  []string{"list", "--contains", "WORDS"},
+			want:  []string{"DATE"},
+		},
+		{
+			name:  "list --json carries indexed content",
+			setup: [][]string{{"new", "--stdin"}, {"list"}},
+			args:  []string{"list", "--json"},
+			want:  []string{`"content": "words words"`},
+		},
+		{
+			name: "new --no-tui prints the path",
+			args: []string{"new", "--no-tui", "--date", "2024-03-05T09:30"},
+			want: []string{"2024-03-05", ".md"},
+		},
+		{
+			name:  "new --stdin reports the words",
+			args:  []string{"new", "--stdin"},
+			stdin: "one two three",
+			want:  []string{"(3 words, complete: false)"},
+		},
+		{
+			name: "new --dry-run",
+			args: []string{"new", "--dry-run"},
+			want: []string{"Dry run: would create"},
+		},
+		{
+			name:    "new --stdin --no-tui",
+			args:    []string{"new", "--stdin", "--no-tui"},
+			wantErr: "--stdin and --no-tui can't be used together",
+		},
+		{
+			name:    "new with a bad --date",
+			args:    []string{"new", "--no-tui", "--date", "March 5"},
+			wantErr: "invalid --date",
+		},
+		{
+			name:    "list --json --completed-streak",
+			args:    []string{"list", "--json", "--completed-streak"},
+			wantErr: "--json and --completed-streak can't be used together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			for _, args := range tt.setup {
+				if _, err := runCommand(t, home, "words words", args...); err != nil {
+					t.Fatalf("setup %v: %v", args, err)
+				}
+			}
+
+			got, err := runCommand(t, home, tt.stdin, tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output %q doesn't contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewNoTUICreatesEntry(t *testing.T) {
+	home := t.TempDir()
+	configYAML := "journal:\n  entry_header_template: \"# {{.Date}} ({{.Weekday}})\\nIntention: \"\n"
+	if err := os.WriteFile(filepath.Join(home, "config.yaml"), []byte(configYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, home, "", "new", "--no-tui", "--date", "2024-03-05T09:30")
+	if err != nil {
+		t.Fatalf("new --no-tui: %v", err)
+	}
+
+	path := strings.TrimSpace(out)
+	if !strings.HasPrefix(path, filepath.Join(home, "journals")) {
+		t.Errorf("path %q isn't in the journal under %s", path, home)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("entry wasn't created: %v", err)
+	}
+	if want := "# 2024-03-05 (Tuesday)\nIntention: \n"; !strings.HasSuffix(string(data), want) {
+		t.Errorf("entry = %q, want it to end with the rendered template %q", data, want)
+	}
+}
+
+func TestNewDateRecordsCreatedAt(t *testing.T) {
+	home := t.TempDir()
+	out, err := runCommand(t, home, "", "new", "--no-tui", "--date", "2019-01-02T08:15")
+	if err != nil {
+		t.Fatalf("new --date: %v", err)
+	}
+
+	path := strings.TrimSpace(out)
+	if !strings.HasPrefix(filepath.Base(path), "2019-01-02T08:15") {
+		t.Errorf("path %q isn't named for the date", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("entry wasn't created: %v", err)
+	}
+	if !strings.Contains(string(data), "created_at: 2019-01-02T08:15:00") {
+		t.Errorf("entry = %q, want created_at on the given date", data)
+	}
+}
+
+// journalFiles returns the contents of every file under dir, keyed by path
+func journalFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		files[path] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("reading %s: %v", dir, err)
+	}
+	return files
+}
+
+func TestDryRunLeavesJournalUnchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "new", args: []string{"new", "--dry-run"}, want: "Dry run: would create"},
+		{name: "prune-empty", args: []string{"prune-empty", "--min", "5", "--dry-run"}, want: "Would move 1 entries"},
+		{name: "compact", args: []string{"compact", "--older-than", "0s", "--dry-run"}, want: "Would remove 1 files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			if _, err := runCommand(t, home, "words words", "new", "--stdin"); err != nil {
+				t.Fatalf("setup: %v", err)
+			}
+			journals := filepath.Join(home, "journals")
+			if err := os.WriteFile(filepath.Join(journals, "old.md.bak"), []byte("backup"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			before := journalFiles(t, journals)
+
+			out, err := runCommand(t, home, "", tt.args...)
+			if err != nil {
+				t.Fatalf("%v: %v", tt.args, err)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output %q doesn't contain %q", out, tt.want)
+			}
+
+			after := journalFiles(t, journals)
+			if len(after) != len(before) {
+				t.Errorf("journal has %d files after the dry run, want %d", len(after), len(before))
+			}
+			for path, content := range before {
+				if after[path] != content {
+					t.Errorf("%s changed in a dry run", path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compactOlderThan time.Duration
+	compactDryRun    bool
+)
+
+// compactCmd represents the compact command
+var compactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Remove stale drafts, backups, old trash, and leftovers from the journal directory",
+	Long: `Remove clutter that is older than --older-than: files left by interrupted
+writes, .bak and .draft files, sidecars of deleted entries, entries moved to
+.trash/ that long ago, and drafts left in journal.draft_dir for entries that
+no longer exist. Entries are never touched. Use --dry-run to see what would
+be removed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		journalManager, err := newJournalManager(journal.WithDryRun(compactDryRun))
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		now := time.Now()
+		stale, err := journalManager.Compact(journal.CompactOptions{OlderThan: compactOlderThan, Now: now})
+		if err != nil {
+			return fmt.Errorf("failed to compact journal directory: %w", err)
+		}
+
+		if len(stale) == 0 {
+			fmt.Fprintln(out, "Nothing to clean up.")
+			return nil
+		}
+
+		total := 0
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "AGE\tSIZE\tREASON\tFILE")
+		fmt.Fprintln(w, "---\t----\t------\t----")
+		for _, file := range stale {
+			total += file.Size
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", formatAge(now.Sub(file.ModTime)), file.Size, file.Reason, file.Path)
+		}
+		w.Flush()
+
+		verb := "Removed"
+		if compactDryRun {
+			verb = "Would remove"
+		}
+		fmt.Fprintf(out, "\n%s %d files (%d bytes).\n", verb, len(stale), total)
+		return nil
+	},
+}
+
+// formatAge shows a duration in whole days, or hours when under a day
+func formatAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+func init() {
+	compactCmd.Flags().DurationVar(&compactOlderThan, "older-than", 7*24*time.Hour, "Only remove files last modified longer ago than this")
+	compactCmd.Flags().BoolVar(&compactDryRun, "dry-run", false, "Show what would be removed without removing anything")
+	rootCmd.AddCommand(compactCmd)
+}
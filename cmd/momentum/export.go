@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/export"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput         string
+	exportNoConversation bool
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <entry>",
+	Short: "Export an entry as HTML",
+	Long: `Export a journal entry as a standalone HTML page. The entry's AI conversation,
+if it has one, is included as a transcript after the entry.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		filePath, err := journalManager.ResolveEntryPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry, err := journalManager.ReadEntry(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry: %w", err)
+		}
+
+		var messages []journal.Message
+		if !exportNoConversation {
+			messages, err = journalManager.LoadConversation(entry)
+			if err != nil {
+				return err
+			}
+		}
+
+		page, err := export.Document(entry, messages)
+		if err != nil {
+			return fmt.Errorf("failed to export journal entry: %w", err)
+		}
+
+		if exportOutput == "" {
+			fmt.Fprint(out, page)
+			return nil
+		}
+
+		if err := os.WriteFile(exportOutput, []byte(page), 0644); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		fmt.Fprintf(out, "Exported %s to %s\n", entry.FileName, exportOutput)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write the HTML to a file instead of stdout")
+	exportCmd.Flags().BoolVar(&exportNoConversation, "no-conversation", false, "Leave out the AI conversation transcript")
+	rootCmd.AddCommand(exportCmd)
+}
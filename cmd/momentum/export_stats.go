@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var exportStatsCSV string
+
+// exportStatsCmd represents the export-stats command
+var exportStatsCmd = &cobra.Command{
+	Use:   "export-stats",
+	Short: "Export per-entry statistics as CSV",
+	Long: `Write one CSV row per entry, oldest first, for charting elsewhere. The
+columns are:
+
+  date, time, file, words, goal, goal_unit, completed, minutes
+
+minutes is the time spent writing, and is empty for entries written before
+word count history was recorded. New columns are only ever added at the end.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		entries, err := journalManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+
+		goal := journal.StatsGoal(journalManager.Goal())
+		if exportStatsCSV == "" || exportStatsCSV == "-" {
+			return journal.WriteStatsCSV(entries, out, goal)
+		}
+
+		f, err := os.Create(exportStatsCSV)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", exportStatsCSV, err)
+		}
+		if err := journal.WriteStatsCSV(entries, f, goal); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", exportStatsCSV, err)
+		}
+		fmt.Fprintf(out, "Exported statistics for %d entries to %s\n", len(entries), exportStatsCSV)
+		return nil
+	},
+}
+
+func init() {
+	exportStatsCmd.Flags().StringVar(&exportStatsCSV, "csv", "", "Write the CSV to a file instead of stdout")
+	rootCmd.AddCommand(exportStatsCmd)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportCommand(t *testing.T) {
+	home := t.TempDir()
+	if _, err := runCommand(t, home, "Morning words", "new", "--stdin", "--date", "2024-03-05T09:30"); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, "journals", "*.md"))
+	if len(matches) != 1 {
+		t.Fatalf("want one entry, found %v", matches)
+	}
+	entry := filepath.Base(matches[0])
+
+	conversation := `[{"role":"user","content":"Any prompts?","time":"2024-03-05T09:31:00Z"},{"role":"assistant","content":"Describe the view.","time":"2024-03-05T09:32:00Z"}]`
+	conversationFile := strings.TrimSuffix(matches[0], ".md") + ".conversation.json"
+	if err := os.WriteFile(conversationFile, []byte(conversation), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, home, "", "export", entry)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	for _, want := range []string{"Morning words", `<section class="conversation">`, "Any prompts?", "Describe the view."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("export doesn't contain %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCommand(t, home, "", "export", entry, "--no-conversation")
+	if err != nil {
+		t.Fatalf("export --no-conversation: %v", err)
+	}
+	if !strings.Contains(out, "Morning words") || strings.Contains(out, "Any prompts?") {
+		t.Errorf("export --no-conversation got:\n%s", out)
+	}
+
+	file := filepath.Join(t.TempDir(), "entry.html")
+	out, err = runCommand(t, home, "", "export", entry, "-o", file)
+	if err != nil {
+		t.Fatalf("export -o: %v", err)
+	}
+	if !strings.Contains(out, "Exported "+entry+" to "+file) {
+		t.Errorf("export -o printed %q", out)
+	}
+	if data, err := os.ReadFile(file); err != nil || !strings.Contains(string(data), "Describe the view.") {
+		t.Errorf("exported file: %v, %q", err, data)
+	}
+}
+
+func TestExportStatsCommand(t *testing.T) {
+	home := t.TempDir()
+	if err := os.WriteFile(filepath.Join(home, "config.yaml"), []byte("journal:\n  word_count_goal: 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for date, text := range map[string]string{"2024-03-05T09:30": "Just two", "2024-03-06T07:15": "Three whole words"} {
+		if _, err := runCommand(t, home, text, "new", "--stdin", "--date", date); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	out, err := runCommand(t, home, "", "export-stats")
+	if err != nil {
+		t.Fatalf("export-stats: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	want := []string{
+		"date,time,file,words,goal,goal_unit,completed,minutes",
+		"2024-03-05,09:30,",
+		"2024-03-06,07:15,",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("export-stats wrote %d lines, want %d:\n%s", len(lines), len(want), out)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d = %q, want it to start %q", i+1, lines[i], prefix)
+		}
+	}
+	if !strings.HasSuffix(lines[1], ",2,3,words,false,") || !strings.HasSuffix(lines[2], ",3,3,words,true,") {
+		t.Errorf("rows don't carry the configured goal:\n%s", out)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+const (
+	graphWidth  = 60
+	graphHeight = 10
+)
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph <entry>",
+	Short: "Graph an entry's word count over time",
+	Long:  `Draw an ASCII graph of how an entry's word count grew during its writing sessions.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		filePath, err := journalManager.ResolveEntryPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry, err := journalManager.ReadEntry(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry: %w", err)
+		}
+
+		if len(entry.WordCountHistory) == 0 {
+			fmt.Fprintln(out, "No word count history recorded for this entry.")
+			return nil
+		}
+
+		fmt.Fprint(out, renderGraph(entry.WordCountHistory, graphWidth, graphHeight))
+		return nil
+	},
+}
+
+// scaleSamples maps samples onto at most width columns, returning the row
+// (0 = bottom, height-1 = top) for each column and the maximum word count.
+func scaleSamples(samples []journal.Sample, width, height int) ([]int, int) {
+	if len(samples) == 0 || width <= 0 || height <= 0 {
+		return nil, 0
+	}
+
+	maxWords := 0
+	for _, s := range samples {
+		if s.WordCount > maxWords {
+			maxWords = s.WordCount
+		}
+	}
+
+	columns := width
+	if len(samples) < columns {
+		columns = len(samples)
+	}
+
+	rows := make([]int, columns)
+	for col := range rows {
+		// Pick the sample proportionally positioned for this column
+		idx := 0
+		if columns > 1 {
+			idx = col * (len(samples) - 1) / (columns - 1)
+		}
+		if maxWords > 0 {
+			rows[col] = samples[idx].WordCount * (height - 1) / maxWords
+		}
+	}
+
+	return rows, maxWords
+}
+
+// renderGraph draws the samples as an ASCII chart with a labelled y-axis
+func renderGraph(samples []journal.Sample, width, height int) string {
+	rows, maxWords := scaleSamples(samples, width, height)
+	labelWidth := len(fmt.Sprint(maxWords))
+
+	var b strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		label := ""
+		switch row {
+		case height - 1:
+			label = fmt.Sprint(maxWords)
+		case 0:
+			label = "0"
+		}
+		fmt.Fprintf(&b, "%*s |", labelWidth, label)
+		for _, r := range rows {
+			if r == row {
+				b.WriteByte('*')
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	fmt.Fprintf(&b, "%*s +%s\n", labelWidth, "", strings.Repeat("-", len(rows)))
+	fmt.Fprintf(&b, "%*s  %s -> %s\n", labelWidth, "",
+		samples[0].Time.Format("15:04"),
+		samples[len(samples)-1].Time.Format("15:04"))
+
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+)
+
+// samplesOf returns samples a minute apart with the given word counts
+func samplesOf(counts ...int) []journal.Sample {
+	t0 := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	samples := make([]journal.Sample, len(counts))
+	for i, n := range counts {
+		samples[i] = journal.Sample{Time: t0.Add(time.Duration(i) * time.Minute), WordCount: n}
+	}
+	return samples
+}
+
+func TestScaleSamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []journal.Sample
+		width    int
+		height   int
+		wantRows []int
+		wantMax  int
+	}{
+		{name: "no samples", samples: nil, width: 60, height: 10, wantRows: nil, wantMax: 0},
+		{name: "no width", samples: samplesOf(1, 2), width: 0, height: 10, wantRows: nil, wantMax: 0},
+		{name: "no height", samples: samplesOf(1, 2), width: 60, height: 0, wantRows: nil, wantMax: 0},
+		{name: "fewer samples than the width", samples: samplesOf(0, 5, 10), width: 60, height: 10, wantRows: []int{0, 4, 9}, wantMax: 10},
+		{name: "single sample is the top", samples: samplesOf(7), width: 60, height: 10, wantRows: []int{9}, wantMax: 7},
+		{name: "all zero stays on the bottom", samples: samplesOf(0, 0, 0), width: 60, height: 10, wantRows: []int{0, 0, 0}, wantMax: 0},
+		{name: "more samples than the width", samples: samplesOf(0, 1, 2, 3, 4), width: 3, height: 5, wantRows: []int{0, 2, 4}, wantMax: 4},
+		{name: "shrinking count", samples: samplesOf(8, 2), width: 60, height: 5, wantRows: []int{4, 1}, wantMax: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, maxWords := scaleSamples(tt.samples, tt.width, tt.height)
+			if !slices.Equal(rows, tt.wantRows) || maxWords != tt.wantMax {
+				t.Errorf("scaleSamples = %v, %d, want %v, %d", rows, maxWords, tt.wantRows, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestRenderGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []journal.Sample
+		want    string
+	}{
+		{
+			name:    "rising",
+			samples: samplesOf(0, 4),
+			want: "4 | *\n" +
+				"  |  \n" +
+				"0 |* \n" +
+				"  +--\n" +
+				"   09:30 -> 09:31\n",
+		},
+		{
+			name:    "all zero",
+			samples: samplesOf(0, 0, 0),
+			want: "0 |   \n" +
+				"  |   \n" +
+				"0 |***\n" +
+				"  +---\n" +
+				"   09:30 -> 09:32\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderGraph(tt.samples, 10, 3); got != tt.want {
+				t.Errorf("renderGraph =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphCommand(t *testing.T) {
+	home := t.TempDir()
+	if _, err := runCommand(t, home, "one two three", "new", "--stdin"); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, "journals", "*.md"))
+	if len(matches) != 1 {
+		t.Fatalf("want one entry, found %v", matches)
+	}
+	entry := filepath.Base(matches[0])
+
+	out, err := runCommand(t, home, "", "graph", entry)
+	if err != nil {
+		t.Fatalf("graph: %v", err)
+	}
+	if !strings.Contains(out, "No word count history recorded") {
+		t.Errorf("without history got %q", out)
+	}
+
+	history := `[{"time":"2024-03-05T09:30:00Z","word_count":0},{"time":"2024-03-05T09:31:00Z","word_count":3}]`
+	historyFile := strings.TrimSuffix(matches[0], ".md") + ".history.json"
+	if err := os.WriteFile(historyFile, []byte(history), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = runCommand(t, home, "", "graph", entry)
+	if err != nil {
+		t.Fatalf("graph: %v", err)
+	}
+	if want := renderGraph(samplesOf(0, 3), graphWidth, graphHeight); out != want {
+		t.Errorf("graph =\n%s\nwant\n%s", out, want)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initNonInteractive bool
+	initProvider       string
+	initModel          string
+	initEndpoint       string
+	initAPIKey         string
+	initStorageDir     string
+	initGoal           int
+)
+
+// initCmd represents the init command
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up the configuration file",
+	Long: `Walk through the main settings (AI provider, model, endpoint, API key,
+storage directory and word goal) and write them to the config file. Press
+Enter to keep the value shown in brackets.
+
+Settings given as flags are not asked for. With --non-interactive nothing is
+asked, and settings not given as flags keep their current values.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var p *prompter
+		if !initNonInteractive {
+			p = &prompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.OutOrStdout()}
+		}
+
+		if err := runInitWizard(cmd, cfg, p); err != nil {
+			return err
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid settings: %w", err)
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", config.ConfigPath())
+		return nil
+	},
+}
+
+// runInitWizard fills in c from the flags that were set and, when p is not
+// nil, by asking for the rest
+func runInitWizard(cmd *cobra.Command, c *config.Config, p *prompter) error {
+	flags := cmd.Flags()
+
+	previousProvider := c.LLM.Provider
+	if err := askString(flags.Changed("provider"), initProvider, p,
+		"AI provider ("+strings.Join(config.Providers, "/")+")", &c.LLM.Provider); err != nil {
+		return err
+	}
+	// The Ollama endpoint makes no sense for OpenRouter; an empty one
+	// falls back to the provider's default
+	if c.LLM.Provider != previousProvider && !flags.Changed("endpoint") {
+		c.LLM.Endpoint = ""
+		if c.LLM.Provider == "ollama" {
+			c.LLM.Endpoint = config.DefaultConfig().LLM.Endpoint
+		}
+	}
+
+	if err := askString(flags.Changed("model"), initModel, p, "Model", &c.LLM.ModelName); err != nil {
+		return err
+	}
+	if err := askString(flags.Changed("endpoint"), initEndpoint, p, "Endpoint", &c.LLM.Endpoint); err != nil {
+		return err
+	}
+	if c.LLM.Provider == "openrouter" || c.LLM.Provider == "auto" {
+		if err := askString(flags.Changed("api-key"), initAPIKey, p, "OpenRouter API key", &c.LLM.APIKey); err != nil {
+			return err
+		}
+	}
+	if err := askString(flags.Changed("storage-dir"), initStorageDir, p, "Journal directory", &c.Journal.StorageDir); err != nil {
+		return err
+	}
+
+	goal := strconv.Itoa(c.Journal.WordCountGoal)
+	if err := askString(flags.Changed("goal"), strconv.Itoa(initGoal), p, "Daily word goal", &goal); err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(goal)
+	if err != nil {
+		return fmt.Errorf("word goal must be a number, got %q", goal)
+	}
+	c.Journal.WordCountGoal = n
+
+	return nil
+}
+
+// askString sets *value from a flag if one was given, otherwise asks for it
+// when prompting. Without a flag or prompter the current value is kept.
+func askString(fromFlag bool, flagValue string, p *prompter, label string, value *string) error {
+	if fromFlag {
+		*value = flagValue
+		return nil
+	}
+	if p == nil {
+		return nil
+	}
+	answer, err := p.ask(label, *value)
+	if err != nil {
+		return err
+	}
+	*value = answer
+	return nil
+}
+
+// prompter asks questions one line at a time, which works the same whether
+// or not stdin is a terminal
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask prints the question with its current value and returns the answer,
+// or the current value if the answer is empty
+func (p *prompter) ask(label, current string) (string, error) {
+	fmt.Fprintf(p.out, "%s [%s]: ", label, current)
+	line, err := p.in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return current, nil
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Don't prompt; only apply the settings given as flags")
+	initCmd.Flags().StringVar(&initProvider, "provider", "", "AI provider (ollama, openrouter, or auto)")
+	initCmd.Flags().StringVar(&initModel, "model", "", "Model name")
+	initCmd.Flags().StringVar(&initEndpoint, "endpoint", "", "API endpoint")
+	initCmd.Flags().StringVar(&initAPIKey, "api-key", "", "OpenRouter API key")
+	initCmd.Flags().StringVar(&initStorageDir, "storage-dir", "", "Directory to store journal entries in")
+	initCmd.Flags().IntVar(&initGoal, "goal", 0, "Daily word goal")
+	rootCmd.AddCommand(initCmd)
+}
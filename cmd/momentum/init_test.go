@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// savedConfig reads back the config file momentum wrote under home
+func savedConfig(t *testing.T, home string) *config.Config {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(home, "config.yaml"))
+	if err != nil {
+		t.Fatalf("config wasn't written: %v", err)
+	}
+	c := config.DefaultConfig()
+	if err := yaml.Unmarshal(data, c); err != nil {
+		t.Fatalf("parsing config: %v", err)
+	}
+	return c
+}
+
+func TestInitNonInteractive(t *testing.T) {
+	home := t.TempDir()
+	storage := filepath.Join(home, "pages")
+
+	out, err := runCommand(t, home, "", "init", "--non-interactive",
+		"--provider", "openrouter", "--model", "some/model", "--api-key", "sk-test",
+		"--storage-dir", storage, "--goal", "500")
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if !strings.Contains(out, "Wrote "+filepath.Join(home, "config.yaml")) {
+		t.Errorf("output %q doesn't name the config file", out)
+	}
+
+	c := savedConfig(t, home)
+	if err := c.Validate(); err != nil {
+		t.Errorf("written config is invalid: %v", err)
+	}
+	if c.LLM.Provider != "openrouter" || c.LLM.ModelName != "some/model" || c.LLM.APIKey != "sk-test" {
+		t.Errorf("llm = %s %s %s, want the flags", c.LLM.Provider, c.LLM.ModelName, c.LLM.APIKey)
+	}
+	// The Ollama endpoint is dropped on switching to OpenRouter
+	if c.LLM.Endpoint != "" {
+		t.Errorf("endpoint = %q, want the provider default", c.LLM.Endpoint)
+	}
+	if c.Journal.StorageDir != storage || c.Journal.WordCountGoal != 500 {
+		t.Errorf("journal = %s, goal %d; want %s, 500", c.Journal.StorageDir, c.Journal.WordCountGoal, storage)
+	}
+
+	// Settings not given as flags keep their values
+	if _, err := runCommand(t, home, "", "init", "--non-interactive", "--goal", "1000"); err != nil {
+		t.Fatalf("init --goal: %v", err)
+	}
+	if c := savedConfig(t, home); c.LLM.ModelName != "some/model" || c.Journal.WordCountGoal != 1000 {
+		t.Errorf("after a second init: model %q, goal %d; want some/model, 1000", c.LLM.ModelName, c.Journal.WordCountGoal)
+	}
+}
+
+func TestInitRejectsInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "unknown provider", args: []string{"--provider", "gpt"}, wantErr: "unknown llm.provider"},
+		{name: "negative goal", args: []string{"--goal", "-5"}, wantErr: "word_count_goal must not be negative"},
+		{name: "empty storage dir", args: []string{"--storage-dir", ""}, wantErr: "storage_dir must be set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			args := append([]string{"init", "--non-interactive"}, tt.args...)
+			_, err := runCommand(t, home, "", args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInitPrompts(t *testing.T) {
+	home := t.TempDir()
+	// Provider, model, endpoint, storage dir, goal; blank keeps the default
+	answers := "ollama\nmistral\n\n\n900\n"
+	out, err := runCommand(t, home, answers, "init")
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if strings.Contains(out, "API key") {
+		t.Errorf("asked for an API key for Ollama:\n%s", out)
+	}
+	c := savedConfig(t, home)
+	defaults := config.DefaultConfig()
+	if c.LLM.ModelName != "mistral" || c.LLM.Endpoint != defaults.LLM.Endpoint || c.Journal.WordCountGoal != 900 {
+		t.Errorf("model %q, endpoint %q, goal %d; want mistral, the default endpoint, 900", c.LLM.ModelName, c.LLM.Endpoint, c.Journal.WordCountGoal)
+	}
+}
@@ -1,56 +1,251 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal" // Adjusted import path
 	"github.com/spf13/cobra"
 )
 
+var (
+	listShowErrors bool
+	listGroupBy    string
+	listJSON       bool
+	listLimit      int
+	listPage       int
+	listContains   string
+	listTree       bool
+	listWatch      bool
+	listInterval   time.Duration
+	listStreak     bool
+)
+
 // listCmd represents the list command
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List journal entries",
 	Long:  `List all journal entries with basic information.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
 		// Create journal manager
-		journalManager, err := journal.NewManager(cfg, logger)
+		journalManager, err := newJournalManager()
 		if err != nil {
 			return fmt.Errorf("failed to create journal manager: %w", err)
 		}
 
-		// List entries
-		entries, err := journalManager.ListEntries()
+		if listTree && listGroupBy != "" {
+			return fmt.Errorf("--tree and --group-by can't be used together")
+		}
+
+		if listJSON && listStreak {
+			return fmt.Errorf("--json and --completed-streak can't be used together")
+		}
+
+		if listWatch {
+			if listJSON {
+				return fmt.Errorf("--watch and --json can't be used together")
+			}
+			return watchList(cmd.Context(), out, journalManager)
+		}
+		return renderList(out, journalManager)
+	},
+}
+
+// renderList writes the entry list once, as selected by the list flags
+func renderList(out io.Writer, journalManager *journal.Manager) error {
+	// List entries
+	entries, err := journalManager.ListEntries(journal.IncludePartial(listShowErrors))
+	if err != nil {
+		return fmt.Errorf("failed to list journal entries: %w", err)
+	}
+
+	// Streaks count every day, whatever is filtered out of the list
+	all := entries
+	entries = journal.Containing(entries, listContains)
+
+	// Page through the entries oldest first
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	total := len(entries)
+	entries, first := journal.Paginate(entries, listPage, listLimit)
+
+	var groups []journal.EntryGroup
+	if listGroupBy != "" {
+		period, err := journal.ParsePeriod(listGroupBy)
 		if err != nil {
-			return fmt.Errorf("failed to list journal entries: %w", err)
+			return err
 		}
+		groups = journal.GroupEntries(entries, period)
+	}
 
-		if len(entries) == 0 {
-			fmt.Println("No journal entries found.")
+	if listJSON {
+		// The JSON carries each entry's content
+		for _, entry := range entries {
+			if err := entry.LoadContent(); err != nil {
+				return err
+			}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if listGroupBy != "" {
+			if groups == nil {
+				groups = []journal.EntryGroup{}
+			}
+			return enc.Encode(groups)
+		}
+		if entries == nil {
+			entries = []*journal.JournalEntry{}
+		}
+		return enc.Encode(entries)
+	}
+
+	if total == 0 {
+		if listContains != "" {
+			fmt.Fprintf(out, "No journal entries contain %q.\n", listContains)
 			return nil
 		}
+		fmt.Fprintln(out, "No journal entries found.")
+		return nil
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "Page %d is past the last entry (%d entries, %d per page).\n", listPage, total, listLimit)
+		return nil
+	}
+
+	// Create a tab writer for nice formatting
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if listTree {
+		printTree(w, journalManager, entries)
+	} else {
+		printTable(w, journalManager, entries, groups)
+	}
+	w.Flush()
 
-		// Create a tab writer for nice formatting
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "DATE\tTIME\tWORDS\tCOMPLETE\tFILE")
-		fmt.Fprintln(w, "----\t----\t-----\t--------\t----")
+	if len(entries) < total {
+		fmt.Fprintf(out, "\nshowing %d–%d of %d\n", first+1, first+len(entries), total)
+	}
 
+	if listShowErrors {
+		printProblemEntries(out, entries)
+	}
+
+	if listStreak {
+		current, longest := journal.ComputeCompletedStreak(all, time.Now())
+		fmt.Fprintf(out, "\nCompleted streak: %s (longest: %s)\n", days(current), days(longest))
+	}
+	return nil
+}
+
+// printTable writes the entries as a table, under group headers if grouped
+func printTable(w io.Writer, manager *journal.Manager, entries []*journal.JournalEntry, groups []journal.EntryGroup) {
+	fmt.Fprintln(w, "DATE\tTIME\tWORDS\tPROGRESS\tCOMPLETE\tFILE")
+	fmt.Fprintln(w, "----\t----\t-----\t--------\t--------\t----")
+
+	if groups == nil {
 		for _, entry := range entries {
-			fmt.Fprintf(w, "%s\t%s\t%d\t%v\t%s\n",
-				entry.CreatedAt.Format("2006-01-02"),
-				entry.CreatedAt.Format("15:04"),
-				entry.WordCount,
-				entry.IsCompleted,
-				entry.FileName)
+			printEntryRow(w, manager, entry, "")
+		}
+		return
+	}
+	for _, group := range groups {
+		fmt.Fprintf(w, "%s\t\t\t\t\t\n", group.Label)
+		for _, entry := range group.Entries {
+			printEntryRow(w, manager, entry, "  ")
 		}
+	}
+}
 
-		w.Flush()
-		return nil
-	},
+// printTree writes the entries, oldest first, under year, month and day
+// headers
+func printTree(w io.Writer, manager *journal.Manager, entries []*journal.JournalEntry) {
+	var year, month, day string
+	for _, entry := range entries {
+		at := entry.CreatedAt
+		if y := at.Format("2006"); y != year {
+			year, month, day = y, "", ""
+			fmt.Fprintf(w, "%s\t\t\t\n", year)
+		}
+		if mo := at.Format("01 January"); mo != month {
+			month, day = mo, ""
+			fmt.Fprintf(w, "  %s\t\t\t\n", month)
+		}
+		if d := at.Format("02 Monday"); d != day {
+			day = d
+			fmt.Fprintf(w, "    %s\t\t\t\n", day)
+		}
+		fmt.Fprintf(w, "      %s\t%s\t%s\t%s\n",
+			at.Format("15:04"),
+			formatProgress(manager.Progress(entry)),
+			completeMark(entry),
+			entry.FileName)
+	}
+}
+
+// completeMark flags entries that met their goal
+func completeMark(entry *journal.JournalEntry) string {
+	if entry.IsCompleted {
+		return "✓"
+	}
+	return ""
+}
+
+// printEntryRow writes one entry as a table row, indenting the date column
+// when the entry sits under a group header
+func printEntryRow(w io.Writer, manager *journal.Manager, entry *journal.JournalEntry, indent string) {
+	fmt.Fprintf(w, "%s%s\t%s\t%d\t%s\t%v\t%s\n",
+		indent,
+		entry.CreatedAt.Format("2006-01-02"),
+		entry.CreatedAt.Format("15:04"),
+		entry.WordCount,
+		formatProgress(manager.Progress(entry)),
+		entry.IsCompleted,
+		entry.FileName)
+}
+
+// formatProgress shows progress against the goal, e.g. "412/750 (54%)"
+func formatProgress(current, goal int) string {
+	if goal <= 0 {
+		return fmt.Sprintf("%d/-", current)
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", current, goal, current*100/goal)
+}
+
+// printProblemEntries lists entries that could only be partly parsed
+func printProblemEntries(out io.Writer, entries []*journal.JournalEntry) {
+	var problems []*journal.JournalEntry
+	for _, entry := range entries {
+		if entry.Partial {
+			problems = append(problems, entry)
+		}
+	}
+	if len(problems) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Problem files:")
+	for _, entry := range problems {
+		fmt.Fprintf(out, "  %s: %s\n", entry.FileName, entry.ParseError)
+	}
 }
 
 func init() {
+	listCmd.Flags().BoolVar(&listShowErrors, "show-errors", false, "Include entries with malformed frontmatter and list the problems")
+	listCmd.Flags().StringVar(&listGroupBy, "group-by", "", "Group entries under day, week, month or year headers")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print entries as JSON (nested under their groups with --group-by)")
+	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Show at most N entries per page (0 shows all)")
+	listCmd.Flags().BoolVar(&listTree, "tree", false, "Show entries under year, month and day headings")
+	listCmd.Flags().StringVar(&listContains, "contains", "", "Only list entries whose text includes this, ignoring case")
+	listCmd.Flags().IntVar(&listPage, "page", 1, "Page to show with --limit, counting from 1")
+	listCmd.Flags().BoolVar(&listStreak, "completed-streak", false, "Also show the current and longest runs of days with a completed entry")
+	listCmd.Flags().BoolVar(&listWatch, "watch", false, "Keep the list on screen, redrawing it when entries change until interrupted")
+	listCmd.Flags().DurationVar(&listInterval, "interval", 30*time.Second, "With --watch, also redraw this often, e.g. as the day changes")
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFormatProgress(t *testing.T) {
+	tests := []struct {
+		current, goal int
+		want          string
+	}{
+		{current: 412, goal: 750, want: "412/750 (54%)"},
+		{current: 0, goal: 750, want: "0/750 (0%)"},
+		{current: 750, goal: 750, want: "750/750 (100%)"},
+		{current: 900, goal: 750, want: "900/750 (120%)"},
+		{current: 12, goal: 0, want: "12/-"},
+	}
+	for _, tt := range tests {
+		if got := formatProgress(tt.current, tt.goal); got != tt.want {
+			t.Errorf("formatProgress(%d, %d) = %q, want %q", tt.current, tt.goal, got, tt.want)
+		}
+	}
+}
+
+func TestListProgress(t *testing.T) {
+	home := t.TempDir()
+	for _, date := range []string{"2024-03-05T09:30", "2024-03-06T09:30"} {
+		if _, err := runCommand(t, home, "one two three", "new", "--stdin", "--date", date); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	// The second entry sets its own goal in its frontmatter
+	path := filepath.Join(home, "journals", "2024-03-06T09:30-morning-pages.md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), "---\n", "---\nword_count_goal: 4\n", 1))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, home, "", "list")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	progress := strings.Index(lines[0], "PROGRESS")
+	if progress < 0 {
+		t.Fatalf("no PROGRESS column in %q", lines[0])
+	}
+	want := map[string]string{
+		"2024-03-05": "3/750 (0%)",
+		"2024-03-06": "3/4 (75%)",
+	}
+	for _, line := range lines[2:] {
+		date, _, _ := strings.Cut(line, " ")
+		if want[date] == "" {
+			continue
+		}
+		if !strings.HasPrefix(line[progress:], want[date]) {
+			t.Errorf("%s: progress column %q, want %q", date, line[progress:], want[date])
+		}
+		delete(want, date)
+	}
+	if len(want) > 0 {
+		t.Errorf("entries missing from list:\n%s", out)
+	}
+}
+
+func TestListGroupBy(t *testing.T) {
+	home := t.TempDir()
+	for _, date := range []string{"2024-02-29T23:30", "2024-01-31T23:30", "2024-03-01T00:10"} {
+		if _, err := runCommand(t, home, "words", "new", "--stdin", "--date", date); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	out, err := runCommand(t, home, "", "list", "--group-by", "month")
+	if err != nil {
+		t.Fatalf("list --group-by month: %v", err)
+	}
+	var rows []string
+	for _, line := range strings.Split(out, "\n")[2:] {
+		if line = strings.TrimRight(line, " "); line != "" {
+			rows = append(rows, strings.Fields(line)[0])
+		}
+	}
+	want := []string{"2024-01", "2024-01-31", "2024-02", "2024-02-29", "2024-03", "2024-03-01"}
+	if strings.Join(rows, " ") != strings.Join(want, " ") {
+		t.Errorf("rows %v, want %v:\n%s", rows, want, out)
+	}
+	if !strings.Contains(out, "\n  2024-02-29") {
+		t.Errorf("entries aren't indented under their month:\n%s", out)
+	}
+
+	out, err = runCommand(t, home, "", "list", "--group-by", "month", "--json")
+	if err != nil {
+		t.Fatalf("list --group-by month --json: %v", err)
+	}
+	var groups []struct {
+		Label   string `json:"label"`
+		Entries []struct {
+			FileName string `json:"file_name"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(out), &groups); err != nil {
+		t.Fatalf("decoding %q: %v", out, err)
+	}
+	if len(groups) != 3 || groups[1].Label != "2024-02" || len(groups[1].Entries) != 1 ||
+		groups[1].Entries[0].FileName != "2024-02-29T23:30-morning-pages.md" {
+		t.Errorf("JSON groups = %+v", groups)
+	}
+
+	if _, err := runCommand(t, home, "", "list", "--group-by", "fortnight"); err == nil {
+		t.Error("expected an unknown period to be rejected")
+	}
+}
+
+func TestListContains(t *testing.T) {
+	home := t.TempDir()
+	for date, text := range map[string]string{
+		"2024-03-05T09:30": "Planted Tomatoes by the fence",
+		"2024-03-06T09:30": "A long day of meetings",
+		"2024-03-07T09:30": "Left work early for the tomato sale",
+	} {
+		if _, err := runCommand(t, home, text, "new", "--stdin", "--date", date); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	tests := []struct {
+		substr   string
+		want     []string
+		wantNone bool
+	}{
+		{substr: "tomato", want: []string{"2024-03-05", "2024-03-07"}},
+		{substr: "MEETINGS", want: []string{"2024-03-06"}},
+		{substr: "zebra", wantNone: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.substr, func(t *testing.T) {
+			out, err := runCommand(t, home, "", "list", "--contains", tt.substr)
+			if err != nil {
+				t.Fatalf("list --contains: %v", err)
+			}
+			if tt.wantNone {
+				if want := fmt.Sprintf("No journal entries contain %q.", tt.substr); !strings.Contains(out, want) {
+					t.Errorf("list printed %q, want %q", out, want)
+				}
+				return
+			}
+			for _, date := range []string{"2024-03-05", "2024-03-06", "2024-03-07"} {
+				if got, want := strings.Contains(out, date), slices.Contains(tt.want, date); got != want {
+					t.Errorf("%s listed = %v, want %v:\n%s", date, got, want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestListTree(t *testing.T) {
+	home := t.TempDir()
+	for _, date := range []string{"2024-02-28T21:00", "2024-03-05T07:15", "2024-03-05T09:30"} {
+		if _, err := runCommand(t, home, "words words", "new", "--stdin", "--date", date); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+	// File one entry away in a dated folder; it is still listed
+	journals := filepath.Join(home, "journals")
+	nested := filepath.Join(journals, "2024", "02")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	name := "2024-02-28T21:00-morning-pages.md"
+	if err := os.Rename(filepath.Join(journals, name), filepath.Join(nested, name)); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, home, "", "list", "--tree")
+	if err != nil {
+		t.Fatalf("list --tree: %v", err)
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	want := []string{
+		"2024",
+		"02 February",
+		"28 Wednesday",
+		"21:00 2/750 (0%) 2024-02-28T21:00-morning-pages.md",
+		"03 March",
+		"05 Tuesday",
+		"07:15 2/750 (0%) 2024-03-05T07:15-morning-pages.md",
+		"09:30 2/750 (0%) 2024-03-05T09:30-morning-pages.md",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("list --tree printed:\n%s\nwant:\n%s", out, strings.Join(want, "\n"))
+	}
+
+	if _, err := runCommand(t, home, "", "list", "--tree", "--group-by", "day"); err == nil {
+		t.Error("--tree with --group-by succeeded")
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/fsnotify/fsnotify"
+)
+
+// clearScreen moves the cursor to the top left and clears the terminal
+const clearScreen = "\x1b[H\x1b[2J"
+
+// watchSettle is how long a burst of file events has to go quiet before
+// the list is redrawn, since a save touches several files
+const watchSettle = 200 * time.Millisecond
+
+// watchList redraws the entry list whenever a file in the journal
+// directory changes, and every --interval, until interrupted
+func watchList(ctx context.Context, out io.Writer, manager *journal.Manager) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch journal directory: %w", err)
+	}
+	defer watcher.Close()
+	if err := watchDirs(watcher, cfg.Journal.StorageDir); err != nil {
+		return fmt.Errorf("failed to watch journal directory: %w", err)
+	}
+
+	ticker := time.NewTicker(listInterval)
+	defer ticker.Stop()
+
+	for {
+		drawList(out, manager)
+
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(out)
+			return nil
+		case <-ticker.C:
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			settle(watcher.Events)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("failed to watch journal directory: %w", err)
+		}
+	}
+}
+
+// drawList clears the screen and renders the list, with a footer saying
+// when it was drawn. The list is rendered before clearing so the screen
+// doesn't flicker, and errors are shown in its place so watching carries on.
+func drawList(out io.Writer, manager *journal.Manager) {
+	var buf bytes.Buffer
+	if err := renderList(&buf, manager); err != nil {
+		buf.Reset()
+		fmt.Fprintf(&buf, "Error: %v\n", err)
+	}
+	fmt.Fprintf(&buf, "\nUpdated %s. Press Ctrl+C to stop.\n", time.Now().Format("15:04:05"))
+	io.WriteString(out, clearScreen+buf.String())
+}
+
+// settle waits until events have stopped arriving for watchSettle
+func settle(events <-chan fsnotify.Event) {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-time.After(watchSettle):
+			return
+		}
+	}
+}
+
+// watchDirs adds dir and the directories under it to the watcher, leaving
+// out hidden ones like .snapshots
+func watchDirs(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe to read while watchList writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// listFixture creates an entry in a fresh journal and returns the home
+// directory, with the config loaded for it
+func listFixture(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	if _, err := runCommand(t, home, "Morning words", "new", "--stdin", "--date", "2024-03-05T09:30"); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	return home
+}
+
+func TestRenderList(t *testing.T) {
+	home := listFixture(t)
+	want, err := runCommand(t, home, "", "list")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	manager, err := newJournalManager()
+	if err != nil {
+		t.Fatalf("newJournalManager: %v", err)
+	}
+	var out bytes.Buffer
+	if err := renderList(&out, manager); err != nil {
+		t.Fatalf("renderList: %v", err)
+	}
+	if out.String() != want {
+		t.Errorf("renderList wrote:\n%s\nwant the list command's output:\n%s", out.String(), want)
+	}
+	if !strings.Contains(out.String(), "2024-03-05T09:30-morning-pages.md") {
+		t.Errorf("entry missing from:\n%s", out.String())
+	}
+}
+
+func TestDrawList(t *testing.T) {
+	listFixture(t)
+	manager, err := newJournalManager()
+	if err != nil {
+		t.Fatalf("newJournalManager: %v", err)
+	}
+
+	var out bytes.Buffer
+	drawList(&out, manager)
+	got := out.String()
+	if !strings.HasPrefix(got, clearScreen) || strings.Count(got, clearScreen) != 1 {
+		t.Errorf("draw doesn't clear the screen once first: %q", got)
+	}
+	for _, want := range []string{"2024-03-05T09:30-morning-pages.md", "Press Ctrl+C to stop."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("draw doesn't contain %q:\n%s", want, got)
+		}
+	}
+
+	// An error takes the list's place, so watching can carry on
+	listGroupBy = "fortnight"
+	t.Cleanup(func() { listGroupBy = "" })
+	out.Reset()
+	drawList(&out, manager)
+	if got := out.String(); !strings.Contains(got, "Error: ") || !strings.Contains(got, "Press Ctrl+C to stop.") {
+		t.Errorf("draw with an error:\n%s", got)
+	}
+}
+
+func TestWatchList(t *testing.T) {
+	home := listFixture(t)
+	manager, err := newJournalManager()
+	if err != nil {
+		t.Fatalf("newJournalManager: %v", err)
+	}
+	listInterval = time.Hour // Only file changes redraw
+	t.Cleanup(func() { listInterval = 30 * time.Second })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() { done <- watchList(ctx, &out, manager) }()
+
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(out.String(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %q in:\n%s", want, out.String())
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	waitFor("2024-03-05T09:30-morning-pages.md")
+
+	// A new entry file redraws the list
+	added := filepath.Join(home, "journals", "2024-03-06T09:30-morning-pages.md")
+	if err := os.WriteFile(added, []byte("Evening words"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("2024-03-06T09:30-morning-pages.md")
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("watchList: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchList didn't stop when cancelled")
+	}
+}
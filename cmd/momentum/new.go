@@ -2,65 +2,212 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log" // Use standard log for fatal errors from Bubble Tea
+	"time"
 
 	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal" // Adjusted import path
-	"github.com/ZachBeta/momentum_journal_nvim_go/internal/tui"     // Import the new TUI package
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/tui" // Import the new TUI package
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
+var (
+	newDryRun bool
+	newStdin  bool
+	newNoTUI  bool
+	newDate   string
+)
+
+// entryDateLayouts are the formats --date accepts
+var entryDateLayouts = []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// parseEntryDate reads a --date value in local time. A date without a time
+// of day takes the one from now, so a backfilled entry sorts like one
+// written at this time of day
+func parseEntryDate(value string, now time.Time) (time.Time, error) {
+	for _, layout := range entryDateLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" {
+			t = time.Date(t.Year(), t.Month(), t.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.Local)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --date %q (want YYYY-MM-DD or YYYY-MM-DDTHH:MM)", value)
+}
+
 // newCmd represents the new command
 var newCmd = &cobra.Command{
 	Use:   "new",
 	Short: "Start a new journal entry",
 	Long: `Create a new journal entry and open the Momentum Journal interface.
-This command starts a new writing session with the specified settings.`,
+This command starts a new writing session with the specified settings.
+
+With --no-tui the entry, header and all, is created and its path printed
+without opening the interface, so a script or cron job can prepare the day's
+entry for writing later.
+
+--date backdates the entry, e.g. to catch up on a missed day; its file name,
+header and created_at all use that date.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if newStdin && newNoTUI {
+			return fmt.Errorf("--stdin and --no-tui can't be used together")
+		}
+
 		// Create journal manager
-		journalManager, err := journal.NewManager(cfg, logger)
+		journalManager, err := newJournalManager(journal.WithDryRun(newDryRun))
 		if err != nil {
 			// Log error using zap before returning
 			logger.Error("Failed to create journal manager", zap.Error(err))
 			return fmt.Errorf("failed to create journal manager: %w", err)
 		}
 
+		createdAt := time.Now()
+		if newDate != "" {
+			if createdAt, err = parseEntryDate(newDate, createdAt); err != nil {
+				return err
+			}
+		}
 		// Create new entry
-		// TBD: We might want to pass the entry or its path to the TUI model later
-		_, err = journalManager.CreateEntry()
+		entry, warning, err := journalManager.CreateEntryAt(createdAt)
 		if err != nil {
 			// Log error using zap before returning
 			logger.Error("Failed to create journal entry", zap.Error(err))
 			return fmt.Errorf("failed to create journal entry: %w", err)
 		}
 
-		// Initialize the TUI model
-		tuiModel := tui.InitialModel()
+		// Nothing was written, so there is no entry to edit
+		if newDryRun {
+			fmt.Fprintf(cmd.OutOrStdout(), "Dry run: would create %s\n", entry.FilePath)
+			return nil
+		}
 
-		// Create and run the Bubble Tea program
-		// Using tea.WithAltScreen() provides a dedicated screen for the TUI
-		// Using tea.WithMouseCellMotion() enables mouse support (optional but often useful)
-		p := tea.NewProgram(tuiModel, tea.WithAltScreen()) //, tea.WithMouseCellMotion())
+		// Piped content goes straight into the entry without the TUI
+		if newStdin {
+			return writeEntryFromStdin(cmd, journalManager, entry)
+		}
 
-		logger.Info("Starting Momentum Journal TUI...")
+		// The entry is on disk with its header, ready to open later
+		if newNoTUI {
+			fmt.Fprintln(cmd.OutOrStdout(), entry.FilePath)
+			return nil
+		}
 
-		// Run the program. This blocks until the program exits.
-		if _, err := p.Run(); err != nil {
-			// Log the error from Bubble Tea using standard log or zap
-			logger.Error("Error running Bubble Tea program", zap.Error(err))
-			// Use standard log for fatal errors that terminate the app immediately after TUI fails
-			log.Fatalf("Alas, there's been an error: %v", err)
-			// The return below might not be reached if log.Fatalf exits, but good practice.
-			return fmt.Errorf("error running TUI: %w", err)
+		if err := runTUI(cmd.OutOrStdout(), journalManager, entry, tui.WithNotice(warning)); err != nil {
+			return err
 		}
 
-		logger.Info("Momentum Journal TUI finished.")
 		// TBD: Perform any cleanup after the TUI exits if needed.
 		return nil
 	},
 }
 
+// runTUI opens the entry in the Momentum Journal interface and, once the
+// session ends, writes its summary to out and records it in git if the
+// journal lives in a repository
+func runTUI(out io.Writer, journalManager *journal.Manager, entry *journal.JournalEntry, opts ...tui.Option) error {
+	// Create the LLM client; the TUI still works without AI assistance
+	var llmClient llm.Client
+	if cfg.LLM.Enabled {
+		var err error
+		llmClient, err = llm.NewClient(cfg, logger)
+		if err != nil {
+			logger.Warn("AI assistance disabled", zap.Error(err))
+		}
+	}
+
+	// Initialize the TUI model
+	tuiModel := tui.InitialModel(cfg, journalManager, entry, llmClient, opts...)
+
+	// Create and run the Bubble Tea program
+	// Using tea.WithAltScreen() provides a dedicated screen for the TUI
+	// Using tea.WithMouseCellMotion() enables wheel scrolling, at the cost of
+	// the terminal's own text selection, so it is only on when configured
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if cfg.UI.ScrollLines > 0 {
+		programOpts = append(programOpts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(tuiModel, programOpts...)
+
+	logger.Info("Starting Momentum Journal TUI...")
+
+	// Run the program. This blocks until the program exits.
+	final, err := p.Run()
+	if err != nil {
+		// Log the error from Bubble Tea using standard log or zap
+		logger.Error("Error running Bubble Tea program", zap.Error(err))
+		// Use standard log for fatal errors that terminate the app immediately after TUI fails
+		log.Fatalf("Alas, there's been an error: %v", err)
+		// The return below might not be reached if log.Fatalf exits, but good practice.
+		return fmt.Errorf("error running TUI: %w", err)
+	}
+
+	logger.Info("Momentum Journal TUI finished.")
+
+	// The TUI has closed, so the summary stays on the terminal
+	if session, ok := tui.SessionOf(final); ok && !session.ReadOnly {
+		fmt.Fprintln(out, formatSessionSummary(session))
+	}
+
+	// Record the session in git if the journal lives in a repository
+	if err := journalManager.CommitEntry(entry); err != nil {
+		logger.Warn("Failed to commit journal entry", zap.Error(err))
+	}
+	return nil
+}
+
+// formatSessionSummary describes a writing session in a line or two: words
+// written, time taken, pace, and where the entry stands against its goal
+func formatSessionSummary(s tui.Session) string {
+	elapsed := s.Duration.Round(time.Second)
+	wpm := 0
+	if minutes := s.Duration.Minutes(); minutes > 0 {
+		wpm = int(float64(s.Words) / minutes)
+	}
+	summary := fmt.Sprintf("Session: %d words in %s (%d wpm).", s.Words, elapsed, wpm)
+
+	switch {
+	case s.Discarded:
+		return summary + " Changes since the last save were discarded."
+	case s.Goal <= 0:
+		return summary
+	case s.GoalMet():
+		return summary + fmt.Sprintf(" Goal met: %d/%d %s.", s.Progress, s.Goal, s.Unit)
+	default:
+		return summary + fmt.Sprintf(" Goal: %d/%d %s, %d to go.", s.Progress, s.Goal, s.Unit, s.Goal-s.Progress)
+	}
+}
+
+// writeEntryFromStdin saves everything read from stdin as the entry's content
+func writeEntryFromStdin(cmd *cobra.Command, journalManager *journal.Manager, entry *journal.JournalEntry) error {
+	content, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return fmt.Errorf("failed to read stdin: %w", err)
+	}
+
+	// Keep the configured header, if any, above the piped text
+	entry.Content = entry.Header + string(content)
+	if err := journalManager.SaveEntry(entry); err != nil {
+		return fmt.Errorf("failed to save journal entry: %w", err)
+	}
+
+	if err := journalManager.CommitEntry(entry); err != nil {
+		logger.Warn("Failed to commit journal entry", zap.Error(err))
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s (%d words, complete: %v)\n", entry.FilePath, entry.WordCount, entry.IsCompleted)
+	return nil
+}
+
 func init() {
+	newCmd.Flags().BoolVar(&newStdin, "stdin", false, "Create the entry from piped stdin instead of opening the TUI")
+	newCmd.Flags().BoolVar(&newNoTUI, "no-tui", false, "Create the entry and print its path without opening the TUI")
+	newCmd.Flags().StringVar(&newDate, "date", "", "Date the entry YYYY-MM-DD or YYYY-MM-DDTHH:MM instead of now, to backfill a missed day")
+	newCmd.Flags().BoolVar(&newDryRun, "dry-run", false, "Show what would be created without writing any files")
 	rootCmd.AddCommand(newCmd)
 }
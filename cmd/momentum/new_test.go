@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/tui"
+)
+
+func TestFormatSessionSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		session tui.Session
+		want    string
+	}{
+		{
+			name:    "goal met",
+			session: tui.Session{Words: 300, Duration: 10 * time.Minute, Progress: 760, Goal: 750, Unit: "words"},
+			want:    "Session: 300 words in 10m0s (30 wpm). Goal met: 760/750 words.",
+		},
+		{
+			name:    "goal to go",
+			session: tui.Session{Words: 125, Duration: 5*time.Minute + 400*time.Millisecond, Progress: 125, Goal: 750, Unit: "words"},
+			want:    "Session: 125 words in 5m0s (24 wpm). Goal: 125/750 words, 625 to go.",
+		},
+		{
+			name:    "minutes goal",
+			session: tui.Session{Words: 40, Duration: 90 * time.Second, Progress: 1, Goal: 15, Unit: "minutes"},
+			want:    "Session: 40 words in 1m30s (26 wpm). Goal: 1/15 minutes, 14 to go.",
+		},
+		{
+			name:    "no goal",
+			session: tui.Session{Words: 12, Duration: time.Minute, Progress: 12},
+			want:    "Session: 12 words in 1m0s (12 wpm).",
+		},
+		{
+			name:    "no time passed",
+			session: tui.Session{Goal: 750, Unit: "words"},
+			want:    "Session: 0 words in 0s (0 wpm). Goal: 0/750 words, 750 to go.",
+		},
+		{
+			name:    "discarded",
+			session: tui.Session{Words: 50, Duration: 2 * time.Minute, Progress: 800, Goal: 750, Unit: "words", Discarded: true},
+			want:    "Session: 50 words in 2m0s (25 wpm). Changes since the last save were discarded.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSessionSummary(tt.session); got != tt.want {
+				t.Errorf("formatSessionSummary =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openReadOnly bool
+	openEdit     bool
+)
+
+// openCmd represents the open command
+var openCmd = &cobra.Command{
+	Use:   "open <entry>",
+	Short: "Open an existing journal entry",
+	Long: `Open an existing journal entry in the Momentum Journal interface.
+
+With --readonly the entry is shown in a scrollable view and can't be edited.
+Setting ui.readonly_completed opens entries that met their goal read-only
+unless --edit is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		filePath, err := journalManager.ResolveEntryPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry, err := journalManager.ReadEntry(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry: %w", err)
+		}
+
+		readonly := openReadOnly || (cfg.UI.ReadOnlyCompleted && entry.IsCompleted && !openEdit)
+		return runTUI(cmd.OutOrStdout(), journalManager, entry, tui.WithReadOnly(readonly))
+	},
+}
+
+func init() {
+	openCmd.Flags().BoolVar(&openReadOnly, "readonly", false, "Open the entry for reading only")
+	openCmd.Flags().BoolVar(&openEdit, "edit", false, "Open for editing even if ui.readonly_completed applies")
+	openCmd.MarkFlagsMutuallyExclusive("readonly", "edit")
+	rootCmd.AddCommand(openCmd)
+}
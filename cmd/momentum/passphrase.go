@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/x/term"
+)
+
+// newJournalManager creates the journal manager, first asking for the
+// passphrase when journal.encrypt is on
+func newJournalManager(opts ...journal.Option) (*journal.Manager, error) {
+	if cfg.Journal.Encrypt {
+		passphrase, err := readPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, journal.WithPassphrase(passphrase))
+	}
+	return journal.NewManager(cfg, logger, opts...)
+}
+
+// readPassphrase asks for the passphrase on the terminal without echoing
+// it. The terminal is used even when stdin is piped, so `new --stdin` still
+// gets its content.
+func readPassphrase() (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to open terminal for the passphrase prompt: %w", err)
+	}
+	defer tty.Close()
+
+	fmt.Fprint(tty, "Journal passphrase: ")
+	var passphrase string
+	if term.IsTerminal(tty.Fd()) {
+		secret, err := term.ReadPassword(tty.Fd())
+		fmt.Fprintln(tty)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		passphrase = string(secret)
+	} else {
+		line, err := bufio.NewReader(tty).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		passphrase = strings.TrimRight(line, "\r\n")
+	}
+
+	if passphrase == "" {
+		return "", fmt.Errorf("a passphrase is required when journal.encrypt is on")
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneMin    int
+	pruneDryRun bool
+	pruneYes    bool
+)
+
+// pruneEmptyCmd represents the prune-empty command
+var pruneEmptyCmd = &cobra.Command{
+	Use:   "prune-empty",
+	Short: "Move empty entries to the trash",
+	Long: `List entries with fewer than --min words, such as those left by abandoned
+sessions, and after confirmation move them and their sidecar files into
+.trash/ in the journal directory. Nothing is deleted outright, so a pruned
+entry can be brought back with "momentum restore". Use --dry-run to only list
+them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		journalManager, err := newJournalManager(journal.WithDryRun(pruneDryRun))
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		entries, err := journalManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+
+		empty := journal.ShorterThan(entries, pruneMin)
+		if len(empty) == 0 {
+			fmt.Fprintf(out, "No entries with fewer than %d words.\n", pruneMin)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tTIME\tWORDS\tFILE")
+		fmt.Fprintln(w, "----\t----\t-----\t----")
+		for _, entry := range empty {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n",
+				entry.CreatedAt.Format("2006-01-02"),
+				entry.CreatedAt.Format("15:04"),
+				entry.WordCount,
+				entry.FileName)
+		}
+		w.Flush()
+		fmt.Fprintln(out)
+
+		if pruneDryRun {
+			fmt.Fprintf(out, "Would move %d entries to %s/.\n", len(empty), journal.TrashDir)
+			return nil
+		}
+
+		if !pruneYes {
+			ok, err := confirm(cmd.InOrStdin(), out, fmt.Sprintf("Move %d entries to %s/?", len(empty), journal.TrashDir))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(out, "Nothing moved.")
+				return nil
+			}
+		}
+
+		for _, entry := range empty {
+			if _, err := journalManager.TrashEntry(entry.FilePath); err != nil {
+				return fmt.Errorf("failed to prune %s: %w", entry.FileName, err)
+			}
+		}
+		fmt.Fprintf(out, "Moved %d entries to %s/.\n", len(empty), journal.TrashDir)
+		return nil
+	},
+}
+
+// confirm asks a yes/no question, taking anything but y or yes as no
+func confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+func init() {
+	pruneEmptyCmd.Flags().IntVar(&pruneMin, "min", 1, "Prune entries with fewer than this many words")
+	pruneEmptyCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "List the entries that would be pruned without moving them")
+	pruneEmptyCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false, "Don't ask for confirmation")
+	rootCmd.AddCommand(pruneEmptyCmd)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var relatedTop int
+
+// relatedCmd represents the related command
+var relatedCmd = &cobra.Command{
+	Use:   "related <entry>",
+	Short: "Show past entries similar to an entry",
+	Long: `Rank the other journal entries by how semantically similar they are to the
+given entry, using embeddings from the configured LLM provider. Embeddings are
+cached next to each entry and recomputed only when the entry changes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		if !cfg.LLM.Enabled {
+			return fmt.Errorf("related entries need AI assistance, which is disabled (llm.enabled: false)")
+		}
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		client, err := llm.NewClient(cfg, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create LLM client: %w", err)
+		}
+
+		filePath, err := journalManager.ResolveEntryPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		target, err := journalManager.ReadEntry(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry: %w", err)
+		}
+
+		entries, err := journalManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+
+		ctx := cmd.Context()
+		model := llm.EmbeddingModel(cfg)
+
+		targetVector, err := entryEmbedding(ctx, journalManager, client, model, target)
+		if err != nil {
+			return err
+		}
+
+		var candidates []*journal.JournalEntry
+		var vectors [][]float32
+		for _, entry := range entries {
+			if filepath.Clean(entry.FilePath) == filepath.Clean(target.FilePath) {
+				continue
+			}
+			vector, err := entryEmbedding(ctx, journalManager, client, model, entry)
+			if err != nil {
+				logger.Warn("Skipping entry without embedding",
+					zap.String("file", entry.FileName),
+					zap.Error(err))
+				continue
+			}
+			candidates = append(candidates, entry)
+			vectors = append(vectors, vector)
+		}
+
+		related := journal.RankBySimilarity(targetVector, candidates, vectors, relatedTop)
+		if len(related) == 0 {
+			fmt.Fprintln(out, "No other journal entries found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SCORE\tDATE\tWORDS\tFILE")
+		fmt.Fprintln(w, "-----\t----\t-----\t----")
+		for _, r := range related {
+			fmt.Fprintf(w, "%.3f\t%s\t%d\t%s\n",
+				r.Score,
+				r.Entry.CreatedAt.Format("2006-01-02"),
+				r.Entry.WordCount,
+				r.Entry.FileName)
+		}
+		return w.Flush()
+	},
+}
+
+// entryEmbedding returns the entry's cached embedding, computing and
+// caching it first if the entry is new or has changed
+func entryEmbedding(ctx context.Context, manager *journal.Manager, client llm.Client, model string, entry *journal.JournalEntry) ([]float32, error) {
+	// The cache is checked against the content, so it's needed either way
+	if err := entry.LoadContent(); err != nil {
+		return nil, err
+	}
+
+	vector, ok, err := manager.LoadEmbedding(entry, model)
+	if err != nil {
+		logger.Warn("Ignoring unreadable embedding cache",
+			zap.String("file", entry.FileName),
+			zap.Error(err))
+	}
+	if ok {
+		return vector, nil
+	}
+
+	vector, err = client.Embed(ctx, entry.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed %s: %w", entry.FileName, err)
+	}
+
+	if err := manager.SaveEmbedding(entry, model, vector); err != nil {
+		logger.Warn("Failed to cache embedding",
+			zap.String("file", entry.FileName),
+			zap.Error(err))
+	}
+	return vector, nil
+}
+
+func init() {
+	relatedCmd.Flags().IntVar(&relatedTop, "top", 5, "Number of related entries to show")
+	rootCmd.AddCommand(relatedCmd)
+}
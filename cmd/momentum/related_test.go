@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRelatedCommand(t *testing.T) {
+	home := t.TempDir()
+	if err := os.WriteFile(filepath.Join(home, "config.yaml"), []byte("llm:\n  enabled: true\n  provider: mock\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entries := map[string]string{
+		"2024-03-05T09:30": "rain falls softly on the garden this morning",
+		"2024-03-06T09:30": "soft rain on the garden again this morning",
+		"2024-03-07T09:30": "quarterly taxes and spreadsheets all day",
+	}
+	for date, text := range entries {
+		if _, err := runCommand(t, home, text, "new", "--stdin", "--date", date); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	out, err := runCommand(t, home, "", "related", "2024-03-05T09:30-morning-pages")
+	if err != nil {
+		t.Fatalf("related: %v", err)
+	}
+	similar := strings.Index(out, "2024-03-06T09:30-morning-pages.md")
+	unrelated := strings.Index(out, "2024-03-07T09:30-morning-pages.md")
+	if similar < 0 || unrelated < 0 || similar > unrelated {
+		t.Errorf("want the other garden entry ranked first:\n%s", out)
+	}
+	if strings.Contains(out, "2024-03-05T09:30-morning-pages.md") {
+		t.Errorf("entry listed as related to itself:\n%s", out)
+	}
+
+	// Embeddings are cached next to each entry
+	cached, _ := filepath.Glob(filepath.Join(home, "journals", "*.embedding.json"))
+	if len(cached) != 3 {
+		t.Errorf("cached %d embeddings, want 3", len(cached))
+	}
+
+	out, err = runCommand(t, home, "", "related", "2024-03-05T09:30-morning-pages", "--top", "1")
+	if err != nil {
+		t.Fatalf("related --top 1: %v", err)
+	}
+	if strings.Contains(out, "2024-03-07T09:30") {
+		t.Errorf("--top 1 listed more than the best match:\n%s", out)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+	"unicode"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+// maxReplayPause caps the wait between frames, so breaks and the gaps
+// between writing sessions don't stall the replay
+const maxReplayPause = 2 * time.Second
+
+var replaySpeed float64
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay <entry>",
+	Short: "Replay how an entry was written",
+	Long: `Show an entry growing word by word, following the word count history
+recorded while it was written. Only the word count is recorded, so the replay
+shows the final text appearing at the pace it was written, not the edits made
+along the way.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		if replaySpeed <= 0 {
+			return fmt.Errorf("--speed must be positive, got %g", replaySpeed)
+		}
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		filePath, err := journalManager.ResolveEntryPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry, err := journalManager.ReadEntry(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry: %w", err)
+		}
+
+		if len(entry.WordCountHistory) == 0 {
+			fmt.Fprintln(out, "No word count history recorded for this entry.")
+			return nil
+		}
+
+		prose := journal.StripHeader(entry.Header, entry.Content)
+		playReplay(out, prose, entry.WordCountHistory, replayTimeline(entry.WordCountHistory, replaySpeed))
+		return nil
+	},
+}
+
+// replayFrame is one step of a replay: wait Delay, then show the first
+// Words words
+type replayFrame struct {
+	Delay time.Duration
+	Words int
+}
+
+// replayTimeline turns the word count samples into frames, sped up by speed.
+// Words added between two samples appear one at a time, evenly spread over
+// the time between them.
+func replayTimeline(samples []journal.Sample, speed float64) []replayFrame {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	frames := []replayFrame{{Words: samples[0].WordCount}}
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		pause := time.Duration(float64(cur.Time.Sub(prev.Time)) / speed)
+		pause = min(max(pause, 0), maxReplayPause)
+
+		added := cur.WordCount - prev.WordCount
+		if added <= 0 {
+			frames = append(frames, replayFrame{Delay: pause, Words: cur.WordCount})
+			continue
+		}
+		step := pause / time.Duration(added)
+		for w := prev.WordCount + 1; w <= cur.WordCount; w++ {
+			frames = append(frames, replayFrame{Delay: step, Words: w})
+		}
+	}
+	return frames
+}
+
+// firstWords returns text up to the end of its nth whitespace-separated
+// word, keeping the line breaks in between
+func firstWords(text string, n int) string {
+	words := 0
+	inWord := false
+	for i, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			if inWord && words == n {
+				return text[:i]
+			}
+			inWord = false
+		case !inWord:
+			if words == n {
+				return text[:i]
+			}
+			inWord = true
+			words++
+		}
+	}
+	return text
+}
+
+// playReplay draws each frame over the last one, with the time and word
+// count of the sample it belongs to underneath
+func playReplay(w io.Writer, prose string, samples []journal.Sample, frames []replayFrame) {
+	start, end := samples[0].Time, samples[len(samples)-1].Time
+	for _, frame := range frames {
+		time.Sleep(frame.Delay)
+		fmt.Fprint(w, "\033[H\033[2J") // Home the cursor and clear the screen
+		fmt.Fprintln(w, firstWords(prose, frame.Words))
+		fmt.Fprintf(w, "\n-- %d words (%s -> %s) --\n", frame.Words, start.Format("15:04"), end.Format("15:04"))
+	}
+}
+
+func init() {
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 10, "How many times faster than it was written to replay")
+	rootCmd.AddCommand(replayCmd)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+)
+
+func TestReplayTimeline(t *testing.T) {
+	t0 := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	sample := func(after time.Duration, words int) journal.Sample {
+		return journal.Sample{Time: t0.Add(after), WordCount: words}
+	}
+
+	tests := []struct {
+		name    string
+		samples []journal.Sample
+		speed   float64
+		want    []replayFrame
+	}{
+		{name: "no history", samples: nil, speed: 1, want: nil},
+		{
+			name:    "single sample",
+			samples: []journal.Sample{sample(0, 3)},
+			speed:   1,
+			want:    []replayFrame{{Words: 3}},
+		},
+		{
+			name:    "words spread over the gap, sped up",
+			samples: []journal.Sample{sample(0, 0), sample(4*time.Second, 2)},
+			speed:   2,
+			want:    []replayFrame{{Words: 0}, {Delay: time.Second, Words: 1}, {Delay: time.Second, Words: 2}},
+		},
+		{
+			name:    "unchanged and shrinking counts get one frame",
+			samples: []journal.Sample{sample(0, 2), sample(time.Second, 2), sample(2*time.Second, 1)},
+			speed:   1,
+			want:    []replayFrame{{Words: 2}, {Delay: time.Second, Words: 2}, {Delay: time.Second, Words: 1}},
+		},
+		{
+			name:    "long breaks are capped",
+			samples: []journal.Sample{sample(0, 0), sample(time.Hour, 1)},
+			speed:   1,
+			want:    []replayFrame{{Words: 0}, {Delay: maxReplayPause, Words: 1}},
+		},
+		{
+			name:    "clock going back doesn't wait",
+			samples: []journal.Sample{sample(0, 0), sample(-time.Minute, 1)},
+			speed:   1,
+			want:    []replayFrame{{Words: 0}, {Delay: 0, Words: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replayTimeline(tt.samples, tt.speed); !slices.Equal(got, tt.want) {
+				t.Errorf("replayTimeline = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstWords(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{text: "one two three", n: 0, want: ""},
+		{text: "one two three", n: 2, want: "one two"},
+		{text: "one\n\ntwo three", n: 2, want: "one\n\ntwo"},
+		{text: "  one two", n: 1, want: "  one"},
+		{text: "one two", n: 5, want: "one two"},
+	}
+
+	for _, tt := range tests {
+		if got := firstWords(tt.text, tt.n); got != tt.want {
+			t.Errorf("firstWords(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestReplayCommand(t *testing.T) {
+	home := t.TempDir()
+	if _, err := runCommand(t, home, "one two three", "new", "--stdin", "--date", "2024-03-05T09:30"); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(home, "journals", "*.md"))
+	if len(matches) != 1 {
+		t.Fatalf("want one entry, found %v", matches)
+	}
+	entry := filepath.Base(matches[0])
+
+	out, err := runCommand(t, home, "", "replay", entry)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if !strings.Contains(out, "No word count history recorded") {
+		t.Errorf("without history got %q", out)
+	}
+
+	history := `[{"time":"2024-03-05T09:30:00Z","word_count":0},{"time":"2024-03-05T09:31:00Z","word_count":3}]`
+	historyFile := strings.TrimSuffix(matches[0], ".md") + ".history.json"
+	if err := os.WriteFile(historyFile, []byte(history), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = runCommand(t, home, "", "replay", entry, "--speed", "60000")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if frames := strings.Count(out, "\033[2J"); frames != 4 {
+		t.Errorf("drew %d frames, want 4", frames)
+	}
+	for _, want := range []string{"-- 0 words (09:30 -> 09:31) --", "one two\n\n-- 2 words", "one two three\n\n-- 3 words"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q doesn't contain %q", out, want)
+		}
+	}
+
+	if _, err := runCommand(t, home, "", "replay", entry, "--speed", "0"); err == nil {
+		t.Error("expected --speed 0 to be rejected")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <entry>",
+	Short: "Move an entry out of the trash",
+	Long: `Move an entry and its sidecar files from .trash/ back into the journal,
+filed by the date it was started. If the journal already has an entry by that
+name, the restored one gets a numbered suffix.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		trashPath, err := journalManager.ResolveEntryPath(filepath.Join(journal.TrashDir, args[0]))
+		if err != nil {
+			return err
+		}
+
+		filePath, err := journalManager.RestoreEntry(trashPath)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Restored %s\n", filePath)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
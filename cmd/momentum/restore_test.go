@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRestoreCommand(t *testing.T) {
+	home := t.TempDir()
+	journals := filepath.Join(home, "journals")
+	if _, err := runCommand(t, home, "", "new", "--no-tui", "--date", "2024-03-05T09:30"); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if _, err := runCommand(t, home, "", "prune-empty", "--yes"); err != nil {
+		t.Fatalf("prune-empty: %v", err)
+	}
+	name := "2024-03-05T09:30-morning-pages"
+	if _, err := os.Stat(filepath.Join(journals, ".trash", name+".md")); err != nil {
+		t.Fatalf("entry wasn't trashed: %v", err)
+	}
+
+	out, err := runCommand(t, home, "", "restore", name)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	restored := filepath.Join(journals, name+".md")
+	if !strings.Contains(out, "Restored "+restored) {
+		t.Errorf("output %q doesn't name %s", out, restored)
+	}
+	if _, err := os.Stat(restored); err != nil {
+		t.Errorf("entry wasn't restored: %v", err)
+	}
+
+	if _, err := runCommand(t, home, "", "restore", name); err == nil {
+		t.Error("expected restoring it again to fail")
+	}
+	if _, err := runCommand(t, home, "", "restore", "../"+name); err == nil {
+		t.Error("expected a path out of the trash to be rejected")
+	}
+}
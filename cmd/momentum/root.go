@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"  // Adjusted import path
 	"github.com/ZachBeta/momentum_journal_nvim_go/internal/logging" // Adjusted import path
@@ -13,6 +14,7 @@ import (
 var (
 	debug      bool
 	configFile string
+	logFormat  string
 	logger     *zap.Logger
 	cfg        *config.Config
 )
@@ -24,18 +26,27 @@ var rootCmd = &cobra.Command{
 	Long: `Momentum Journal is a minimalist tool for following "The Artist's Way" journaling practice.
 It provides a distraction-free writing environment with AI support to help maintain writing flow.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		var err error
+		// Hold what loading the config logs until the configured format
+		// is known, so stderr never mixes formats
+		bootLogger, buffered := logging.NewBufferedLogger()
+		var loadErr error
+		cfg, loadErr = config.Load(bootLogger)
+
+		// The configured format applies unless the flag picked one
+		format := logFormat
+		if loadErr == nil && !cmd.Flags().Changed("log-format") {
+			format = cfg.Log.Format
+		}
 
-		// Initialize logger
-		logger, err = logging.NewLogger(debug)
+		var err error
+		logger, err = logging.NewLogger(debug, format)
 		if err != nil {
 			return fmt.Errorf("failed to initialize logger: %w", err)
 		}
+		buffered.Replay(logger)
 
-		// Load configuration
-		cfg, err = config.Load(logger)
-		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
+		if loadErr != nil {
+			return fmt.Errorf("failed to load configuration: %w", loadErr)
 		}
 
 		return nil
@@ -53,5 +64,6 @@ func Execute() {
 func init() {
 	// Add persistent flags for the root command
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug mode")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logging.FormatConsole, "Log format on stderr: "+strings.Join(logging.Formats, " or "))
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file (default is $HOME/.config/momentum_journal/config.yaml)")
 }
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/server"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var servePort int
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Browse journal entries in a web browser",
+	Long: `Start a read-only web server on localhost that lists journal entries
+and renders each one as HTML.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(servePort))
+		logger.Info("Serving journal entries", zap.String("url", "http://"+addr))
+
+		if err := http.ListenAndServe(addr, server.NewHandler(journalManager, logger)); err != nil {
+			return fmt.Errorf("failed to serve journal entries: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// snapshotsCmd represents the snapshots command
+var snapshotsCmd = &cobra.Command{
+	Use:   "snapshots <entry>",
+	Short: "List an entry's autosave snapshots",
+	Long: `List the snapshots kept of an entry, oldest first. With journal.snapshots on,
+every autosave that changes the entry keeps a timestamped copy of it under
+.snapshots/<entry>/ next to the entry, up to journal.max_snapshots.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		filePath, err := journalManager.ResolveEntryPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry, err := journalManager.ReadEntry(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry: %w", err)
+		}
+
+		snapshots, err := journalManager.Snapshots(entry)
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			fmt.Fprintf(out, "No snapshots of %s.\n", entry.FileName)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TAKEN\tSIZE\tFILE")
+		fmt.Fprintln(w, "-----\t----\t----")
+		for _, snapshot := range snapshots {
+			fmt.Fprintf(w, "%s\t%d\t%s\n",
+				snapshot.Time.Format("2006-01-02 15:04:05"),
+				snapshot.Size,
+				snapshot.Path)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotsCmd)
+}
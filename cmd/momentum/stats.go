@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsWeekly  bool
+	statsMonthly bool
+	statsYearly  bool
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show writing statistics",
+	Long: `Show totals across all journal entries, or with --weekly, --monthly or
+--yearly, a table of entries, words, and completed entries per period.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		entries, err := journalManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+
+		if len(entries) == 0 {
+			fmt.Fprintln(out, "No journal entries found.")
+			return nil
+		}
+
+		switch {
+		case statsWeekly:
+			printPeriodStats(out, journal.AggregateByPeriod(entries, journal.PeriodWeek), "WEEK")
+		case statsMonthly:
+			printPeriodStats(out, journal.AggregateByPeriod(entries, journal.PeriodMonth), "MONTH")
+		case statsYearly:
+			printPeriodStats(out, journal.AggregateByPeriod(entries, journal.PeriodYear), "YEAR")
+		default:
+			printTotals(out, entries)
+		}
+		return nil
+	},
+}
+
+// printTotals prints summary statistics across all entries
+func printTotals(out io.Writer, entries []*journal.JournalEntry) {
+	words, completed := 0, 0
+	for _, entry := range entries {
+		words += entry.WordCount
+		if entry.IsCompleted {
+			completed++
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Entries:\t%d\n", len(entries))
+	fmt.Fprintf(w, "Words:\t%d\n", words)
+	fmt.Fprintf(w, "Average words:\t%d\n", words/len(entries))
+	fmt.Fprintf(w, "Completed:\t%s\n", completion(completed, len(entries)))
+	w.Flush()
+}
+
+// printPeriodStats prints one row per period
+func printPeriodStats(out io.Writer, stats []journal.PeriodStat, heading string) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tENTRIES\tWORDS\tCOMPLETED\n", heading)
+	fmt.Fprintln(w, "----\t-------\t-----\t---------")
+	for _, stat := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n",
+			stat.Label,
+			stat.Entries,
+			stat.Words,
+			completion(stat.Completed, stat.Entries))
+	}
+	w.Flush()
+}
+
+// completion formats completed entries as a count and percentage
+func completion(completed, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", completed, total, completed*100/total)
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsWeekly, "weekly", false, "Group entries by ISO week")
+	statsCmd.Flags().BoolVar(&statsMonthly, "monthly", false, "Group entries by month")
+	statsCmd.Flags().BoolVar(&statsYearly, "yearly", false, "Group entries by year")
+	statsCmd.MarkFlagsMutuallyExclusive("weekly", "monthly", "yearly")
+	rootCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var themesTop int
+
+// themesCmd represents the themes command
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "Show the most frequent words across entries",
+	Long: `Summarize recurring themes by listing the most frequent words across all
+journal entries, ignoring common stopwords and markdown syntax.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		// Create journal manager
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		entries, err := journalManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+
+		words := journal.TopWords(entries, themesTop, journal.DefaultStopwords)
+		if len(words) == 0 {
+			fmt.Fprintln(out, "No words found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "WORD\tCOUNT")
+		fmt.Fprintln(w, "----\t-----")
+		for _, wc := range words {
+			fmt.Fprintf(w, "%s\t%d\n", wc.Word, wc.Count)
+		}
+
+		w.Flush()
+		return nil
+	},
+}
+
+func init() {
+	themesCmd.Flags().IntVar(&themesTop, "top", 10, "Number of words to show")
+	rootCmd.AddCommand(themesCmd)
+}
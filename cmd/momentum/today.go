@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var todayDailyGoal bool
+
+// todayCmd represents the today command
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "Show today's progress in one line",
+	Long: `Show whether you have written today, today's word count against the goal,
+and your current streak of days with an entry. Nothing is created.
+
+With --daily-goal, all of today's entries count together toward the goal, so
+a morning and an evening session can complete the day between them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		journalManager, err := newJournalManager()
+		if err != nil {
+			return fmt.Errorf("failed to create journal manager: %w", err)
+		}
+
+		entries, err := journalManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+
+		if todayDailyGoal {
+			printDailyGoal(out, entries, time.Now())
+			return nil
+		}
+		printToday(out, journalManager, entries, time.Now())
+		return nil
+	},
+}
+
+// printToday prints the check-in line for now. With several entries today,
+// the most recent one is the one in progress.
+func printToday(w io.Writer, manager *journal.Manager, entries []*journal.JournalEntry, now time.Time) {
+	streak := journal.Streak(entries, now)
+	today := journal.EntriesOn(entries, now)
+
+	if len(today) == 0 {
+		fmt.Fprintf(w, "Nothing written today yet. Streak: %s. Start with `momentum new`.\n", days(streak))
+		return
+	}
+
+	entry := today[len(today)-1]
+	current, goal := manager.Progress(entry)
+	status := "goal met"
+	if !entry.IsCompleted {
+		status = fmt.Sprintf("%d to go", goal-current)
+	}
+	fmt.Fprintf(w, "Wrote today: %d/%d %s (%s). Streak: %s.\n", current, goal, manager.Goal().Unit(), status, days(streak))
+}
+
+// printDailyGoal prints the check-in line for now, counting every entry
+// written today toward the configured goal
+func printDailyGoal(w io.Writer, entries []*journal.JournalEntry, now time.Time) {
+	streak := journal.Streak(entries, now)
+	today := journal.EntriesOn(entries, now)
+
+	if len(today) == 0 {
+		fmt.Fprintf(w, "Nothing written today yet. Streak: %s. Start with `momentum new`.\n", days(streak))
+		return
+	}
+
+	words := journal.SumWordsForDate(entries, now)
+	goal := cfg.Journal.WordCountGoal
+	status := "day complete"
+	if words < goal {
+		status = fmt.Sprintf("%d to go", goal-words)
+	}
+	sessions := "1 entry"
+	if len(today) > 1 {
+		sessions = fmt.Sprintf("%d entries", len(today))
+	}
+	fmt.Fprintf(w, "Today: %d/%d words across %s (%s). Streak: %s.\n", words, goal, sessions, status, days(streak))
+}
+
+// days formats a day count
+func days(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+func init() {
+	todayCmd.Flags().BoolVar(&todayDailyGoal, "daily-goal", false, "Count all of today's entries together toward the goal")
+	rootCmd.AddCommand(todayCmd)
+}
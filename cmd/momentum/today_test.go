@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTodayCommand(t *testing.T) {
+	now := time.Now()
+	today := now.Format("2006-01-02") + "T00:00"
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02") + "T00:00"
+
+	tests := []struct {
+		name   string
+		config string
+		dates  []string // entries written first, each with "words words"
+		args   []string
+		want   string
+	}{
+		{
+			name: "nothing yet",
+			args: []string{"today"},
+			want: "Nothing written today yet. Streak: 0 days. Start with `momentum new`.\n",
+		},
+		{
+			name:  "nothing yet with a streak",
+			dates: []string{yesterday},
+			args:  []string{"today"},
+			want:  "Nothing written today yet. Streak: 1 day. Start with `momentum new`.\n",
+		},
+		{
+			name:  "wrote today",
+			dates: []string{yesterday, today},
+			args:  []string{"today"},
+			want:  "Wrote today: 2/750 words (748 to go). Streak: 2 days.\n",
+		},
+		{
+			name:   "goal met",
+			config: "journal:\n  word_count_goal: 2\n",
+			dates:  []string{today},
+			args:   []string{"today"},
+			want:   "Wrote today: 2/2 words (goal met). Streak: 1 day.\n",
+		},
+		{
+			name:  "daily goal, one entry",
+			dates: []string{yesterday, today},
+			args:  []string{"today", "--daily-goal"},
+			want:  "Today: 2/750 words across 1 entry (748 to go). Streak: 2 days.\n",
+		},
+		{
+			name:  "daily goal sums the day",
+			dates: []string{today, today},
+			args:  []string{"today", "--daily-goal"},
+			want:  "Today: 4/750 words across 2 entries (746 to go). Streak: 1 day.\n",
+		},
+		{
+			name:   "daily goal met between entries",
+			config: "journal:\n  word_count_goal: 3\n",
+			dates:  []string{today, today},
+			args:   []string{"today", "--daily-goal"},
+			want:   "Today: 4/3 words across 2 entries (day complete). Streak: 1 day.\n",
+		},
+		{
+			name:  "daily goal, nothing yet",
+			dates: []string{yesterday},
+			args:  []string{"today", "--daily-goal"},
+			want:  "Nothing written today yet. Streak: 1 day. Start with `momentum new`.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			if tt.config != "" {
+				if err := os.WriteFile(filepath.Join(home, "config.yaml"), []byte(tt.config), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, date := range tt.dates {
+				if _, err := runCommand(t, home, "words words", "new", "--stdin", "--date", date); err != nil {
+					t.Fatalf("setup: %v", err)
+				}
+			}
+
+			out, err := runCommand(t, home, "", tt.args...)
+			if err != nil {
+				t.Fatalf("today: %v", err)
+			}
+			if out != tt.want {
+				t.Errorf("today printed %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
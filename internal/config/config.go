@@ -4,7 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
 
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/fsutil"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/logging"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
@@ -13,12 +19,26 @@ import (
 type Config struct {
 	// LLM provider settings
 	LLM struct {
-		Provider    string  `yaml:"provider"`    // "ollama" or "openrouter"
-		APIKey      string  `yaml:"api_key"`     // API key for openrouter
-		ModelName   string  `yaml:"model_name"`  // Model to use e.g., "llama3" for Ollama
-		Endpoint    string  `yaml:"endpoint"`    // API endpoint
-		MaxTokens   int     `yaml:"max_tokens"`  // Maximum tokens for response
-		Temperature float64 `yaml:"temperature"` // Temperature for generation
+		Enabled       bool    `yaml:"enabled"`         // AI assistance and the conversation pane; false is pure writing
+		Provider      string  `yaml:"provider"`        // "ollama", "openrouter", "auto" to detect one at startup, or "mock" for canned offline replies
+		APIKey        string  `yaml:"api_key"`         // API key for openrouter
+		ModelName     string  `yaml:"model_name"`      // Model to use e.g., "llama3" for Ollama
+		Endpoint      string  `yaml:"endpoint"`        // API endpoint
+		MaxTokens     int     `yaml:"max_tokens"`      // Maximum tokens for response
+		Temperature   float64 `yaml:"temperature"`     // Temperature for generation
+		Stream        bool    `yaml:"stream"`          // Stream responses token by token
+		CacheTTL      int     `yaml:"cache_ttl"`       // Seconds to reuse responses to identical prompts; 0 disables
+		SaveBeforeAsk bool    `yaml:"save_before_ask"` // Save the entry before asking the AI about it
+
+		RequestsPerMinute int `yaml:"requests_per_minute"` // Most requests sent to the provider per minute; 0 is unlimited
+		ContextParagraphs int `yaml:"context_paragraphs"`  // Most recent paragraphs of the entry sent as context; 0 sends it all
+
+		EmptyReplyNotice string `yaml:"empty_reply_notice"` // Shown instead of a reply that has no text; empty shows nothing
+
+		EmbeddingModel    string `yaml:"embedding_model"`    // Model used for related entries; empty uses model_name
+		EmbeddingEndpoint string `yaml:"embedding_endpoint"` // Embeddings API endpoint; empty derives it from endpoint
+
+		PromptLibrary map[string]string `yaml:"prompt_library"` // Canned prompts for the AI companion, by name
 	} `yaml:"llm"`
 
 	// Journal settings
@@ -26,53 +46,165 @@ type Config struct {
 		StorageDir       string `yaml:"storage_dir"`       // Directory to store journal files
 		WordCountGoal    int    `yaml:"word_count_goal"`   // Default 750 words (3 pages)
 		AutosaveInterval int    `yaml:"autosave_interval"` // Autosave interval in seconds
+		CJKCounting      bool   `yaml:"cjk_counting"`      // Count each CJK character as a word
+		UnicodeCounting  bool   `yaml:"unicode_counting"`  // Count runs of letters across scripts, ignoring punctuation and exotic spaces
+		OnSave           string `yaml:"on_save"`           // Shell command run after each save, with the entry path as $1
+		OnComplete       string `yaml:"on_complete"`       // Shell command run once when an entry reaches the goal
+		GitAutoCommit    bool   `yaml:"git_auto_commit"`   // Commit the entry to git when a session ends
+		DraftDir         string `yaml:"draft_dir"`         // Local directory for autosaves; empty autosaves into storage_dir
+		SaveOnBlur       bool   `yaml:"save_on_blur"`      // Save the entry when focus leaves the writing pane
+		GoalMode         string `yaml:"goal_mode"`         // "soft" just marks completion; "hard" offers to finish the session
+		GoalType         string `yaml:"goal_type"`         // What word_count_goal counts: words, characters, or minutes spent writing
+		Encrypt          bool   `yaml:"encrypt"`           // Encrypt entry bodies with a passphrase asked for at startup
+		FormatOnSave     bool   `yaml:"format_on_save"`    // Tidy whitespace and blank lines when saving, leaving code fences alone
+		FileMode         string `yaml:"file_mode"`         // Octal permissions for entry files, e.g. "0600" for a private journal
+		DirMode          string `yaml:"dir_mode"`          // Octal permissions for directories created for entries
+		FolderLayout     string `yaml:"folder_layout"`     // Where new entries go: flat, by-month (YYYY/MM/), or by-year (YYYY/)
+		Snapshots        bool   `yaml:"snapshots"`         // Keep a timestamped copy of the entry in .snapshots/ after each autosave
+		MaxSnapshots     int    `yaml:"max_snapshots"`     // Snapshots kept per entry, oldest pruned first; 0 keeps them all
+		TimeWarnDays     int    `yaml:"time_warn_days"`    // Warn when a new entry is dated in the future or this many days from the latest one; 0 disables
+
+		AutoQuitOnComplete bool `yaml:"auto_quit_on_complete"` // Save and quit a few seconds after the goal is reached, unless a key is pressed
+
+		EntryHeaderTemplate string `yaml:"entry_header_template"` // Go template written at the top of new entries, e.g. "# {{.Date}}\nIntention: "
 	} `yaml:"journal"`
 
 	// UI settings
 	UI struct {
-		Theme string `yaml:"theme"` // UI theme (light/dark)
+		Theme                string `yaml:"theme"`                  // UI theme (light/dark)
+		HighlightCurrentLine bool   `yaml:"highlight_current_line"` // Tint the cursor line in the writing pane
+		ConvoPosition        string `yaml:"convo_position"`         // Conversation pane position (left/right/bottom)
+		ScrollOff            int    `yaml:"scroll_off"`             // Lines kept visible above/below the cursor, like vim's scrolloff
+		Border               string `yaml:"border"`                 // Border of unfocused panes (rounded/thick/double/normal/hidden)
+		FocusedBorder        string `yaml:"focused_border"`         // Border of the focused pane
+		DimAfter             int    `yaml:"dim_after"`              // Seconds without input before the UI dims; 0 disables
+		CursorShapes         bool   `yaml:"cursor_shapes"`          // Block cursor in normal mode, bar in insert mode
+		ReadOnlyCompleted    bool   `yaml:"readonly_completed"`     // Open entries that met their goal read-only
+		LineWordCounts       bool   `yaml:"line_word_counts"`       // Show the running word count beside each line
+		FocusLock            bool   `yaml:"focus_lock"`             // Block pane switches and quitting until the word goal is met
+		ScrollLines          int    `yaml:"scroll_lines"`           // Lines scrolled per mouse wheel notch; 0 leaves the mouse to the terminal
+		LineNumbers          string `yaml:"line_numbers"`           // Line numbers in the writing pane (none/absolute/relative)
+		CursorBlink          bool   `yaml:"cursor_blink"`           // Blink the cursor in insert mode; off keeps it steady
+		ConfirmQuit          bool   `yaml:"confirm_quit"`           // Ask whether to save before quitting with unsaved changes
+		ContinueLists        bool   `yaml:"continue_lists"`         // o and O continue markdown list items, not just the indent
+		DateInsertFormat     string `yaml:"date_insert_format"`     // Go time layout inserted by ctrl+d in insert mode; empty disables it
+		StatusHints          bool   `yaml:"status_hints"`           // Show key hints for the focused pane in the status bar
+		ReflowWidth          int    `yaml:"reflow_width"`           // Columns gq wraps paragraphs to; 0 uses the writing pane width
+		LowPower             bool   `yaml:"low_power"`              // No cursor blink or spinner, and words recounted at most once a second while typing
+		RenderAIMarkdown     bool   `yaml:"render_ai_markdown"`     // Render AI replies in the conversation pane as styled markdown
+		PacingBPM            int    `yaml:"pacing_bpm"`             // Beats per minute of a dot breathing in the status bar as a writing rhythm; 0 hides it
 	} `yaml:"ui"`
 
+	// Logging settings
+	Log struct {
+		Format string `yaml:"format"` // Log format on stderr (console/json); --log-format overrides it
+	} `yaml:"log"`
+
 	logger *zap.Logger
 }
 
-// DefaultConfig returns the default configuration
-func DefaultConfig() *Config {
-	homeDir, err := os.UserHomeDir()
+// HomeEnv names the environment variable that, when set, holds both the
+// config file and the journals, in place of the home directory
+const HomeEnv = "MOMENTUM_HOME"
+
+// baseDir returns the directory the config file and journals are kept
+// under: $MOMENTUM_HOME if set (custom is true), otherwise the home
+// directory, or the current directory when there is no home directory, in
+// which case the error says why
+func baseDir() (dir string, custom bool, err error) {
+	if dir := os.Getenv(HomeEnv); dir != "" {
+		return dir, true, nil
+	}
+
+	home, err := os.UserHomeDir()
 	if err != nil {
-		homeDir = "."
+		return ".", false, fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return home, false, nil
+}
+
+// configDir returns the directory holding config.yaml; $MOMENTUM_HOME
+// holds it directly
+func configDir(base string, custom bool) string {
+	if custom {
+		return base
+	}
+	return filepath.Join(base, ".config", "momentum_journal")
+}
+
+// journalsDir returns the default storage directory; $MOMENTUM_HOME holds
+// it as journals/
+func journalsDir(base string, custom bool) string {
+	if custom {
+		return filepath.Join(base, "journals")
 	}
+	return filepath.Join(base, "momentum_journal", "journals")
+}
+
+// DefaultConfig returns the default configuration
+func DefaultConfig() *Config {
+	base, custom, _ := baseDir()
 
 	c := &Config{}
 
 	// Default LLM settings
+	c.LLM.Enabled = true
 	c.LLM.Provider = "ollama"
 	c.LLM.ModelName = "llama3"
 	c.LLM.Endpoint = "http://localhost:11434/api/generate"
 	c.LLM.MaxTokens = 2048
 	c.LLM.Temperature = 0.7
+	c.LLM.Stream = true
+	c.LLM.SaveBeforeAsk = true
+	c.LLM.EmptyReplyNotice = "(model returned no text — try a different model or prompt)"
+	c.LLM.PromptLibrary = map[string]string{
+		"reflect on my mood":      "Reflect back the mood of this journal entry in two or three gentle sentences.",
+		"summarize what I wrote":  "Summarize this journal entry in three short bullet points.",
+		"suggest three questions": "Suggest three open questions that could help me go deeper in this journal entry.",
+	}
 
 	// Default journal settings
-	c.Journal.StorageDir = filepath.Join(homeDir, "momentum_journal", "journals")
+	c.Journal.StorageDir = journalsDir(base, custom)
 	c.Journal.WordCountGoal = 750
 	c.Journal.AutosaveInterval = 30
+	c.Journal.SaveOnBlur = true
+	c.Journal.GoalMode = "soft"
+	c.Journal.GoalType = GoalTypeWords
+	c.Journal.FileMode = "0644"
+	c.Journal.DirMode = "0755"
+	c.Journal.FolderLayout = FolderLayoutFlat
+	c.Journal.MaxSnapshots = 20
+	c.Journal.TimeWarnDays = 365
 
 	// Default UI settings
 	c.UI.Theme = "dark"
+	c.UI.HighlightCurrentLine = true
+	c.UI.ConvoPosition = "right"
+	c.UI.Border = "rounded"
+	c.UI.FocusedBorder = "thick"
+	c.UI.LineNumbers = "absolute"
+	c.UI.CursorBlink = true
+	c.UI.DateInsertFormat = "2006-01-02 15:04"
+	c.UI.StatusHints = true
+	c.UI.ReflowWidth = 80
+	c.UI.RenderAIMarkdown = true
+
+	// Default logging settings
+	c.Log.Format = logging.FormatConsole
 
 	return c
 }
 
 // ConfigPath returns the path to the config file
 func ConfigPath() string {
-	homeDir, err := os.UserHomeDir()
+	base, custom, err := baseDir()
 	if err != nil {
 		return "momentum_journal_config.yaml"
 	}
 
-	configDir := filepath.Join(homeDir, ".config", "momentum_journal")
+	configDir := configDir(base, custom)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return filepath.Join(homeDir, "momentum_journal_config.yaml")
+		return filepath.Join(base, "momentum_journal_config.yaml")
 	}
 
 	return filepath.Join(configDir, "config.yaml")
@@ -83,6 +215,11 @@ func Load(logger *zap.Logger) (*Config, error) {
 	config := DefaultConfig()
 	config.logger = logger
 
+	if _, _, err := baseDir(); err != nil {
+		logger.Warn("Keeping config and journals in the current directory; set "+HomeEnv+" to choose where they go",
+			zap.Error(err))
+	}
+
 	configPath := ConfigPath()
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		logger.Info("Config file not found, creating default config", zap.String("path", configPath))
@@ -101,11 +238,133 @@ func Load(logger *zap.Logger) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", configPath, err)
+	}
+
 	logger.Info("Loaded configuration", zap.String("path", configPath))
 	return config, nil
 }
 
-// Save saves the configuration to file
+// Providers lists the accepted values for LLM.Provider
+var Providers = []string{"ollama", "openrouter", "auto", "mock"}
+
+// GoalModes lists the accepted values for Journal.GoalMode
+var GoalModes = []string{"soft", "hard"}
+
+// LineNumberStyles lists the accepted values for UI.LineNumbers
+var LineNumberStyles = []string{"none", "absolute", "relative"}
+
+// Journal.GoalType values
+const (
+	GoalTypeWords      = "words"
+	GoalTypeCharacters = "characters"
+	GoalTypeMinutes    = "minutes"
+)
+
+// GoalTypes lists the accepted values for Journal.GoalType
+var GoalTypes = []string{GoalTypeWords, GoalTypeCharacters, GoalTypeMinutes}
+
+// Journal.FolderLayout values
+const (
+	FolderLayoutFlat    = "flat"
+	FolderLayoutByMonth = "by-month"
+	FolderLayoutByYear  = "by-year"
+)
+
+// FolderLayouts lists the accepted values for Journal.FolderLayout
+var FolderLayouts = []string{FolderLayoutFlat, FolderLayoutByMonth, FolderLayoutByYear}
+
+// ConvoPositions lists the accepted values for UI.ConvoPosition
+var ConvoPositions = []string{"left", "right", "bottom"}
+
+// BorderStyles lists the accepted values for UI.Border and UI.FocusedBorder
+var BorderStyles = []string{"rounded", "thick", "double", "normal", "hidden"}
+
+// ParseFileMode parses octal permissions such as "0600" or "0o600". An
+// empty string parses as 0, leaving the caller's default in place.
+func ParseFileMode(s string) (os.FileMode, error) {
+	if s == "" {
+		return 0, nil
+	}
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0o"), "0O")
+	mode, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("%q is not an octal permission like 0600", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// Validate checks settings that can't be safely defaulted at runtime
+func (c *Config) Validate() error {
+	// An unset provider is detected at startup, like "auto"
+	if c.LLM.Provider != "" && !slices.Contains(Providers, c.LLM.Provider) {
+		return fmt.Errorf("unknown llm.provider %q (want one of %s)", c.LLM.Provider, strings.Join(Providers, ", "))
+	}
+	if c.Journal.StorageDir == "" {
+		return fmt.Errorf("journal.storage_dir must be set")
+	}
+	if _, err := template.New("header").Parse(c.Journal.EntryHeaderTemplate); err != nil {
+		return fmt.Errorf("invalid journal.entry_header_template: %w", err)
+	}
+	if !slices.Contains(GoalModes, c.Journal.GoalMode) {
+		return fmt.Errorf("unknown journal.goal_mode %q (want one of %s)", c.Journal.GoalMode, strings.Join(GoalModes, ", "))
+	}
+	if !slices.Contains(GoalTypes, c.Journal.GoalType) {
+		return fmt.Errorf("unknown journal.goal_type %q (want one of %s)", c.Journal.GoalType, strings.Join(GoalTypes, ", "))
+	}
+	if _, err := ParseFileMode(c.Journal.FileMode); err != nil {
+		return fmt.Errorf("invalid journal.file_mode: %w", err)
+	}
+	if _, err := ParseFileMode(c.Journal.DirMode); err != nil {
+		return fmt.Errorf("invalid journal.dir_mode: %w", err)
+	}
+	if !slices.Contains(FolderLayouts, c.Journal.FolderLayout) {
+		return fmt.Errorf("unknown journal.folder_layout %q (want one of %s)", c.Journal.FolderLayout, strings.Join(FolderLayouts, ", "))
+	}
+	if c.Journal.MaxSnapshots < 0 {
+		return fmt.Errorf("journal.max_snapshots must not be negative, got %d", c.Journal.MaxSnapshots)
+	}
+	if c.Journal.TimeWarnDays < 0 {
+		return fmt.Errorf("journal.time_warn_days must not be negative, got %d", c.Journal.TimeWarnDays)
+	}
+	if c.Journal.WordCountGoal < 0 {
+		return fmt.Errorf("journal.word_count_goal must not be negative, got %d", c.Journal.WordCountGoal)
+	}
+	if !slices.Contains(LineNumberStyles, c.UI.LineNumbers) {
+		return fmt.Errorf("unknown ui.line_numbers %q (want one of %s)", c.UI.LineNumbers, strings.Join(LineNumberStyles, ", "))
+	}
+	if !slices.Contains(logging.Formats, c.Log.Format) {
+		return fmt.Errorf("unknown log.format %q (want one of %s)", c.Log.Format, strings.Join(logging.Formats, ", "))
+	}
+	if c.LLM.RequestsPerMinute < 0 {
+		return fmt.Errorf("llm.requests_per_minute must not be negative, got %d", c.LLM.RequestsPerMinute)
+	}
+	if c.LLM.ContextParagraphs < 0 {
+		return fmt.Errorf("llm.context_paragraphs must not be negative, got %d", c.LLM.ContextParagraphs)
+	}
+	if c.UI.PacingBPM < 0 {
+		return fmt.Errorf("ui.pacing_bpm must not be negative, got %d", c.UI.PacingBPM)
+	}
+	if c.UI.ReflowWidth < 0 {
+		return fmt.Errorf("ui.reflow_width must not be negative, got %d", c.UI.ReflowWidth)
+	}
+	if c.UI.ScrollLines < 0 {
+		return fmt.Errorf("ui.scroll_lines must not be negative, got %d", c.UI.ScrollLines)
+	}
+	if !slices.Contains(ConvoPositions, c.UI.ConvoPosition) {
+		return fmt.Errorf("unknown ui.convo_position %q (want one of %s)", c.UI.ConvoPosition, strings.Join(ConvoPositions, ", "))
+	}
+	if !slices.Contains(BorderStyles, c.UI.Border) {
+		return fmt.Errorf("unknown ui.border %q (want one of %s)", c.UI.Border, strings.Join(BorderStyles, ", "))
+	}
+	if !slices.Contains(BorderStyles, c.UI.FocusedBorder) {
+		return fmt.Errorf("unknown ui.focused_border %q (want one of %s)", c.UI.FocusedBorder, strings.Join(BorderStyles, ", "))
+	}
+	return nil
+}
+
+// Save saves the configuration to file, replacing it atomically
 func (c *Config) Save() error {
 	configPath := ConfigPath()
 
@@ -120,7 +379,7 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := fsutil.WriteFileAtomic(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
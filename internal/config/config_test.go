@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/logging"
+)
+
+func TestValidateLogFormat(t *testing.T) {
+	for _, format := range logging.Formats {
+		cfg := DefaultConfig()
+		cfg.Log.Format = format
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("log.format %q: %v", format, err)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.Log.Format = "xml"
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown log.format "xml"`) {
+		t.Errorf("log.format xml: err = %v, want it rejected", err)
+	}
+}
+
+func TestValidateConvoPosition(t *testing.T) {
+	for _, position := range ConvoPositions {
+		cfg := DefaultConfig()
+		cfg.UI.ConvoPosition = position
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("ui.convo_position %q: %v", position, err)
+		}
+	}
+
+	for _, position := range []string{"top", "Right", ""} {
+		cfg := DefaultConfig()
+		cfg.UI.ConvoPosition = position
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "unknown ui.convo_position") {
+			t.Errorf("ui.convo_position %q: err = %v, want it rejected", position, err)
+		}
+	}
+}
+
+func TestValidateBorders(t *testing.T) {
+	for _, style := range BorderStyles {
+		cfg := DefaultConfig()
+		cfg.UI.Border = style
+		cfg.UI.FocusedBorder = style
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("border %q: %v", style, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(*Config)
+		wantErr string
+	}{
+		{name: "border", edit: func(c *Config) { c.UI.Border = "dotted" }, wantErr: `unknown ui.border "dotted"`},
+		{name: "focused border", edit: func(c *Config) { c.UI.FocusedBorder = "Thick" }, wantErr: `unknown ui.focused_border "Thick"`},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		tt.edit(cfg)
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseFileMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    os.FileMode
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "0600", want: 0o600},
+		{in: "600", want: 0o600},
+		{in: "0o640", want: 0o640},
+		{in: "0O755", want: 0o755},
+		{in: "0", want: 0},
+		{in: "0777", want: 0o777},
+		{in: "1000", wantErr: true},
+		{in: "0o1777", wantErr: true},
+		{in: "0800", wantErr: true},
+		{in: "0x1ff", wantErr: true},
+		{in: "-600", wantErr: true},
+		{in: "rw-r--r--", wantErr: true},
+		{in: "0o", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFileMode(tt.in)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "is not an octal permission") {
+				t.Errorf("ParseFileMode(%q) = %v, %v, want an error", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseFileMode(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidateFileModes(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Journal.FileMode = "0644"
+	cfg.Journal.DirMode = "0o755"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("valid modes rejected: %v", err)
+	}
+
+	cfg = DefaultConfig()
+	cfg.Journal.FileMode = "644x"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid journal.file_mode") {
+		t.Errorf("journal.file_mode 644x: err = %v, want it rejected", err)
+	}
+
+	cfg = DefaultConfig()
+	cfg.Journal.DirMode = "01777"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid journal.dir_mode") {
+		t.Errorf("journal.dir_mode 01777: err = %v, want it rejected", err)
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMomentumHome(t *testing.T) {
+	home := t.TempDir()
+	custom := t.TempDir()
+
+	tests := []struct {
+		name        string
+		momentum    string
+		wantStorage string
+		wantConfig  string
+	}{
+		{
+			name:        "home directory",
+			wantStorage: filepath.Join(home, "momentum_journal", "journals"),
+			wantConfig:  filepath.Join(home, ".config", "momentum_journal", "config.yaml"),
+		},
+		{
+			name:        "MOMENTUM_HOME overrides",
+			momentum:    custom,
+			wantStorage: filepath.Join(custom, "journals"),
+			wantConfig:  filepath.Join(custom, "config.yaml"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			t.Setenv(HomeEnv, tt.momentum)
+
+			if got := DefaultConfig().Journal.StorageDir; got != tt.wantStorage {
+				t.Errorf("StorageDir = %q, want %q", got, tt.wantStorage)
+			}
+			if got := ConfigPath(); got != tt.wantConfig {
+				t.Errorf("ConfigPath = %q, want %q", got, tt.wantConfig)
+			}
+		})
+	}
+}
+
+func TestHomeFallbackWarns(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", "") // os.UserHomeDir fails without it
+	t.Setenv(HomeEnv, "")
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	cfg, err := Load(zap.New(core))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if want := filepath.Join(".", "momentum_journal", "journals"); cfg.Journal.StorageDir != want {
+		t.Errorf("StorageDir = %q, want %q", cfg.Journal.StorageDir, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "momentum_journal_config.yaml")); err != nil {
+		t.Errorf("default config not written to the current directory: %v", err)
+	}
+	warnings := logs.All()
+	if len(warnings) != 1 {
+		t.Fatalf("logged %d warnings, want 1", len(warnings))
+	}
+	if msg := warnings[0].Message; !strings.Contains(msg, "current directory") || !strings.Contains(msg, HomeEnv) {
+		t.Errorf("warning %q doesn't explain the fallback", msg)
+	}
+}
+
+func TestNoFallbackWarning(t *testing.T) {
+	t.Setenv(HomeEnv, t.TempDir())
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	if _, err := Load(zap.New(core)); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("logged warnings with MOMENTUM_HOME set: %v", logs.All())
+	}
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/base64"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// attachmentResolver inlines images attached to an entry as data URIs, so
+// exported pages show them wherever the page ends up
+type attachmentResolver struct {
+	dir string // Directory of the entry that links resolve against
+}
+
+// Transform rewrites images that point into the attachments directory
+func (r attachmentResolver) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if img, ok := n.(*ast.Image); ok && entering {
+			if uri, ok := r.dataURI(string(img.Destination)); ok {
+				img.Destination = []byte(uri)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// dataURI reads an attachment and encodes it as a data URI. Anything that
+// isn't a readable image in the attachments directory is left alone.
+func (r attachmentResolver) dataURI(dest string) (string, bool) {
+	clean := path.Clean(dest)
+	if !strings.HasPrefix(clean, journal.AttachmentsDir+"/") {
+		return "", false
+	}
+
+	mediaType := mime.TypeByExtension(path.Ext(clean))
+	if !strings.HasPrefix(mediaType, "image/") {
+		return "", false
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.dir, filepath.FromSlash(clean)))
+	if err != nil {
+		return "", false
+	}
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data), true
+}
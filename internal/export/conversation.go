@@ -0,0 +1,73 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+)
+
+var transcriptTemplate = template.Must(template.New("transcript").Parse(`<section class="conversation">
+<h2>Conversation</h2>
+{{range .}}<div class="message {{.Class}}">
+<p class="role"><strong>{{.Label}}</strong>{{if not .Time.IsZero}} <time datetime="{{.Time.Format "2006-01-02T15:04:05Z07:00"}}">{{.Time.Format "15:04"}}</time>{{end}}</p>
+<div class="content">{{.Body}}</div>
+</div>
+{{end}}</section>
+`))
+
+// transcriptMessage is a message prepared for the transcript template
+type transcriptMessage struct {
+	journal.Message
+	Label string
+	Class string
+	Body  template.HTML
+}
+
+// roleLabels maps message roles to the labels shown in transcripts
+var roleLabels = map[string]string{
+	journal.RoleUser:      "You",
+	journal.RoleAssistant: "AI",
+}
+
+// ConversationHTML renders an entry's AI conversation as a transcript
+// section, oldest message first. It returns an empty string when there are
+// no messages.
+func ConversationHTML(messages []journal.Message) (string, error) {
+	if len(messages) == 0 {
+		return "", nil
+	}
+
+	sorted := make([]journal.Message, len(messages))
+	copy(sorted, messages)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	items := make([]transcriptMessage, 0, len(sorted))
+	for _, msg := range sorted {
+		body, err := markdownHTML(msg.Content)
+		if err != nil {
+			return "", err
+		}
+
+		label, ok := roleLabels[msg.Role]
+		if !ok {
+			label = msg.Role
+		}
+		items = append(items, transcriptMessage{
+			Message: msg,
+			Label:   label,
+			Class:   "role-" + msg.Role,
+			Body:    template.HTML(body), // goldmark escapes raw HTML by default
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := transcriptTemplate.Execute(&buf, items); err != nil {
+		return "", fmt.Errorf("failed to render conversation: %w", err)
+	}
+	return buf.String(), nil
+}
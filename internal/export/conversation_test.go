@@ -0,0 +1,80 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+)
+
+func TestConversationHTML(t *testing.T) {
+	start := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	messages := []journal.Message{
+		{Role: journal.RoleAssistant, Content: "Try *one* more line.", Time: start.Add(2 * time.Minute)},
+		{Role: journal.RoleUser, Content: "I'm stuck <script>", Time: start},
+		{Role: "system", Content: "Be brief", Time: start.Add(time.Minute)},
+	}
+
+	got, err := ConversationHTML(messages)
+	if err != nil {
+		t.Fatalf("ConversationHTML: %v", err)
+	}
+
+	// Oldest first, whatever order they were stored in
+	order := []string{"I'm stuck", "Be brief", "Try <em>one</em> more line."}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(got, want)
+		if i < 0 {
+			t.Fatalf("transcript doesn't contain %q:\n%s", want, got)
+		}
+		if i < last {
+			t.Errorf("%q is out of order:\n%s", want, got)
+		}
+		last = i
+	}
+
+	for _, want := range []string{
+		`<div class="message role-user">`,
+		`<strong>You</strong> <time datetime="2024-03-05T09:30:00Z">09:30</time>`,
+		`<div class="message role-assistant">`,
+		`<strong>AI</strong>`,
+		`<strong>system</strong>`, // Unknown roles are shown as is
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("transcript doesn't contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<script>") || !strings.Contains(got, "raw HTML omitted") {
+		t.Errorf("raw HTML in a message was kept:\n%s", got)
+	}
+
+	if got, err := ConversationHTML(nil); got != "" || err != nil {
+		t.Errorf("no messages: got %q, %v; want nothing", got, err)
+	}
+}
+
+func TestDocument(t *testing.T) {
+	entry := &journal.JournalEntry{FileName: "entry.md", Content: "Morning words", WordCount: 2}
+	messages := []journal.Message{{Role: journal.RoleUser, Content: "Hello"}}
+
+	with, err := Document(entry, messages)
+	if err != nil {
+		t.Fatalf("Document: %v", err)
+	}
+	if !strings.Contains(with, `<section class="conversation">`) || !strings.Contains(with, "Hello") {
+		t.Errorf("document is missing the transcript:\n%s", with)
+	}
+	if strings.Index(with, "Morning words") > strings.Index(with, "Hello") {
+		t.Errorf("transcript comes before the entry:\n%s", with)
+	}
+
+	without, err := Document(entry, nil)
+	if err != nil {
+		t.Fatalf("Document: %v", err)
+	}
+	if strings.Contains(without, `<section class="conversation">`) {
+		t.Errorf("document without messages has a transcript:\n%s", without)
+	}
+}
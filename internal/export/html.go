@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/util"
+)
+
+// EntryHTML renders an entry's markdown content as an HTML fragment.
+// Raw HTML in the entry is escaped rather than passed through, and
+// attached images are embedded in the page.
+func EntryHTML(entry *journal.JournalEntry) (string, error) {
+	md := goldmark.New(goldmark.WithParserOptions(parser.WithASTTransformers(
+		util.Prioritized(attachmentResolver{dir: filepath.Dir(entry.FilePath)}, 100),
+	)))
+	return convert(md, entry.Content)
+}
+
+// markdownHTML renders markdown as an HTML fragment
+func markdownHTML(markdown string) (string, error) {
+	return convert(goldmark.New(), markdown)
+}
+
+// convert renders markdown as an HTML fragment with md
+func convert(md goldmark.Markdown, markdown string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(markdown), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return buf.String(), nil
+}
+
+var documentTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Entry.FileName}}</title>
+<style>
+body { max-width: 40em; margin: 2em auto; font-family: Georgia, serif; line-height: 1.5; }
+.conversation { margin-top: 3em; border-top: 1px solid #ccc; font-family: sans-serif; }
+.message { margin: 1em 0; padding: 0.5em 1em; border-radius: 6px; }
+.role-user { background: #eef4fb; }
+.role-assistant { background: #f4f4f4; }
+.role { margin: 0; color: #555; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{.Entry.FileName}}</h1>
+<p>{{.Entry.WordCount}} words</p>
+<article>
+{{.Body}}
+</article>
+{{.Conversation}}
+</body>
+</html>
+`))
+
+// Document renders an entry as a standalone HTML page, followed by a
+// transcript of its AI conversation when messages are given
+func Document(entry *journal.JournalEntry, messages []journal.Message) (string, error) {
+	body, err := EntryHTML(entry)
+	if err != nil {
+		return "", err
+	}
+
+	conversation, err := ConversationHTML(messages)
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		Entry        *journal.JournalEntry
+		Body         template.HTML
+		Conversation template.HTML
+	}{
+		Entry:        entry,
+		Body:         template.HTML(body), // goldmark escapes raw HTML by default
+		Conversation: template.HTML(conversation),
+	}
+
+	var buf bytes.Buffer
+	if err := documentTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render document: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,105 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// dirNames lists the names in dir
+func dirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("reading %s: %v", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // Content already at the target, if any
+	}{
+		{name: "new file"},
+		{name: "replaces a longer file", existing: "the old content, which is longer than the new"},
+		{name: "replaces a shorter file", existing: "old"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "entry.md")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := WriteFileAtomic(path, []byte("new content"), 0o640); err != nil {
+				t.Fatalf("WriteFileAtomic: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading target: %v", err)
+			}
+			if string(data) != "new content" {
+				t.Errorf("target = %q, want %q", data, "new content")
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0o640 {
+				t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+			}
+			if names := dirNames(t, dir); len(names) != 1 {
+				t.Errorf("directory holds %v, want only entry.md", names)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomicFailedRename(t *testing.T) {
+	dir := t.TempDir()
+
+	// A non-empty directory can't be renamed over, so the write fails at
+	// the last step
+	path := filepath.Join(dir, "entry.md")
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	inside := filepath.Join(path, "keep")
+	if err := os.WriteFile(inside, []byte("untouched"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new content"), 0o644); err == nil {
+		t.Fatal("WriteFileAtomic succeeded, want an error")
+	}
+
+	// The target is as it was and the temp file is gone
+	if data, err := os.ReadFile(inside); err != nil || string(data) != "untouched" {
+		t.Errorf("target changed: %q, %v", data, err)
+	}
+	if names := dirNames(t, dir); len(names) != 1 || names[0] != "entry.md" {
+		t.Errorf("directory holds %v, want only entry.md", names)
+	}
+}
+
+func TestWriteFileAtomicMissingDir(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "missing", "entry.md")
+
+	if err := WriteFileAtomic(path, []byte("new content"), 0o644); err == nil {
+		t.Fatal("WriteFileAtomic succeeded, want an error")
+	}
+	if names := dirNames(t, dir); len(names) != 0 {
+		t.Errorf("directory holds %v, want nothing", names)
+	}
+}
@@ -0,0 +1,68 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// AttachmentsDir is the directory, next to the entries, that attached files
+// are copied into
+const AttachmentsDir = "attachments"
+
+// imageExts are the attachment types linked as inline images
+var imageExts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".svg": true}
+
+// AttachFile copies srcPath into the attachments directory and returns its
+// path relative to the entry, for use in a markdown link. A file of the same
+// name already attached gets a numbered suffix rather than being replaced.
+func (m *Manager) AttachFile(entry *JournalEntry, srcPath string) (string, error) {
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	dir := filepath.Join(filepath.Dir(entry.FilePath), AttachmentsDir)
+	name, err := m.freeName(dir, filepath.Base(srcPath))
+	if err != nil {
+		return "", err
+	}
+	relPath := path.Join(AttachmentsDir, name)
+
+	if m.dryRun {
+		m.logger.Info("Dry run: would attach file",
+			zap.String("src", srcPath),
+			zap.String("dest", relPath))
+		return relPath, nil
+	}
+
+	if err := m.store.Write(filepath.Join(dir, name), data); err != nil {
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+
+	m.logger.Info("Attached file",
+		zap.String("entry", entry.FileName),
+		zap.String("file", relPath))
+	return relPath, nil
+}
+
+// AttachmentLink returns the markdown that references an attachment: an
+// inline image for pictures and a plain link for anything else
+func AttachmentLink(relPath string) string {
+	name := path.Base(relPath)
+	alt := strings.TrimSuffix(name, path.Ext(name))
+	// Angle brackets keep paths with spaces or parentheses intact
+	dest := relPath
+	if strings.ContainsAny(dest, " ()") {
+		dest = "<" + dest + ">"
+	}
+
+	if imageExts[strings.ToLower(path.Ext(name))] {
+		return fmt.Sprintf("![%s](%s)", alt, dest)
+	}
+	return fmt.Sprintf("[%s](%s)", name, dest)
+}
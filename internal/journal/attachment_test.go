@@ -0,0 +1,73 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestInAttachments(t *testing.T) {
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{rel: "2024-03-05T09:30-morning-pages.md", want: false},
+		{rel: "attachments.md", want: false},
+		{rel: filepath.Join("attachments", "notes.md"), want: true},
+		{rel: filepath.Join("2024", "attachments", "notes.md"), want: true},
+		{rel: filepath.Join("2024", "03", "attachments", "notes.md"), want: true},
+		{rel: filepath.Join("2024", "03", "attachments.md"), want: false},
+		{rel: filepath.Join("my-attachments", "notes.md"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			if got := inAttachments(tt.rel); got != tt.want {
+				t.Errorf("inAttachments(%q) = %v, want %v", tt.rel, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNestedAttachmentsAreNotEntries(t *testing.T) {
+	for _, layout := range []string{config.FolderLayoutFlat, config.FolderLayoutByMonth, config.FolderLayoutByYear} {
+		t.Run(layout, func(t *testing.T) {
+			m, _ := newTestManager(t, func(cfg *config.Config) {
+				cfg.Journal.FolderLayout = layout
+			})
+			entry, _, err := m.CreateEntryAt(time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local))
+			if err != nil {
+				t.Fatalf("CreateEntryAt: %v", err)
+			}
+
+			src := filepath.Join(t.TempDir(), "notes.md")
+			if err := os.WriteFile(src, []byte("not an entry"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			rel, err := m.AttachFile(entry, src)
+			if err != nil {
+				t.Fatalf("AttachFile: %v", err)
+			}
+
+			entries, err := m.ListEntries()
+			if err != nil {
+				t.Fatalf("ListEntries: %v", err)
+			}
+			if len(entries) != 1 || entries[0].FileName != entry.FileName {
+				t.Errorf("ListEntries = %v, want only %s", entries, entry.FileName)
+			}
+
+			if _, err := m.ResolveEntryPath("notes.md"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("ResolveEntryPath(notes.md): err = %v, want ErrNotFound", err)
+			}
+			attached, _ := filepath.Rel(testStorageDir, filepath.Join(filepath.Dir(entry.FilePath), rel))
+			if _, err := m.ResolveEntryPath(attached); !errors.Is(err, ErrNotFound) {
+				t.Errorf("ResolveEntryPath(%s): err = %v, want ErrNotFound", attached, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package journal
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LogAutosave records an autosave tick at debug level, idle ones included,
+// so a log taken with --debug shows whether autosave kept running and what
+// each tick wrote. bytes is how much content the tick saved, or 0 if
+// nothing changed, and err is why the save failed, if it did
+func (m *Manager) LogAutosave(entry *JournalEntry, at time.Time, bytes int, err error) {
+	if !m.logger.Core().Enabled(zap.DebugLevel) {
+		return // Skip counting words on every tick when nobody will see it
+	}
+	m.logger.Debug("Autosave",
+		zap.String("file", entry.FileName),
+		zap.Time("at", at),
+		zap.Int("word_count", m.countEntryWords(entry)),
+		zap.Int("bytes", bytes),
+		zap.Bool("idle", bytes == 0),
+		zap.Error(err))
+}
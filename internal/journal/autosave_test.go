@@ -0,0 +1,74 @@
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogAutosave(t *testing.T) {
+	at := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	entry := &JournalEntry{FileName: "entry.md", Content: "one two three"}
+	saveErr := errors.New("disk full")
+
+	tests := []struct {
+		name     string
+		level    zapcore.Level
+		bytes    int
+		err      error
+		wantLogs int
+		wantIdle bool
+	}{
+		{name: "save", level: zap.DebugLevel, bytes: 42, wantLogs: 1},
+		{name: "idle tick", level: zap.DebugLevel, bytes: 0, wantLogs: 1, wantIdle: true},
+		{name: "failed save", level: zap.DebugLevel, bytes: 42, err: saveErr, wantLogs: 1},
+		{name: "debug off", level: zap.InfoLevel, bytes: 42, wantLogs: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestManager(t, nil)
+			core, logs := observer.New(tt.level)
+			m.logger = zap.New(core)
+
+			m.LogAutosave(entry, at, tt.bytes, tt.err)
+
+			if logs.Len() != tt.wantLogs {
+				t.Fatalf("got %d log entries, want %d", logs.Len(), tt.wantLogs)
+			}
+			if tt.wantLogs == 0 {
+				return
+			}
+
+			logged := logs.All()[0]
+			if logged.Level != zap.DebugLevel || logged.Message != "Autosave" {
+				t.Errorf("logged %v %q, want debug %q", logged.Level, logged.Message, "Autosave")
+			}
+			fields := logged.ContextMap()
+			want := map[string]any{
+				"file":       "entry.md",
+				"at":         at,
+				"word_count": int64(3),
+				"bytes":      int64(tt.bytes),
+				"idle":       tt.wantIdle,
+			}
+			for key, value := range want {
+				if fields[key] != value {
+					t.Errorf("%s = %v, want %v", key, fields[key], value)
+				}
+			}
+			if tt.err != nil && fields["error"] != tt.err.Error() {
+				t.Errorf("error = %v, want %q", fields["error"], tt.err)
+			}
+			if tt.err == nil {
+				if _, ok := fields["error"]; ok {
+					t.Errorf("error logged for a save that worked: %v", fields["error"])
+				}
+			}
+		})
+	}
+}
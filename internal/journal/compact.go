@@ -0,0 +1,182 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// sidecarSuffixes are the files kept next to an entry, named after it
+var sidecarSuffixes = []string{".history.json", ".conversation.json", ".embedding.json"}
+
+// CompactOptions configures Compact
+type CompactOptions struct {
+	OlderThan time.Duration // Only files last modified longer ago than this are stale
+	Now       time.Time     // Time ages are measured from; zero means time.Now()
+}
+
+// StaleFile is a file Compact removed, or would remove in a dry run
+type StaleFile struct {
+	Path    string    `json:"path"`
+	Reason  string    `json:"reason"`
+	ModTime time.Time `json:"mod_time"`
+	Size    int       `json:"size"`
+}
+
+// staleReason says why a file in the journal directory is clutter, or
+// returns "" for files that belong there. entries holds the names of the
+// entry files present.
+func staleReason(name string, entries map[string]bool) string {
+	switch {
+	case strings.HasPrefix(name, ".") && strings.Contains(name, ".tmp-"),
+		strings.HasPrefix(name, ".momentum-write-check-"):
+		return "interrupted write"
+	case strings.HasSuffix(name, ".bak"), strings.HasSuffix(name, "~"):
+		return "backup"
+	case strings.HasSuffix(name, ".draft"):
+		return "draft"
+	}
+	for _, suffix := range sidecarSuffixes {
+		if stem, ok := strings.CutSuffix(name, suffix); ok && !entries[stem+".md"] {
+			return "orphaned sidecar"
+		}
+	}
+	return ""
+}
+
+// staleKind says what a directory Compact scans holds, and so which of
+// its files can be stale
+type staleKind int
+
+const (
+	staleInJournal staleKind = iota // Entries and their leftovers
+	staleInDrafts                   // Drafts, stale once their entry is gone
+	staleInTrash                    // Trashed entries, all stale once old enough
+)
+
+// Compact removes clutter from the journal directory that is older than
+// opts.OlderThan: files left by interrupted writes, backups, drafts, and
+// sidecars of deleted entries. It also empties the trash of entries trashed
+// that long ago. Drafts in a separate draft directory are stale once they
+// are that old too and their entry no longer exists; the draft of an entry
+// that does can still be recovered by opening it. In a dry run nothing is
+// removed. Either way the stale files are returned, oldest first.
+func (m *Manager) Compact(opts CompactOptions) ([]StaleFile, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	cutoff := now.Add(-opts.OlderThan)
+	storageDir := m.config.Journal.StorageDir
+
+	stale, err := m.findStale(storageDir, cutoff, staleInJournal, nil)
+	if err != nil {
+		return nil, err
+	}
+	trash, err := m.findStale(filepath.Join(storageDir, TrashDir), cutoff, staleInTrash, nil)
+	if err != nil {
+		return nil, err
+	}
+	stale = append(stale, trash...)
+	if dir := m.config.Journal.DraftDir; dir != "" && filepath.Clean(dir) != filepath.Clean(storageDir) {
+		live, err := m.entryNames()
+		if err != nil {
+			return nil, err
+		}
+		drafts, err := m.findStale(dir, cutoff, staleInDrafts, live)
+		if err != nil {
+			return nil, err
+		}
+		stale = append(stale, drafts...)
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].ModTime.Before(stale[j].ModTime)
+	})
+
+	for _, file := range stale {
+		if m.dryRun {
+			m.logger.Info("Dry run: would remove stale file",
+				zap.String("file", file.Path),
+				zap.String("reason", file.Reason))
+			continue
+		}
+		if err := m.store.Delete(file.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove %s: %w", file.Path, err)
+		}
+		m.logger.Info("Removed stale file",
+			zap.String("file", file.Path),
+			zap.String("reason", file.Reason))
+	}
+	return stale, nil
+}
+
+// entryNames returns the file names of the entries in the journal, in any
+// folder
+func (m *Manager) entryNames() (map[string]bool, error) {
+	paths, err := m.store.Walk(m.config.Journal.StorageDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read journal directory: %w", err)
+	}
+	names := make(map[string]bool)
+	for _, rel := range paths {
+		if strings.HasSuffix(rel, ".md") && !inAttachments(rel) {
+			names[filepath.Base(rel)] = true
+		}
+	}
+	return names, nil
+}
+
+// findStale lists the stale files of the given kind in dir last modified
+// before cutoff. live holds the names of the journal's entries, which
+// drafts are checked against.
+func (m *Manager) findStale(dir string, cutoff time.Time, kind staleKind, live map[string]bool) ([]StaleFile, error) {
+	names, err := m.store.List(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	entries := make(map[string]bool)
+	for _, name := range names {
+		if strings.HasSuffix(name, ".md") {
+			entries[name] = true
+		}
+	}
+
+	var stale []StaleFile
+	for _, name := range names {
+		var reason string
+		switch kind {
+		case staleInJournal:
+			reason = staleReason(name, entries)
+		case staleInDrafts:
+			if !live[name] {
+				reason = "abandoned draft"
+			}
+		case staleInTrash:
+			reason = "old trash"
+		}
+		if reason == "" {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		data, modTime, err := m.store.Read(path)
+		if err != nil {
+			continue // Removed since it was listed
+		}
+		if !modTime.Before(cutoff) {
+			continue
+		}
+		stale = append(stale, StaleFile{Path: path, Reason: reason, ModTime: modTime, Size: len(data)})
+	}
+	return stale, nil
+}
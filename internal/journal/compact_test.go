@@ -0,0 +1,114 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// age sets the modification time of the file at path to at
+func (s *MemoryStore) age(t *testing.T, path string, at time.Time) {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.files[filepath.Clean(path)]
+	if !ok {
+		t.Fatalf("no file at %s to age", path)
+	}
+	f.modTime = at
+	s.files[filepath.Clean(path)] = f
+}
+
+func TestCompact(t *testing.T) {
+	now := time.Date(2024, 3, 20, 9, 0, 0, 0, time.Local)
+	old := now.Add(-30 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	m, store := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.DraftDir = "/drafts"
+	})
+	kept, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	trashed, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	trashPath, err := m.TrashEntry(trashed.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+
+	files := map[string]time.Time{
+		kept.FilePath: old,
+		trashPath:     old,
+		filepath.Join(testStorageDir, TrashDir, "recent.md"): recent,
+		filepath.Join(testStorageDir, "old.md.bak"):          old,
+		filepath.Join(testStorageDir, "new.md.bak"):          recent,
+		filepath.Join("/drafts", kept.FileName):              old,
+		filepath.Join("/drafts", "gone-morning-pages.md"):    old,
+		filepath.Join("/drafts", "fresh-morning-pages.md"):   recent,
+	}
+	for path, at := range files {
+		if !store.has(path) {
+			if err := store.Write(path, []byte("x")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		store.age(t, path, at)
+	}
+
+	wantStale := map[string]string{
+		trashPath: "old trash",
+		filepath.Join(testStorageDir, "old.md.bak"):       "backup",
+		filepath.Join("/drafts", "gone-morning-pages.md"): "abandoned draft",
+	}
+	check := func(t *testing.T, stale []StaleFile) {
+		t.Helper()
+		got := map[string]string{}
+		for _, file := range stale {
+			got[file.Path] = file.Reason
+		}
+		if len(got) != len(wantStale) {
+			t.Errorf("stale files = %v, want %v", got, wantStale)
+		}
+		for path, reason := range wantStale {
+			if got[path] != reason {
+				t.Errorf("%s: reason = %q, want %q", path, got[path], reason)
+			}
+		}
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		dry, _ := newTestManager(t, func(cfg *config.Config) {
+			cfg.Journal.DraftDir = "/drafts"
+		}, WithStore(store), WithDryRun(true))
+		stale, err := dry.Compact(CompactOptions{OlderThan: 7 * 24 * time.Hour, Now: now})
+		if err != nil {
+			t.Fatalf("Compact: %v", err)
+		}
+		check(t, stale)
+		for path := range files {
+			if !store.has(path) {
+				t.Errorf("dry run removed %s", path)
+			}
+		}
+	})
+
+	t.Run("removes", func(t *testing.T) {
+		stale, err := m.Compact(CompactOptions{OlderThan: 7 * 24 * time.Hour, Now: now})
+		if err != nil {
+			t.Fatalf("Compact: %v", err)
+		}
+		check(t, stale)
+		for path := range files {
+			_, removed := wantStale[path]
+			if store.has(path) == removed {
+				t.Errorf("%s: present = %v, want %v", path, !removed, removed)
+			}
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+// Message roles in an entry's AI conversation
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is a single turn in the AI conversation attached to an entry
+type Message struct {
+	Role    string    `json:"role"` // RoleUser or RoleAssistant
+	Content string    `json:"content"`
+	Time    time.Time `json:"time"`
+}
+
+// conversationPath returns the sidecar file used to persist an entry's AI conversation
+func conversationPath(entryPath string) string {
+	return strings.TrimSuffix(entryPath, ".md") + ".conversation.json"
+}
+
+// LoadConversation reads the AI conversation attached to the entry. An entry
+// without a conversation has no messages. An encrypted conversation needs
+// the passphrase; one saved before encryption was turned on is read as is.
+func (m *Manager) LoadConversation(entry *JournalEntry) ([]Message, error) {
+	data, _, err := m.store.Read(conversationPath(entry.FilePath))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read conversation: %w", err)
+	}
+
+	// Plaintext conversations are JSON arrays; anything else was encrypted
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '[' {
+		plaintext, err := m.decrypt(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read conversation: %w", err)
+		}
+		data = []byte(plaintext)
+	}
+
+	var messages []Message
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse conversation: %w", err)
+	}
+
+	return messages, nil
+}
+
+// SaveConversation writes the AI conversation attached to the entry,
+// encrypted like the entry body when a passphrase is set
+func (m *Manager) SaveConversation(entry *JournalEntry, messages []Message) error {
+	if len(messages) == 0 || m.dryRun {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal conversation: %w", err)
+	}
+
+	if m.passphrase != "" {
+		sealed, err := m.encrypt(string(data))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt conversation: %w", err)
+		}
+		data = []byte(sealed)
+	}
+
+	if err := m.store.Write(conversationPath(entry.FilePath), data); err != nil {
+		return fmt.Errorf("failed to write conversation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package journal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters for deriving the entry key from the passphrase
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	keyLen       = 32 // AES-256
+	saltLen      = 16
+	encryptedKey = "encrypted" // Frontmatter flag on entries whose body is encrypted
+)
+
+// SaltFile holds the journal's salt in StorageDir, so every session
+// derives the same key and it only needs deriving once. It isn't secret,
+// and deleting it only makes the next session pick a new salt.
+const SaltFile = ".salt"
+
+// KeyCheckFile holds a known constant sealed with the journal's key, next
+// to SaltFile, so a mistyped passphrase is caught before anything is
+// written under a different key
+const KeyCheckFile = ".keycheck"
+
+// keyCheckPlaintext is what KeyCheckFile decrypts to with the right passphrase
+const keyCheckPlaintext = "momentum journal key check"
+
+// WithPassphrase makes the manager encrypt entry bodies on save and decrypt
+// them on read. The passphrase is only held in memory.
+func WithPassphrase(passphrase string) Option {
+	return func(m *Manager) {
+		m.passphrase = passphrase
+	}
+}
+
+// key derives the AES key for salt, remembering it since scrypt is
+// deliberately slow
+func (m *Manager) key(salt []byte) ([]byte, error) {
+	m.keysMu.Lock()
+	defer m.keysMu.Unlock()
+	return m.deriveKey(salt)
+}
+
+// deriveKey is key for callers already holding keysMu
+func (m *Manager) deriveKey(salt []byte) ([]byte, error) {
+	if key, ok := m.keys[string(salt)]; ok {
+		return key, nil
+	}
+	key, err := scrypt.Key([]byte(m.passphrase), salt, scryptN, scryptR, scryptP, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+	if m.keys == nil {
+		m.keys = make(map[string][]byte)
+	}
+	m.keys[string(salt)] = key
+	return key, nil
+}
+
+// saveSalt returns the salt new ciphertexts are written with: the
+// journal's, from SaltFile, or a new one saved there for the first entry
+func (m *Manager) saveSalt() ([]byte, error) {
+	m.keysMu.Lock()
+	defer m.keysMu.Unlock()
+
+	if err := m.initCrypt(true); err != nil {
+		return nil, err
+	}
+	return m.salt, nil
+}
+
+// CheckPassphrase checks the passphrase against the journal's KeyCheckFile,
+// returning ErrWrongPassphrase if it doesn't match. A journal with nothing
+// encrypted yet has nothing to check against, so any passphrase passes.
+func (m *Manager) CheckPassphrase() error {
+	m.keysMu.Lock()
+	defer m.keysMu.Unlock()
+	return m.initCrypt(false)
+}
+
+// initCrypt reads the journal's salt and checks the passphrase against
+// KeyCheckFile. With create, a journal without a salt gets a new one and a
+// journal without a key check gets one for this passphrase; without it,
+// nothing is written. Callers hold keysMu.
+func (m *Manager) initCrypt(create bool) error {
+	if m.salt != nil {
+		return nil
+	}
+
+	dir := m.config.Journal.StorageDir
+	saltPath := filepath.Join(dir, SaltFile)
+	salt, err := m.readBase64(saltPath)
+	fresh := false
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && create:
+		fresh = true
+		salt = make([]byte, saltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("failed to generate salt: %w", err)
+		}
+		if err := m.store.Write(saltPath, []byte(base64.StdEncoding.EncodeToString(salt)+"\n")); err != nil {
+			return fmt.Errorf("failed to save salt: %w", err)
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read salt: %w", err)
+	case len(salt) != saltLen:
+		return fmt.Errorf("failed to read salt: %s is malformed", saltPath)
+	}
+
+	key, err := m.deriveKey(salt)
+	if err != nil {
+		return err
+	}
+	gcm, err := newKeyGCM(key)
+	if err != nil {
+		return err
+	}
+
+	// A new salt needs a new key check, as does a journal encrypted
+	// before key checks existed
+	checkPath := filepath.Join(dir, KeyCheckFile)
+	check, err := m.readBase64(checkPath)
+	switch {
+	case fresh || errors.Is(err, fs.ErrNotExist) && create:
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		sealed := gcm.Seal(nonce, nonce, []byte(keyCheckPlaintext), nil)
+		if err := m.store.Write(checkPath, []byte(base64.StdEncoding.EncodeToString(sealed)+"\n")); err != nil {
+			return fmt.Errorf("failed to save key check: %w", err)
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read key check: %w", err)
+	case len(check) < gcm.NonceSize():
+		return fmt.Errorf("failed to read key check: %s is malformed", checkPath)
+	default:
+		plaintext, err := gcm.Open(nil, check[:gcm.NonceSize()], check[gcm.NonceSize():], nil)
+		if err != nil || string(plaintext) != keyCheckPlaintext {
+			return fmt.Errorf("%w: it doesn't match the journal's key check", ErrWrongPassphrase)
+		}
+	}
+
+	m.salt = salt
+	return nil
+}
+
+// readBase64 reads a base64 encoded file from the store
+func (m *Manager) readBase64(path string) ([]byte, error) {
+	data, _, err := m.store.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%s is malformed: %w", path, err)
+	}
+	return decoded, nil
+}
+
+// newGCM returns an AES-GCM cipher keyed from the passphrase and salt
+func (m *Manager) newGCM(salt []byte) (cipher.AEAD, error) {
+	key, err := m.key(salt)
+	if err != nil {
+		return nil, err
+	}
+	return newKeyGCM(key)
+}
+
+// newKeyGCM returns an AES-GCM cipher for key
+func newKeyGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt seals plaintext with AES-GCM and returns it base64 encoded as
+// salt, nonce and ciphertext
+func (m *Manager) encrypt(plaintext string) (string, error) {
+	salt, err := m.saveSalt()
+	if err != nil {
+		return "", err
+	}
+	gcm, err := m.newGCM(salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	payload := append(append(append([]byte{}, salt...), nonce...), gcm.Seal(nil, nonce, []byte(plaintext), nil)...)
+	return base64.StdEncoding.EncodeToString(payload) + "\n", nil
+}
+
+// decrypt opens a body written by encrypt. A wrong passphrase and a
+// modified file look the same to AES-GCM, so both return ErrWrongPassphrase.
+func (m *Manager) decrypt(body string) (string, error) {
+	if m.passphrase == "" {
+		return "", ErrPassphraseRequired
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimSpace(body))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrWrongPassphrase, err)
+	}
+	if len(payload) < saltLen {
+		return "", fmt.Errorf("%w: ciphertext too short", ErrWrongPassphrase)
+	}
+
+	salt := payload[:saltLen]
+	gcm, err := m.newGCM(salt)
+	if err != nil {
+		return "", err
+	}
+	rest := payload[saltLen:]
+	if len(rest) < gcm.NonceSize() {
+		return "", fmt.Errorf("%w: ciphertext too short", ErrWrongPassphrase)
+	}
+
+	plaintext, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", ErrWrongPassphrase
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,220 @@
+package journal
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+// saveEncrypted creates an entry holding content with a manager using
+// passphrase on store
+func saveEncrypted(t *testing.T, store *MemoryStore, passphrase, content string) (*Manager, *JournalEntry) {
+	t.Helper()
+	m, _ := newTestManager(t, nil, WithStore(store), WithPassphrase(passphrase))
+	entry, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	entry.Content = content
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	return m, entry
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	store := NewMemoryStore()
+	m, entry := saveEncrypted(t, store, "correct horse", "dear diary, a secret")
+
+	data, _, err := store.Read(entry.FilePath)
+	if err != nil {
+		t.Fatalf("reading entry file: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("entry file holds the plaintext: %q", data)
+	}
+	if !strings.Contains(string(data), encryptedKey+": true") {
+		t.Errorf("entry file isn't flagged encrypted: %q", data)
+	}
+
+	read, err := m.ReadEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if read.Content != entry.Content {
+		t.Errorf("Content = %q, want %q", read.Content, entry.Content)
+	}
+
+	// A later session with the same passphrase reads it too
+	later, _ := newTestManager(t, nil, WithStore(store), WithPassphrase("correct horse"))
+	read, err = later.ReadEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry in a later session: %v", err)
+	}
+	if read.Content != entry.Content {
+		t.Errorf("later Content = %q, want %q", read.Content, entry.Content)
+	}
+}
+
+func TestEncryptKeepsJournalSalt(t *testing.T) {
+	store := NewMemoryStore()
+	first, _ := saveEncrypted(t, store, "correct horse", "one")
+	salt, _, err := store.Read(filepath.Join(testStorageDir, SaltFile))
+	if err != nil {
+		t.Fatalf("salt wasn't saved: %v", err)
+	}
+
+	// Every session writes with the journal's salt, so one key opens all
+	second, _ := saveEncrypted(t, store, "correct horse", "two")
+	if string(first.salt) != string(second.salt) {
+		t.Errorf("sessions used different salts")
+	}
+	again, _, _ := store.Read(filepath.Join(testStorageDir, SaltFile))
+	if string(again) != string(salt) {
+		t.Errorf("salt file changed from %q to %q", salt, again)
+	}
+
+	entries, err := second.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("got %d entries, want 2", len(entries))
+	}
+	if len(second.keys) != 1 {
+		t.Errorf("derived %d keys, want 1 for the one salt", len(second.keys))
+	}
+}
+
+func TestDecryptWrongPassphrase(t *testing.T) {
+	store := NewMemoryStore()
+	_, entry := saveEncrypted(t, store, "correct horse", "a secret")
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.StorageDir = testStorageDir
+	if _, err := NewManager(cfg, zap.NewNop(), WithStore(store), WithPassphrase("battery staple")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("NewManager with the wrong passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+
+	// A journal encrypted before key checks existed only finds out on read
+	if err := store.Delete(filepath.Join(testStorageDir, KeyCheckFile)); err != nil {
+		t.Fatalf("removing key check: %v", err)
+	}
+	wrong, _ := newTestManager(t, nil, WithStore(store), WithPassphrase("battery staple"))
+	if _, err := wrong.ReadEntry(entry.FilePath); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("wrong passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+	if _, err := wrong.ListEntries(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("listing with the wrong passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+
+	none, _ := newTestManager(t, nil, WithStore(store))
+	if _, err := none.ReadEntry(entry.FilePath); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("no passphrase: err = %v, want ErrPassphraseRequired", err)
+	}
+}
+
+func TestWrongPassphraseCannotWrite(t *testing.T) {
+	// Both sessions start before the journal has a key check
+	store := NewMemoryStore()
+	right, _ := newTestManager(t, nil, WithStore(store), WithPassphrase("correct horse"))
+	typo, _ := newTestManager(t, nil, WithStore(store), WithPassphrase("correct hrose"))
+
+	entry, err := right.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	before := len(store.files)
+
+	if _, err := typo.CreateEntry(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("CreateEntry with a mistyped passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+	typoEntry := *entry
+	typoEntry.Content = "written under the wrong key"
+	if err := typo.SaveEntry(&typoEntry); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("SaveEntry with a mistyped passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+	if err := typo.SaveConversation(entry, []Message{{Role: RoleUser, Content: "hi"}}); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("SaveConversation with a mistyped passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+	if after := len(store.files); after != before {
+		t.Errorf("store has %d files after the mistyped writes, want %d", after, before)
+	}
+
+	read, err := right.ReadEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if read.Content != entry.Content {
+		t.Errorf("Content = %q, want it unchanged as %q", read.Content, entry.Content)
+	}
+}
+
+func TestConversationEncrypted(t *testing.T) {
+	store := NewMemoryStore()
+	m, entry := saveEncrypted(t, store, "correct horse", "a secret")
+	messages := []Message{{Role: RoleUser, Content: "what am I hiding?"}}
+	if err := m.SaveConversation(entry, messages); err != nil {
+		t.Fatalf("SaveConversation: %v", err)
+	}
+
+	data, _, err := store.Read(conversationPath(entry.FilePath))
+	if err != nil {
+		t.Fatalf("conversation wasn't saved: %v", err)
+	}
+	if strings.Contains(string(data), "hiding") {
+		t.Errorf("conversation file holds the plaintext: %q", data)
+	}
+
+	loaded, err := m.LoadConversation(entry)
+	if err != nil {
+		t.Fatalf("LoadConversation: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Content != messages[0].Content {
+		t.Errorf("loaded %+v, want %+v", loaded, messages)
+	}
+
+	none, _ := newTestManager(t, nil, WithStore(store))
+	if _, err := none.LoadConversation(entry); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("no passphrase: err = %v, want ErrPassphraseRequired", err)
+	}
+
+	// A conversation saved before encryption was turned on still loads
+	plain := `[{"role": "user", "content": "from before"}]`
+	if err := store.Write(conversationPath(entry.FilePath), []byte(plain)); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = m.LoadConversation(entry)
+	if err != nil || len(loaded) != 1 || loaded[0].Content != "from before" {
+		t.Errorf("plaintext conversation = %+v, %v", loaded, err)
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	store := NewMemoryStore()
+	m, entry := saveEncrypted(t, store, "correct horse", "a secret")
+	data, _, err := store.Read(entry.FilePath)
+	if err != nil {
+		t.Fatalf("reading entry file: %v", err)
+	}
+
+	// Swap one base64 character near the end of the ciphertext
+	text := strings.TrimRight(string(data), "\n")
+	i := len(text) - 4
+	swap := byte('A')
+	if text[i] == 'A' {
+		swap = 'B'
+	}
+	tampered := text[:i] + string(swap) + text[i+1:] + "\n"
+	if err := store.Write(entry.FilePath, []byte(tampered)); err != nil {
+		t.Fatalf("writing entry file: %v", err)
+	}
+
+	if _, err := m.ReadEntry(entry.FilePath); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("tampered entry: err = %v, want ErrWrongPassphrase", err)
+	}
+}
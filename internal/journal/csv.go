@@ -0,0 +1,87 @@
+package journal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// StatsCSVHeader names the columns written by WriteStatsCSV, in order. New
+// columns go on the end so existing spreadsheets keep working.
+var StatsCSVHeader = []string{
+	"date",      // Day the entry was created, as YYYY-MM-DD
+	"time",      // Time the entry was created, as HH:MM
+	"file",      // Entry file name
+	"words",     // Words of prose, leaving out any header
+	"goal",      // The entry's goal, in goal_unit
+	"goal_unit", // What the goal counts: words, characters or minutes
+	"completed", // Whether the goal was met: true or false
+	"minutes",   // Minutes spent writing, empty when no history was recorded
+}
+
+// StatsCSVOption configures what WriteStatsCSV writes
+type StatsCSVOption func(*statsCSVOptions)
+
+type statsCSVOptions struct {
+	goal GoalStrategy
+}
+
+// StatsGoal fills the goal and goal_unit columns from goal, normally the
+// Manager's Goal(). Without it they hold the entry's own word goal from
+// its frontmatter, or 0 when it has none
+func StatsGoal(goal GoalStrategy) StatsCSVOption {
+	return func(o *statsCSVOptions) {
+		o.goal = goal
+	}
+}
+
+// WriteStatsCSV writes one row per entry, oldest first, under
+// StatsCSVHeader
+func WriteStatsCSV(entries []*JournalEntry, w io.Writer, opts ...StatsCSVOption) error {
+	var options statsCSVOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	sorted := make([]*JournalEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(StatsCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, entry := range sorted {
+		minutes := ""
+		if len(entry.WordCountHistory) > 0 {
+			minutes = strconv.Itoa(int(timeSpent(entry.WordCountHistory).Minutes()))
+		}
+		goal, unit := entry.Goal, "words"
+		if options.goal != nil {
+			_, goal = options.goal.Progress(entry)
+			unit = options.goal.Unit()
+		}
+		row := []string{
+			entry.CreatedAt.Format("2006-01-02"),
+			entry.CreatedAt.Format("15:04"),
+			entry.FileName,
+			strconv.Itoa(entry.WordCount),
+			strconv.Itoa(goal),
+			unit,
+			strconv.FormatBool(entry.IsCompleted),
+			minutes,
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// statsEntries returns two entries, newest first: one with word count
+// history and one with its own goal in its frontmatter
+func statsEntries() []*JournalEntry {
+	morning := time.Date(2024, 3, 6, 7, 15, 0, 0, time.Local)
+	return []*JournalEntry{
+		{
+			FileName:    "2024-03-06-0715.md",
+			CreatedAt:   morning,
+			WordCount:   820,
+			IsCompleted: true,
+			WordCountHistory: []Sample{
+				{Time: morning, WordCount: 0},
+				{Time: morning.Add(2 * time.Minute), WordCount: 300},
+				{Time: morning.Add(12 * time.Minute), WordCount: 820}, // A break, not writing time
+			},
+		},
+		{
+			FileName:  "2024-03-05-2140.md",
+			CreatedAt: time.Date(2024, 3, 5, 21, 40, 0, 0, time.Local),
+			WordCount: 120,
+			Goal:      300,
+		},
+	}
+}
+
+func TestWriteStatsCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		goalType string // Empty to write without StatsGoal
+		want     string
+	}{
+		{
+			name: "entry goals only",
+			want: `date,time,file,words,goal,goal_unit,completed,minutes
+2024-03-05,21:40,2024-03-05-2140.md,120,300,words,false,
+2024-03-06,07:15,2024-03-06-0715.md,820,0,words,true,2
+`,
+		},
+		{
+			name:     "word goal",
+			goalType: config.GoalTypeWords,
+			want: `date,time,file,words,goal,goal_unit,completed,minutes
+2024-03-05,21:40,2024-03-05-2140.md,120,300,words,false,
+2024-03-06,07:15,2024-03-06-0715.md,820,500,words,true,2
+`,
+		},
+		{
+			name:     "minutes goal",
+			goalType: config.GoalTypeMinutes,
+			want: `date,time,file,words,goal,goal_unit,completed,minutes
+2024-03-05,21:40,2024-03-05-2140.md,120,300,minutes,false,
+2024-03-06,07:15,2024-03-06-0715.md,820,500,minutes,true,2
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []StatsCSVOption
+			if tt.goalType != "" {
+				m, _ := newTestManager(t, func(cfg *config.Config) {
+					cfg.Journal.GoalType = tt.goalType
+					cfg.Journal.WordCountGoal = 500
+				})
+				opts = append(opts, StatsGoal(m.Goal()))
+			}
+
+			var out strings.Builder
+			if err := WriteStatsCSV(statsEntries(), &out, opts...); err != nil {
+				t.Fatalf("WriteStatsCSV: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("CSV =\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteStatsCSVEmpty(t *testing.T) {
+	var out strings.Builder
+	if err := WriteStatsCSV(nil, &out); err != nil {
+		t.Fatalf("WriteStatsCSV: %v", err)
+	}
+	if want := strings.Join(StatsCSVHeader, ",") + "\n"; out.String() != want {
+		t.Errorf("CSV = %q, want just the header %q", out.String(), want)
+	}
+}
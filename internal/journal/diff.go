@@ -0,0 +1,74 @@
+package journal
+
+import "strings"
+
+// DiffOp says how a line changed between two versions of an entry
+type DiffOp int
+
+const (
+	DiffEqual   DiffOp = iota // Present in both versions
+	DiffAdded                 // Only in the new version
+	DiffRemoved               // Only in the old version
+)
+
+// DiffLine is one line of a line-level diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines compares two versions of an entry line by line, returning every
+// line of both in order with removals before the additions that replace
+// them. It uses a longest-common-subsequence table, which is plenty fast
+// for journal-sized text.
+func DiffLines(before, after string) []DiffLine {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]DiffLine, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, DiffLine{Op: DiffEqual, Text: b[j]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, DiffLine{Op: DiffRemoved, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{Op: DiffAdded, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Op: DiffRemoved, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{Op: DiffAdded, Text: b[j]})
+	}
+	return diff
+}
+
+// splitLines splits text into lines, treating empty text as no lines
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
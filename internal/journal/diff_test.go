@@ -0,0 +1,38 @@
+package journal
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	eq := func(s string) DiffLine { return DiffLine{Op: DiffEqual, Text: s} }
+	add := func(s string) DiffLine { return DiffLine{Op: DiffAdded, Text: s} }
+	del := func(s string) DiffLine { return DiffLine{Op: DiffRemoved, Text: s} }
+
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []DiffLine
+	}{
+		{name: "both empty", before: "", after: "", want: []DiffLine{}},
+		{name: "no change", before: "a\nb\nc", after: "a\nb\nc", want: []DiffLine{eq("a"), eq("b"), eq("c")}},
+		{name: "from empty", before: "", after: "a\nb", want: []DiffLine{add("a"), add("b")}},
+		{name: "to empty", before: "a\nb", after: "", want: []DiffLine{del("a"), del("b")}},
+		{name: "insert in middle", before: "a\nc", after: "a\nb\nc", want: []DiffLine{eq("a"), add("b"), eq("c")}},
+		{name: "insert at end", before: "a", after: "a\nb", want: []DiffLine{eq("a"), add("b")}},
+		{name: "delete in middle", before: "a\nb\nc", after: "a\nc", want: []DiffLine{eq("a"), del("b"), eq("c")}},
+		{name: "delete at start", before: "a\nb", after: "b", want: []DiffLine{del("a"), eq("b")}},
+		{name: "replace puts removal first", before: "a\nold\nc", after: "a\nnew\nc", want: []DiffLine{eq("a"), del("old"), add("new"), eq("c")}},
+		{name: "trailing newline added", before: "a", after: "a\n", want: []DiffLine{eq("a"), add("")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffLines(tt.before, tt.after); !slices.Equal(got, tt.want) {
+				t.Errorf("DiffLines(%q, %q) = %+v, want %+v", tt.before, tt.after, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+// UsesDraftDir reports whether autosaves go to a separate draft directory
+// instead of straight to the entry file
+func (m *Manager) UsesDraftDir() bool {
+	return m.config.Journal.DraftDir != ""
+}
+
+// draftPath returns where the entry's autosaved draft is kept
+func (m *Manager) draftPath(entry *JournalEntry) string {
+	return filepath.Join(m.config.Journal.DraftDir, entry.FileName)
+}
+
+// SaveDraft writes the entry's current content to the draft directory,
+// leaving the entry file in StorageDir untouched
+func (m *Manager) SaveDraft(entry *JournalEntry) error {
+	if m.dryRun {
+		m.logger.Info("Dry run: would save draft",
+			zap.String("file", m.draftPath(entry)))
+		return nil
+	}
+
+	content := entry.Content
+	if m.passphrase != "" {
+		var err error
+		if content, err = m.encrypt(content); err != nil {
+			return fmt.Errorf("failed to encrypt draft: %w", err)
+		}
+	}
+
+	if err := m.store.Write(m.draftPath(entry), []byte(content)); err != nil {
+		return fmt.Errorf("failed to write draft: %w", err)
+	}
+
+	m.logger.Debug("Saved draft",
+		zap.String("file", entry.FileName),
+		zap.String("dir", m.config.Journal.DraftDir),
+		zap.Int("bytes", len(content)))
+	return nil
+}
+
+// LoadDraft returns the draft left for the entry by an earlier session, and
+// whether there is one worth recovering: a draft matching the entry's
+// content has nothing to add
+func (m *Manager) LoadDraft(entry *JournalEntry) (string, bool, error) {
+	if !m.UsesDraftDir() {
+		return "", false, nil
+	}
+
+	data, _, err := m.store.Read(m.draftPath(entry))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read draft: %w", err)
+	}
+
+	content := string(data)
+	if m.passphrase != "" {
+		if content, err = m.decrypt(content); err != nil {
+			return "", false, fmt.Errorf("failed to decrypt draft: %w", err)
+		}
+	}
+	return content, content != entry.Content, nil
+}
+
+// RemoveDraft deletes the entry's draft once its content has been saved
+// to StorageDir. A missing draft is not an error.
+func (m *Manager) RemoveDraft(entry *JournalEntry) error {
+	if !m.UsesDraftDir() || m.dryRun {
+		return nil
+	}
+
+	if err := m.store.Delete(m.draftPath(entry)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove draft: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+const testDraftDir = "/drafts"
+
+func TestLoadDraft(t *testing.T) {
+	tests := []struct {
+		name        string
+		draftDir    string
+		passphrase  string
+		draft       string // Saved as a draft when not empty
+		wantDraft   string
+		wantRecover bool
+	}{
+		{name: "no draft dir", draft: "unsaved words"},
+		{name: "no draft", draftDir: testDraftDir},
+		{name: "draft matches the entry", draftDir: testDraftDir, draft: "saved words"},
+		{name: "draft differs", draftDir: testDraftDir, draft: "unsaved words", wantDraft: "unsaved words", wantRecover: true},
+		{name: "encrypted draft", draftDir: testDraftDir, passphrase: "correct horse", draft: "unsaved words", wantDraft: "unsaved words", wantRecover: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.passphrase != "" {
+				opts = append(opts, WithPassphrase(tt.passphrase))
+			}
+			m, store := newTestManager(t, func(cfg *config.Config) {
+				cfg.Journal.DraftDir = tt.draftDir
+			}, opts...)
+			entry, err := m.CreateEntry()
+			if err != nil {
+				t.Fatalf("CreateEntry: %v", err)
+			}
+			entry.Content = "saved words"
+			if err := m.SaveEntry(entry); err != nil {
+				t.Fatalf("SaveEntry: %v", err)
+			}
+
+			if tt.draft != "" {
+				draft := *entry
+				draft.Content = tt.draft
+				if err := m.SaveDraft(&draft); err != nil {
+					t.Fatalf("SaveDraft: %v", err)
+				}
+				if tt.passphrase != "" {
+					data, _, _ := store.Read(m.draftPath(entry))
+					if strings.Contains(string(data), tt.draft) {
+						t.Errorf("draft holds the plaintext: %q", data)
+					}
+				}
+			}
+
+			got, recover, err := m.LoadDraft(entry)
+			if err != nil {
+				t.Fatalf("LoadDraft: %v", err)
+			}
+			if recover != tt.wantRecover {
+				t.Errorf("recover = %v, want %v", recover, tt.wantRecover)
+			}
+			if recover && got != tt.wantDraft {
+				t.Errorf("draft = %q, want %q", got, tt.wantDraft)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Embedding is a cached embedding vector for an entry. It is only valid for
+// the model and content it was computed from.
+type Embedding struct {
+	Model       string    `json:"model"`
+	ContentHash string    `json:"content_hash"`
+	Vector      []float32 `json:"vector"`
+}
+
+// embeddingPath returns the sidecar file used to cache an entry's embedding
+func embeddingPath(entryPath string) string {
+	return strings.TrimSuffix(entryPath, ".md") + ".embedding.json"
+}
+
+// ContentHash identifies the entry's current content, so a cached embedding
+// can be recomputed once the entry changes
+func ContentHash(entry *JournalEntry) string {
+	sum := sha256.Sum256([]byte(entry.Content))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadEmbedding returns the cached embedding for the entry if there is one
+// for this model and the entry hasn't changed since it was computed
+func (m *Manager) LoadEmbedding(entry *JournalEntry, model string) ([]float32, bool, error) {
+	data, _, err := m.store.Read(embeddingPath(entry.FilePath))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read embedding: %w", err)
+	}
+
+	var cached Embedding
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false, fmt.Errorf("failed to parse embedding: %w", err)
+	}
+
+	if cached.Model != model || cached.ContentHash != ContentHash(entry) {
+		return nil, false, nil
+	}
+	return cached.Vector, true, nil
+}
+
+// SaveEmbedding caches the entry's embedding in its sidecar file
+func (m *Manager) SaveEmbedding(entry *JournalEntry, model string, vector []float32) error {
+	if m.dryRun {
+		return nil
+	}
+
+	data, err := json.Marshal(Embedding{
+		Model:       model,
+		ContentHash: ContentHash(entry),
+		Vector:      vector,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal embedding: %w", err)
+	}
+
+	if err := m.store.Write(embeddingPath(entry.FilePath), data); err != nil {
+		return fmt.Errorf("failed to write embedding: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package journal
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEmbeddingCache(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	entry := &JournalEntry{FilePath: filepath.Join(testStorageDir, "entry.md"), Content: "morning light"}
+	vector := []float32{0.25, -0.5, 1}
+
+	if _, ok, err := m.LoadEmbedding(entry, "nomic"); ok || err != nil {
+		t.Fatalf("before caching: ok = %v, err = %v; want a miss", ok, err)
+	}
+
+	if err := m.SaveEmbedding(entry, "nomic", vector); err != nil {
+		t.Fatalf("SaveEmbedding: %v", err)
+	}
+	if !store.has(filepath.Join(testStorageDir, "entry.embedding.json")) {
+		t.Fatal("no embedding sidecar written")
+	}
+	got, ok, err := m.LoadEmbedding(entry, "nomic")
+	if !ok || err != nil || !reflect.DeepEqual(got, vector) {
+		t.Errorf("cached = %v, %v, %v; want %v", got, ok, err, vector)
+	}
+
+	// Another model's vectors aren't comparable
+	if _, ok, _ := m.LoadEmbedding(entry, "other-model"); ok {
+		t.Error("embedding reused for a different model")
+	}
+
+	// Nor is the vector of an earlier version of the entry
+	entry.Content += " and rain"
+	if _, ok, _ := m.LoadEmbedding(entry, "nomic"); ok {
+		t.Error("embedding reused after the entry changed")
+	}
+
+	store.Write(filepath.Join(testStorageDir, "entry.embedding.json"), []byte("{"))
+	if _, ok, err := m.LoadEmbedding(entry, "nomic"); ok || err == nil {
+		t.Errorf("corrupt cache: ok = %v, err = %v; want an error", ok, err)
+	}
+}
+
+func TestEmbeddingCacheDryRun(t *testing.T) {
+	m, store := newTestManager(t, nil, WithDryRun(true))
+	entry := &JournalEntry{FilePath: filepath.Join(testStorageDir, "entry.md"), Content: "words"}
+	if err := m.SaveEmbedding(entry, "nomic", []float32{1}); err != nil {
+		t.Fatalf("SaveEmbedding: %v", err)
+	}
+	if store.has(filepath.Join(testStorageDir, "entry.embedding.json")) {
+		t.Error("dry run wrote an embedding")
+	}
+}
@@ -0,0 +1,86 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestEntryDir(t *testing.T) {
+	at := time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local)
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{layout: config.FolderLayoutFlat, want: "/journal"},
+		{layout: config.FolderLayoutByMonth, want: filepath.Join("/journal", "2024", "03")},
+		{layout: config.FolderLayoutByYear, want: filepath.Join("/journal", "2024")},
+		{layout: "", want: "/journal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			if got := EntryDir("/journal", tt.layout, at); got != tt.want {
+				t.Errorf("EntryDir(%q) = %q, want %q", tt.layout, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEntriesAcrossFolders(t *testing.T) {
+	m, _ := newTestManager(t, nil)
+	dates := []time.Time{
+		time.Date(2023, 12, 31, 22, 0, 0, 0, time.Local),
+		time.Date(2024, 1, 2, 7, 0, 0, 0, time.Local),
+		time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local),
+	}
+	// Layouts can change over a journal's life, leaving entries in each
+	layouts := []string{config.FolderLayoutFlat, config.FolderLayoutByYear, config.FolderLayoutByMonth}
+	var paths []string
+	for i, at := range dates {
+		m.config.Journal.FolderLayout = layouts[i]
+		entry, _, err := m.CreateEntryAt(at)
+		if err != nil {
+			t.Fatalf("CreateEntryAt: %v", err)
+		}
+		paths = append(paths, entry.FilePath)
+	}
+
+	want := []string{
+		filepath.Join(testStorageDir, "2023-12-31T22:00-morning-pages.md"),
+		filepath.Join(testStorageDir, "2024", "2024-01-02T07:00-morning-pages.md"),
+		filepath.Join(testStorageDir, "2024", "03", "2024-03-05T09:30-morning-pages.md"),
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("entry %d filed at %s, want %s", i, paths[i], want[i])
+		}
+	}
+
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != len(dates) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(dates))
+	}
+	listed := map[string]bool{}
+	for _, entry := range entries {
+		listed[entry.FilePath] = true
+	}
+	for _, path := range want {
+		if !listed[path] {
+			t.Errorf("%s wasn't listed", path)
+		}
+	}
+
+	// Plain names still find entries in any folder
+	for _, path := range want {
+		got, err := m.ResolveEntryPath(filepath.Base(path))
+		if err != nil || got != path {
+			t.Errorf("ResolveEntryPath(%s) = %q, %v; want %s", filepath.Base(path), got, err, path)
+		}
+	}
+}
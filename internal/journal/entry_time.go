@@ -0,0 +1,46 @@
+package journal
+
+import (
+	"fmt"
+	"time"
+)
+
+// futureSlack is how far ahead of the clock a new entry may be dated
+// before it counts as in the future
+const futureSlack = time.Minute
+
+// EntryTimeWarning returns why an entry dated at looks wrong, or "" if it
+// looks fine: it is in the future, or more than Journal.TimeWarnDays days
+// before or after the latest entry, which usually means the system clock is
+// off. The check is skipped when TimeWarnDays is 0
+func (m *Manager) EntryTimeWarning(at time.Time) string {
+	days := m.config.Journal.TimeWarnDays
+	if days <= 0 {
+		return ""
+	}
+
+	if at.After(time.Now().Add(futureSlack)) {
+		return fmt.Sprintf("new entry is dated %s, in the future; check the system clock", at.Format("2006-01-02 15:04"))
+	}
+
+	entries, err := m.ListEntries()
+	if err != nil || len(entries) == 0 {
+		return ""
+	}
+	latest := entries[0].CreatedAt
+	for _, entry := range entries[1:] {
+		if entry.CreatedAt.After(latest) {
+			latest = entry.CreatedAt
+		}
+	}
+
+	gap := at.Sub(latest)
+	if gap < 0 {
+		gap = -gap
+	}
+	if gap > time.Duration(days)*24*time.Hour {
+		return fmt.Sprintf("new entry is dated %s, %d days from the latest entry (%s); check the system clock",
+			at.Format("2006-01-02"), int(gap.Hours()/24), latest.Format("2006-01-02"))
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCreateEntryAtWarning(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		existing []time.Time // Entries already in the journal
+		at       time.Time
+		want     string // In the warning; "" for none
+	}{
+		{name: "first entry", at: now},
+		{name: "next day", existing: []time.Time{now.AddDate(0, 0, -1)}, at: now},
+		{name: "in the future", at: now.Add(time.Hour), want: "in the future"},
+		{name: "years after the latest", existing: []time.Time{now.AddDate(-3, 0, 0)}, at: now, want: "days from the latest entry"},
+		{name: "years before the latest", existing: []time.Time{now}, at: now.AddDate(-3, 0, 0), want: "days from the latest entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestManager(t, nil)
+			for _, at := range tt.existing {
+				if _, _, err := m.CreateEntryAt(at); err != nil {
+					t.Fatalf("CreateEntryAt: %v", err)
+				}
+			}
+			core, logs := observer.New(zap.WarnLevel)
+			m.logger = zap.New(core)
+
+			_, warning, err := m.CreateEntryAt(tt.at)
+			if err != nil {
+				t.Fatalf("CreateEntryAt: %v", err)
+			}
+			if tt.want == "" {
+				if warning != "" || logs.Len() != 0 {
+					t.Errorf("warning = %q with %d logged, want none", warning, logs.Len())
+				}
+				return
+			}
+			if !strings.Contains(warning, tt.want) {
+				t.Errorf("warning = %q, want it to mention %q", warning, tt.want)
+			}
+			if logs.Len() != 1 {
+				t.Errorf("logged %d warnings, want the one", logs.Len())
+			}
+		})
+	}
+}
+
+func TestCreateEntryAtRecordsDate(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	tests := []struct {
+		name string
+		at   time.Time
+		want string // In the warning
+	}{
+		{name: "far past", at: now.AddDate(-5, 0, 0), want: "days from the latest entry"},
+		{name: "future", at: now.AddDate(0, 0, 2), want: "in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, store := newTestManager(t, nil)
+			if _, _, err := m.CreateEntryAt(now); err != nil {
+				t.Fatalf("CreateEntryAt: %v", err)
+			}
+
+			entry, warning, err := m.CreateEntryAt(tt.at)
+			if err != nil {
+				t.Fatalf("CreateEntryAt: %v", err)
+			}
+			if !strings.Contains(warning, tt.want) {
+				t.Errorf("warning = %q, want it to mention %q", warning, tt.want)
+			}
+			if !strings.HasPrefix(entry.FileName, tt.at.Format("2006-01-02T15:04")) {
+				t.Errorf("file name %s isn't dated %s", entry.FileName, tt.at.Format("2006-01-02T15:04"))
+			}
+
+			data, _, err := store.Read(entry.FilePath)
+			if err != nil {
+				t.Fatalf("reading entry: %v", err)
+			}
+			fields, _, err := parseFrontmatter(string(data))
+			if err != nil {
+				t.Fatalf("parseFrontmatter: %v", err)
+			}
+			createdAt, ok := fields["created_at"].(time.Time)
+			if !ok || !createdAt.Equal(tt.at) {
+				t.Errorf("created_at = %v, want %v", fields["created_at"], tt.at)
+			}
+
+			read, err := m.ReadEntry(entry.FilePath)
+			if err != nil {
+				t.Fatalf("ReadEntry: %v", err)
+			}
+			if !read.CreatedAt.Equal(tt.at) {
+				t.Errorf("read CreatedAt = %v, want %v", read.CreatedAt, tt.at)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Errors returned by Manager methods, for use with errors.Is. Underlying
+// errors such as fs.ErrPermission stay wrapped alongside them.
+var (
+	// ErrNotFound means the entry doesn't exist
+	ErrNotFound = errors.New("journal entry not found")
+	// ErrOutsideStorage means a name or path points outside the journal directory
+	ErrOutsideStorage = errors.New("path is outside the journal directory")
+	// ErrPassphraseRequired means the entry is encrypted but no passphrase was given
+	ErrPassphraseRequired = errors.New("journal entry is encrypted; a passphrase is required")
+	// ErrWrongPassphrase means an encrypted entry could not be decrypted,
+	// either because the passphrase is wrong or the file was modified
+	ErrWrongPassphrase = errors.New("failed to decrypt journal entry: wrong passphrase or the file was modified")
+)
+
+// notFound wraps err with ErrNotFound when it reports a missing file
+func notFound(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+
+// checkInStorage returns ErrOutsideStorage unless path is inside the
+// journal directory. On disk that is where symlinks lead, not just where
+// the path says.
+func (m *Manager) checkInStorage(path string) error {
+	if !inDir(m.config.Journal.StorageDir, path) {
+		return fmt.Errorf("%w: %s", ErrOutsideStorage, path)
+	}
+
+	if _, ok := m.store.(FileStore); !ok {
+		return nil
+	}
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil // Nothing there yet to lead anywhere
+	}
+	dir, err := filepath.EvalSymlinks(m.config.Journal.StorageDir)
+	if err != nil || !inDir(dir, real) {
+		return fmt.Errorf("%w: %s links to %s", ErrOutsideStorage, path, real)
+	}
+	return nil
+}
+
+// inDir reports whether path is below dir, judging by the names alone
+func inDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
@@ -0,0 +1,86 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestResolveEntryPathTraversal(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	if err := store.Write("/secret.md", []byte("outside")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Write(filepath.Join(testStorageDir, "2024", "entry.md"), []byte("inside")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want error // nil when it should resolve
+	}{
+		{name: "../secret.md", want: ErrOutsideStorage},
+		{name: "../secret", want: ErrOutsideStorage},
+		{name: "2024/../../secret.md", want: ErrOutsideStorage},
+		{name: "/secret.md", want: ErrNotFound}, // Taken relative to the journal
+		{name: "2024/../missing.md", want: ErrNotFound},
+		{name: "2024/entry.md"},
+		{name: "entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := m.ResolveEntryPath(tt.name)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("ResolveEntryPath(%q): %v", tt.name, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ResolveEntryPath(%q) = %q, %v; want %v", tt.name, path, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSymlinkEscape(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "journal")
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.StorageDir = dir
+	}, WithStore(FileStore{FileMode: 0o644, DirMode: 0o755}))
+
+	secret := filepath.Join(root, "secret.md")
+	if err := os.WriteFile(secret, []byte("outside the journal"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(secret, filepath.Join(dir, "escape.md")); err != nil {
+		t.Skipf("symlinks not available: %v", err)
+	}
+	if err := os.Symlink(root, filepath.Join(dir, "up")); err != nil {
+		t.Fatal(err)
+	}
+	// Links that stay inside the journal are fine
+	if err := os.WriteFile(filepath.Join(dir, "real.md"), []byte("inside"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "real.md"), filepath.Join(dir, "alias.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"escape.md", "up/secret.md"} {
+		if _, err := m.ResolveEntryPath(name); !errors.Is(err, ErrOutsideStorage) {
+			t.Errorf("ResolveEntryPath(%q): err = %v, want ErrOutsideStorage", name, err)
+		}
+		if _, err := m.ReadEntry(filepath.Join(dir, name)); !errors.Is(err, ErrOutsideStorage) {
+			t.Errorf("ReadEntry(%q): err = %v, want ErrOutsideStorage", name, err)
+		}
+	}
+	if _, err := m.ResolveEntryPath("alias.md"); err != nil {
+		t.Errorf("ResolveEntryPath(alias.md): %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package journal
+
+import "strings"
+
+// Containing returns the entries whose content includes substr, ignoring
+// case. An empty substr matches every entry. Entries whose content can't
+// be read don't match.
+func Containing(entries []*JournalEntry, substr string) []*JournalEntry {
+	if substr == "" {
+		return entries
+	}
+	needle := strings.ToLower(substr)
+	var matches []*JournalEntry
+	for _, entry := range entries {
+		if entry.LoadContent() != nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(entry.Content), needle) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// ShorterThan returns the entries with fewer than minWords words, such as the
+// empty entries left by abandoned sessions
+func ShorterThan(entries []*JournalEntry, minWords int) []*JournalEntry {
+	var matches []*JournalEntry
+	for _, entry := range entries {
+		if entry.WordCount < minWords {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
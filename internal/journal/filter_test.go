@@ -0,0 +1,64 @@
+package journal
+
+import (
+	"slices"
+	"testing"
+)
+
+// entryNames returns the file names of entries, in order
+func entryNames(entries []*JournalEntry) []string {
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.FileName)
+	}
+	return names
+}
+
+func TestShorterThan(t *testing.T) {
+	entries := []*JournalEntry{
+		{FileName: "empty.md", WordCount: 0},
+		{FileName: "one.md", WordCount: 1},
+		{FileName: "four.md", WordCount: 4},
+		{FileName: "five.md", WordCount: 5},
+	}
+	tests := []struct {
+		minWords int
+		want     []string
+	}{
+		{minWords: 0, want: nil},
+		{minWords: 1, want: []string{"empty.md"}},
+		{minWords: 5, want: []string{"empty.md", "one.md", "four.md"}},
+		{minWords: 100, want: []string{"empty.md", "one.md", "four.md", "five.md"}},
+	}
+
+	for _, tt := range tests {
+		if got := entryNames(ShorterThan(entries, tt.minWords)); !slices.Equal(got, tt.want) {
+			t.Errorf("ShorterThan(%d) = %v, want %v", tt.minWords, got, tt.want)
+		}
+	}
+}
+
+func TestContaining(t *testing.T) {
+	entries := []*JournalEntry{
+		{FileName: "garden.md", Content: "Planted Tomatoes by the fence."},
+		{FileName: "work.md", Content: "A long day of meetings."},
+		{FileName: "both.md", Content: "Left work early for the tomato sale."},
+	}
+
+	tests := []struct {
+		substr string
+		want   []string
+	}{
+		{substr: "", want: []string{"garden.md", "work.md", "both.md"}},
+		{substr: "tomato", want: []string{"garden.md", "both.md"}},
+		{substr: "TOMATOES", want: []string{"garden.md"}},
+		{substr: "day of", want: []string{"work.md"}},
+		{substr: "zebra", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := entryNames(Containing(entries, tt.substr)); !slices.Equal(got, tt.want) {
+			t.Errorf("Containing(%q) = %v, want %v", tt.substr, got, tt.want)
+		}
+	}
+}
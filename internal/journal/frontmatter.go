@@ -0,0 +1,100 @@
+package journal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatterDelim opens and closes a YAML frontmatter block
+const frontmatterDelim = "---"
+
+// splitFrontmatter separates a leading YAML frontmatter block from the body.
+// ok is false when the content has no complete frontmatter block.
+func splitFrontmatter(content string) (front, body string, ok bool) {
+	if !strings.HasPrefix(content, frontmatterDelim+"\n") {
+		return "", content, false
+	}
+
+	rest := content[len(frontmatterDelim)+1:]
+	// The closing delimiter may be the very first line of rest (empty block)
+	if strings.HasPrefix(rest, frontmatterDelim+"\n") || rest == frontmatterDelim {
+		return "", strings.TrimPrefix(strings.TrimPrefix(rest, frontmatterDelim), "\n"), true
+	}
+
+	end := strings.Index(rest, "\n"+frontmatterDelim+"\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n"+frontmatterDelim) {
+			return rest[:len(rest)-len(frontmatterDelim)-1], "", true
+		}
+		return "", content, false
+	}
+
+	return rest[:end], rest[end+len(frontmatterDelim)+2:], true
+}
+
+// parseFrontmatter splits the entry's frontmatter fields from its body.
+// Content without a frontmatter block is returned whole as the body.
+// Content that opens a block without closing it, or whose block isn't valid
+// YAML, is reported as malformed.
+func parseFrontmatter(content string) (map[string]any, string, error) {
+	front, body, ok := splitFrontmatter(content)
+	if !ok {
+		if strings.HasPrefix(content, frontmatterDelim+"\n") {
+			return nil, content, fmt.Errorf("malformed frontmatter: missing closing %q", frontmatterDelim)
+		}
+		return nil, content, nil
+	}
+
+	fields := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(front), &fields); err != nil {
+		return nil, content, fmt.Errorf("malformed frontmatter: %w", err)
+	}
+	return fields, body, nil
+}
+
+// renderFrontmatter prepends the fields as a YAML frontmatter block to body
+func renderFrontmatter(fields map[string]any, body string) ([]byte, error) {
+	front, err := yaml.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+
+	var buf strings.Builder
+	buf.WriteString(frontmatterDelim + "\n")
+	buf.Write(front)
+	buf.WriteString(frontmatterDelim + "\n")
+	buf.WriteString(body)
+	return []byte(buf.String()), nil
+}
+
+// frontmatter returns the fields to write in the entry's frontmatter: its
+// Extra fields, plus the header and goal when it has them
+func (e *JournalEntry) frontmatter() map[string]any {
+	fields := make(map[string]any, len(e.Extra)+3)
+	for key, value := range e.Extra {
+		fields[key] = value
+	}
+	if e.Header != "" {
+		fields["header"] = e.Header
+	}
+	if e.Goal > 0 {
+		fields["word_count_goal"] = e.Goal
+	}
+	return fields
+}
+
+// frontmatterTime reads a timestamp field, accepting both YAML timestamps
+// and RFC 3339 strings
+func frontmatterTime(fields map[string]any, key string) (time.Time, bool) {
+	switch v := fields[key].(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,99 @@
+package journal
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantFields map[string]any
+		wantBody   string
+		wantErr    bool
+	}{
+		{name: "no frontmatter", content: "Just words", wantBody: "Just words"},
+		{name: "fields", content: "---\nmood: happy\n---\nBody", wantFields: map[string]any{"mood": "happy"}, wantBody: "Body"},
+		{name: "empty block", content: "---\n---\nBody", wantFields: map[string]any{}, wantBody: "Body"},
+		{name: "closed at the end", content: "---\nmood: calm\n---", wantFields: map[string]any{"mood": "calm"}},
+		{name: "not closed", content: "---\nmood: happy\nBody", wantBody: "---\nmood: happy\nBody", wantErr: true},
+		{name: "invalid yaml", content: "---\nmood: [\n---\nBody", wantBody: "---\nmood: [\n---\nBody", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, body, err := parseFrontmatter(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %#v, want %#v", fields, tt.wantFields)
+			}
+			if body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestExtraFrontmatterRoundTrip(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	path := filepath.Join(testStorageDir, "entry.md")
+	content := "---\nmood: happy\ntags:\n    - garden\n    - spring\nword_count_goal: 5\n---\nPlanted the beans."
+	if err := store.Write(path, []byte(content)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	entry, err := m.ReadEntry(path)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	wantExtra := map[string]any{"mood": "happy", "tags": []any{"garden", "spring"}}
+	if !reflect.DeepEqual(entry.Extra, wantExtra) {
+		t.Errorf("Extra = %#v, want %#v", entry.Extra, wantExtra)
+	}
+	if entry.Goal != 5 {
+		t.Errorf("Goal = %d, want the known field mapped to 5", entry.Goal)
+	}
+
+	entry.Content += " Watered them."
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	data, _, err := store.Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !strings.Contains(string(data), "mood: happy\n") {
+		t.Errorf("saved file lost the custom key:\n%s", data)
+	}
+
+	reread, err := m.ReadEntry(path)
+	if err != nil {
+		t.Fatalf("ReadEntry after save: %v", err)
+	}
+	if !reflect.DeepEqual(reread.Extra, wantExtra) {
+		t.Errorf("Extra after save = %#v, want %#v", reread.Extra, wantExtra)
+	}
+	if reread.Goal != 5 || reread.Content != "Planted the beans. Watered them." {
+		t.Errorf("after save: goal %d, content %q", reread.Goal, reread.Content)
+	}
+}
+
+func TestNewEntryHasNoExtra(t *testing.T) {
+	m, _ := newTestManager(t, nil)
+	entry, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	reread, err := m.ReadEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if reread.Extra != nil {
+		t.Errorf("Extra = %#v, want the app's own fields left out", reread.Extra)
+	}
+}
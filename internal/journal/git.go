@@ -0,0 +1,64 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"go.uber.org/zap"
+)
+
+// CommitEntry commits the entry (and its sidecar files) to git when
+// GitAutoCommit is enabled. It quietly does nothing if git isn't installed
+// or the journal directory isn't inside a git repository.
+func (m *Manager) CommitEntry(entry *JournalEntry) error {
+	if !m.config.Journal.GitAutoCommit || m.dryRun {
+		return nil
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		m.logger.Debug("git not found, skipping auto-commit")
+		return nil
+	}
+
+	dir := m.config.Journal.StorageDir
+	if err := exec.Command("git", "-C", dir, "rev-parse", "--is-inside-work-tree").Run(); err != nil {
+		m.logger.Debug("Journal directory is not a git repository, skipping auto-commit",
+			zap.String("dir", dir))
+		return nil
+	}
+
+	paths := []string{entry.FilePath}
+	for _, sidecar := range []string{historyPath(entry.FilePath), conversationPath(entry.FilePath)} {
+		if _, err := os.Stat(sidecar); err == nil {
+			paths = append(paths, sidecar)
+		}
+	}
+
+	if err := runGit(dir, append([]string{"add", "--"}, paths...)...); err != nil {
+		return err
+	}
+
+	// Nothing staged for these paths means there's nothing to commit
+	diff := exec.Command("git", append([]string{"-C", dir, "diff", "--cached", "--quiet", "--"}, paths...)...)
+	if err := diff.Run(); err == nil {
+		return nil
+	}
+
+	message := fmt.Sprintf("journal: %s", entry.FileName)
+	if err := runGit(dir, append([]string{"commit", "-m", message, "--"}, paths...)...); err != nil {
+		return err
+	}
+
+	m.logger.Info("Committed journal entry", zap.String("file", entry.FileName))
+	return nil
+}
+
+// runGit runs a git subcommand in dir, including its output in any error
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", args[0], err, output)
+	}
+	return nil
+}
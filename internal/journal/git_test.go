@@ -0,0 +1,114 @@
+package journal
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// newGitManager returns a manager over a FileStore in a temp directory,
+// made a git repository when init is set. Tests skip without git.
+func newGitManager(t *testing.T, init bool, opts ...Option) *Manager {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	// Keep the user's git config, like commit signing, out of it
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := t.TempDir()
+	if init {
+		if err := runGit(dir, "init", "-q"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.StorageDir = dir
+		cfg.Journal.GitAutoCommit = true
+	}, append([]Option{WithStore(FileStore{FileMode: 0o644, DirMode: 0o755})}, opts...)...)
+	return m
+}
+
+// gitOutput runs a git command in the journal directory and returns its
+// trimmed output
+func gitOutput(t *testing.T, m *Manager, args ...string) string {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", m.config.Journal.StorageDir}, args...)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestCommitEntry(t *testing.T) {
+	m := newGitManager(t, true)
+	entry := createWithHistory(t, m)
+
+	if err := m.CommitEntry(entry); err != nil {
+		t.Fatalf("CommitEntry: %v", err)
+	}
+	if got, want := gitOutput(t, m, "log", "-1", "--format=%s"), "journal: "+entry.FileName; got != want {
+		t.Errorf("commit message = %q, want %q", got, want)
+	}
+	files := gitOutput(t, m, "ls-files")
+	for _, name := range []string{entry.FileName, strings.TrimSuffix(entry.FileName, ".md") + ".history.json"} {
+		if !strings.Contains(files, name) {
+			t.Errorf("%s wasn't committed; tracked files:\n%s", name, files)
+		}
+	}
+
+	// Nothing changed, so nothing more to commit
+	if err := m.CommitEntry(entry); err != nil {
+		t.Fatalf("CommitEntry again: %v", err)
+	}
+	if got := gitOutput(t, m, "rev-list", "--count", "HEAD"); got != "1" {
+		t.Errorf("%s commits after an unchanged commit, want 1", got)
+	}
+
+	entry.Content += " and more"
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	if err := m.CommitEntry(entry); err != nil {
+		t.Fatalf("CommitEntry after an edit: %v", err)
+	}
+	if got := gitOutput(t, m, "rev-list", "--count", "HEAD"); got != "2" {
+		t.Errorf("%s commits after an edit, want 2", got)
+	}
+}
+
+func TestCommitEntrySkipped(t *testing.T) {
+	tests := []struct {
+		name string
+		m    func(t *testing.T) *Manager
+	}{
+		{name: "not a repository", m: func(t *testing.T) *Manager { return newGitManager(t, false) }},
+		{name: "disabled", m: func(t *testing.T) *Manager {
+			m := newGitManager(t, true)
+			m.config.Journal.GitAutoCommit = false
+			return m
+		}},
+		{name: "dry run", m: func(t *testing.T) *Manager { return newGitManager(t, true, WithDryRun(true)) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m(t)
+			entry := &JournalEntry{FilePath: filepath.Join(m.config.Journal.StorageDir, "entry.md"), FileName: "entry.md"}
+			if err := m.CommitEntry(entry); err != nil {
+				t.Fatalf("CommitEntry: %v", err)
+			}
+			if out, err := exec.Command("git", "-C", m.config.Journal.StorageDir, "rev-parse", "HEAD").CombinedOutput(); err == nil {
+				t.Errorf("a commit was made: %s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package journal
+
+import (
+	"time"
+	"unicode"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+// sessionGap is the longest pause between word count samples that still
+// counts as time spent writing; longer gaps are between sessions
+const sessionGap = 5 * time.Minute
+
+// GoalStrategy measures an entry's progress toward its goal
+type GoalStrategy interface {
+	// Progress returns how far the entry has got and the target, in Unit
+	Progress(entry *JournalEntry) (current, target int)
+	// Unit names what is counted, e.g. "words"
+	Unit() string
+}
+
+// wordGoal counts words of prose
+type wordGoal struct{ m *Manager }
+
+func (g wordGoal) Progress(entry *JournalEntry) (int, int) {
+	return entry.WordCount, g.m.EffectiveGoal(entry)
+}
+
+func (wordGoal) Unit() string { return "words" }
+
+// characterGoal counts non-blank characters of prose
+type characterGoal struct{ m *Manager }
+
+func (g characterGoal) Progress(entry *JournalEntry) (int, int) {
+	if err := entry.LoadContent(); err != nil {
+		g.m.logger.Warn("Failed to read journal entry", zap.String("file", entry.FileName), zap.Error(err))
+	}
+	return countCharacters(StripHeader(entry.Header, entry.Content)), g.m.EffectiveGoal(entry)
+}
+
+func (characterGoal) Unit() string { return "characters" }
+
+// durationGoal counts minutes spent writing, from the word count samples
+// taken while the entry was open
+type durationGoal struct{ m *Manager }
+
+func (g durationGoal) Progress(entry *JournalEntry) (int, int) {
+	return int(timeSpent(entry.WordCountHistory).Minutes()), g.m.EffectiveGoal(entry)
+}
+
+func (durationGoal) Unit() string { return "minutes" }
+
+// newGoalStrategy returns the strategy for Journal.GoalType
+func newGoalStrategy(goalType string, m *Manager) GoalStrategy {
+	switch goalType {
+	case config.GoalTypeCharacters:
+		return characterGoal{m}
+	case config.GoalTypeMinutes:
+		return durationGoal{m}
+	default:
+		return wordGoal{m}
+	}
+}
+
+// countCharacters counts the runes in text that aren't whitespace
+func countCharacters(text string) int {
+	n := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// timeSpent adds up the time between consecutive samples, leaving out
+// gaps longer than sessionGap
+func timeSpent(samples []Sample) time.Duration {
+	var spent time.Duration
+	for i := 1; i < len(samples); i++ {
+		if gap := samples[i].Time.Sub(samples[i-1].Time); gap > 0 && gap <= sessionGap {
+			spent += gap
+		}
+	}
+	return spent
+}
+
+// Goal returns the strategy that measures progress toward entry goals
+func (m *Manager) Goal() GoalStrategy {
+	return m.goal
+}
+
+// Progress returns how far the entry is toward its goal, in the units of
+// the configured goal type
+func (m *Manager) Progress(entry *JournalEntry) (current, target int) {
+	return m.goal.Progress(entry)
+}
+
+// isComplete reports whether the entry has reached its goal
+func (m *Manager) isComplete(entry *JournalEntry) bool {
+	current, target := m.Progress(entry)
+	return current >= target
+}
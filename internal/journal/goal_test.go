@@ -0,0 +1,134 @@
+package journal
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestEffectiveGoal(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		wantGoal      int
+		wantCompleted bool
+	}{
+		{name: "configured goal", content: "one two three", wantGoal: 10},
+		{name: "own goal", content: "---\nword_count_goal: 3\n---\none two three", wantGoal: 3, wantCompleted: true},
+		{name: "own goal not yet met", content: "---\nword_count_goal: 5\n---\none two three", wantGoal: 5},
+		{name: "zero goal is ignored", content: "---\nword_count_goal: 0\n---\none two three", wantGoal: 10},
+		{name: "non-numeric goal is ignored", content: "---\nword_count_goal: lots\n---\none two three", wantGoal: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, store := newTestManager(t, func(cfg *config.Config) {
+				cfg.Journal.WordCountGoal = 10
+			})
+			path := filepath.Join(testStorageDir, "entry.md")
+			if err := store.Write(path, []byte(tt.content)); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+
+			entry, err := m.ReadEntry(path)
+			if err != nil {
+				t.Fatalf("ReadEntry: %v", err)
+			}
+			if got := m.EffectiveGoal(entry); got != tt.wantGoal {
+				t.Errorf("EffectiveGoal = %d, want %d", got, tt.wantGoal)
+			}
+			if current, target := m.Progress(entry); current != 3 || target != tt.wantGoal {
+				t.Errorf("Progress = %d/%d, want 3/%d", current, target, tt.wantGoal)
+			}
+			if entry.IsCompleted != tt.wantCompleted {
+				t.Errorf("IsCompleted = %v, want %v", entry.IsCompleted, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestGoalStrategies(t *testing.T) {
+	start := time.Date(2024, 3, 5, 9, 0, 0, 0, time.Local)
+	history := []Sample{
+		{Time: start, WordCount: 0},
+		{Time: start.Add(4 * time.Minute), WordCount: 40},
+		{Time: start.Add(7 * time.Minute), WordCount: 70},
+		{Time: start.Add(47 * time.Minute), WordCount: 80}, // Back after a break
+		{Time: start.Add(49 * time.Minute), WordCount: 90},
+	}
+
+	tests := []struct {
+		goalType      string
+		goal          int
+		wantUnit      string
+		wantCurrent   int
+		wantCompleted bool
+	}{
+		{goalType: config.GoalTypeWords, goal: 4, wantUnit: "words", wantCurrent: 4, wantCompleted: true},
+		{goalType: config.GoalTypeWords, goal: 5, wantUnit: "words", wantCurrent: 4},
+		{goalType: config.GoalTypeCharacters, goal: 20, wantUnit: "characters", wantCurrent: 20, wantCompleted: true},
+		{goalType: config.GoalTypeCharacters, goal: 21, wantUnit: "characters", wantCurrent: 20},
+		{goalType: config.GoalTypeMinutes, goal: 9, wantUnit: "minutes", wantCurrent: 9, wantCompleted: true},
+		{goalType: config.GoalTypeMinutes, goal: 10, wantUnit: "minutes", wantCurrent: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s goal %d", tt.goalType, tt.goal), func(t *testing.T) {
+			m, _ := newTestManager(t, func(cfg *config.Config) {
+				cfg.Journal.GoalType = tt.goalType
+				cfg.Journal.WordCountGoal = tt.goal
+				cfg.Journal.EntryHeaderTemplate = "# Tuesday\n\n"
+			})
+			entry, _, err := m.CreateEntryAt(start)
+			if err != nil {
+				t.Fatalf("CreateEntryAt: %v", err)
+			}
+			entry.Content += "Four words, with   spaces" // 20 characters, leaving out the header and blanks
+			entry.WordCountHistory = history
+			if err := m.SaveEntry(entry); err != nil {
+				t.Fatalf("SaveEntry: %v", err)
+			}
+
+			if got := m.Goal().Unit(); got != tt.wantUnit {
+				t.Errorf("Unit = %q, want %q", got, tt.wantUnit)
+			}
+			if current, target := m.Progress(entry); current != tt.wantCurrent || target != tt.goal {
+				t.Errorf("Progress = %d/%d, want %d/%d", current, target, tt.wantCurrent, tt.goal)
+			}
+			if entry.IsCompleted != tt.wantCompleted {
+				t.Errorf("IsCompleted = %v, want %v", entry.IsCompleted, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestTimeSpent(t *testing.T) {
+	start := time.Date(2024, 3, 5, 9, 0, 0, 0, time.Local)
+	at := func(minutes float64) Sample {
+		return Sample{Time: start.Add(time.Duration(minutes * float64(time.Minute)))}
+	}
+
+	tests := []struct {
+		name    string
+		samples []Sample
+		want    time.Duration
+	}{
+		{name: "no samples"},
+		{name: "one sample", samples: []Sample{at(0)}},
+		{name: "steady writing", samples: []Sample{at(0), at(2), at(4.5)}, want: 270 * time.Second},
+		{name: "gap at the limit counts", samples: []Sample{at(0), at(5)}, want: 5 * time.Minute},
+		{name: "longer gap is a break", samples: []Sample{at(0), at(1), at(6.5), at(8)}, want: 150 * time.Second},
+		{name: "out of order samples are skipped", samples: []Sample{at(3), at(1), at(2)}, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timeSpent(tt.samples); got != tt.want {
+				t.Errorf("timeSpent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// EntryGroup holds the entries created within one period
+type EntryGroup struct {
+	Label   string          `json:"label"` // e.g. "2024-02-14", "2024-W07", "2024-02" or "2024"
+	Start   time.Time       `json:"start"`
+	Entries []*JournalEntry `json:"entries"`
+}
+
+// ParsePeriod converts a user supplied period name into a Period
+func ParsePeriod(name string) (Period, error) {
+	switch p := Period(name); p {
+	case PeriodDay, PeriodWeek, PeriodMonth, PeriodYear:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown period %q (want day, week, month or year)", name)
+	}
+}
+
+// GroupEntries buckets entries by the period their CreatedAt falls in.
+// Groups are returned oldest first and the entries within each group are
+// sorted by CreatedAt; the input slice is left untouched.
+func GroupEntries(entries []*JournalEntry, period Period) []EntryGroup {
+	sorted := make([]*JournalEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var groups []EntryGroup
+	for _, entry := range sorted {
+		start, label := periodStart(entry.CreatedAt, period)
+		if n := len(groups); n > 0 && groups[n-1].Label == label {
+			groups[n-1].Entries = append(groups[n-1].Entries, entry)
+			continue
+		}
+		groups = append(groups, EntryGroup{Label: label, Start: start, Entries: []*JournalEntry{entry}})
+	}
+	return groups
+}
@@ -0,0 +1,108 @@
+package journal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	for _, name := range []string{"day", "week", "month", "year"} {
+		if got, err := ParsePeriod(name); err != nil || string(got) != name {
+			t.Errorf("ParsePeriod(%q) = %q, %v", name, got, err)
+		}
+	}
+	for _, name := range []string{"", "Month", "quarter"} {
+		if _, err := ParsePeriod(name); err == nil || !strings.Contains(err.Error(), "unknown period") {
+			t.Errorf("ParsePeriod(%q): err = %v, want it rejected", name, err)
+		}
+	}
+}
+
+func TestGroupEntries(t *testing.T) {
+	at := func(month time.Month, day, hour, min int) *JournalEntry {
+		created := time.Date(2024, month, day, hour, min, 0, 0, time.Local)
+		return &JournalEntry{FileName: created.Format("01-02T15:04"), CreatedAt: created}
+	}
+	// Out of order, across month ends and the leap day
+	entries := []*JournalEntry{
+		at(time.March, 1, 0, 0),
+		at(time.January, 31, 23, 59),
+		at(time.February, 29, 23, 59),
+		at(time.February, 1, 0, 0),
+		at(time.January, 2, 9, 0),
+		at(time.February, 1, 7, 0),
+	}
+	original := append([]*JournalEntry(nil), entries...)
+
+	tests := []struct {
+		period Period
+		want   map[string][]string // File names by group label, in order
+		labels []string
+	}{
+		{
+			period: PeriodMonth,
+			labels: []string{"2024-01", "2024-02", "2024-03"},
+			want: map[string][]string{
+				"2024-01": {"01-02T09:00", "01-31T23:59"},
+				"2024-02": {"02-01T00:00", "02-01T07:00", "02-29T23:59"},
+				"2024-03": {"03-01T00:00"},
+			},
+		},
+		{
+			period: PeriodDay,
+			labels: []string{"2024-01-02", "2024-01-31", "2024-02-01", "2024-02-29", "2024-03-01"},
+			want: map[string][]string{
+				"2024-02-01": {"02-01T00:00", "02-01T07:00"},
+			},
+		},
+		{
+			// Thursday 29 Feb and Friday 1 Mar share an ISO week
+			period: PeriodWeek,
+			labels: []string{"2024-W01", "2024-W05", "2024-W09"},
+			want: map[string][]string{
+				"2024-W05": {"01-31T23:59", "02-01T00:00", "02-01T07:00"},
+				"2024-W09": {"02-29T23:59", "03-01T00:00"},
+			},
+		},
+		{
+			period: PeriodYear,
+			labels: []string{"2024"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.period), func(t *testing.T) {
+			groups := GroupEntries(entries, tt.period)
+			var labels []string
+			for _, g := range groups {
+				labels = append(labels, g.Label)
+				if start, _ := periodStart(g.Entries[0].CreatedAt, tt.period); !g.Start.Equal(start) {
+					t.Errorf("%s starts %v, want %v", g.Label, g.Start, start)
+				}
+				want, ok := tt.want[g.Label]
+				if !ok {
+					continue
+				}
+				var got []string
+				for _, e := range g.Entries {
+					got = append(got, e.FileName)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%s holds %v, want %v", g.Label, got, want)
+				}
+			}
+			if !reflect.DeepEqual(labels, tt.labels) {
+				t.Errorf("groups %v, want %v", labels, tt.labels)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(entries, original) {
+		t.Error("GroupEntries reordered its input")
+	}
+	if groups := GroupEntries(nil, PeriodMonth); groups != nil {
+		t.Errorf("no entries gave %v, want no groups", groups)
+	}
+}
@@ -0,0 +1,57 @@
+package journal
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// headerData is what Journal.EntryHeaderTemplate is rendered with
+type headerData struct {
+	Date    string    // e.g. "2024-02-14"
+	Time    string    // e.g. "07:30"
+	Weekday string    // e.g. "Wednesday"
+	Now     time.Time // For custom layouts, e.g. {{.Now.Format "January 2"}}
+}
+
+// renderHeader renders the entry header template for an entry created at
+// now. The header always ends with a newline so writing starts below it.
+func renderHeader(tmpl string, now time.Time) (string, error) {
+	t, err := template.New("header").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse entry header template: %w", err)
+	}
+
+	var b strings.Builder
+	err = t.Execute(&b, headerData{
+		Date:    now.Format("2006-01-02"),
+		Time:    now.Format("15:04"),
+		Weekday: now.Weekday().String(),
+		Now:     now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render entry header template: %w", err)
+	}
+
+	header := b.String()
+	if header != "" && !strings.HasSuffix(header, "\n") {
+		header += "\n"
+	}
+	return header, nil
+}
+
+// StripHeader returns the prose of content, without the header it was
+// created with. Once the header has been edited it no longer matches and
+// the whole content counts as prose.
+func StripHeader(header, content string) string {
+	if header == "" {
+		return content
+	}
+	return strings.TrimPrefix(content, header)
+}
+
+// countEntryWords counts the words in the entry's prose, leaving out its header
+func (m *Manager) countEntryWords(entry *JournalEntry) int {
+	return m.CountWords(StripHeader(entry.Header, entry.Content))
+}
@@ -0,0 +1,89 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestRenderHeader(t *testing.T) {
+	at := time.Date(2024, 2, 14, 7, 30, 0, 0, time.Local)
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{tmpl: "# {{.Date}}", want: "# 2024-02-14\n"},
+		{tmpl: "{{.Weekday}} {{.Time}}\n\n", want: "Wednesday 07:30\n\n"},
+		{tmpl: `{{.Now.Format "January 2"}}`, want: "February 14\n"},
+		{tmpl: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			got, err := renderHeader(tt.tmpl, at)
+			if err != nil || got != tt.want {
+				t.Errorf("renderHeader(%q) = %q, %v; want %q", tt.tmpl, got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := renderHeader("{{.Missing", at); err == nil {
+		t.Errorf("renderHeader accepted a malformed template")
+	}
+}
+
+func TestHeaderExcludedFromGoal(t *testing.T) {
+	tests := []struct {
+		name          string
+		goalType      string
+		prose         string
+		editHeader    bool
+		wantProgress  int
+		wantCompleted bool
+	}{
+		{name: "words of prose only", goalType: config.GoalTypeWords, prose: "three prose words", wantProgress: 3, wantCompleted: true},
+		{name: "header alone counts nothing", goalType: config.GoalTypeWords, prose: "", wantProgress: 0},
+		{name: "edited header counts", goalType: config.GoalTypeWords, prose: "two words", editHeader: true, wantProgress: 16, wantCompleted: true},
+		{name: "characters of prose only", goalType: config.GoalTypeCharacters, prose: "abc", wantProgress: 3, wantCompleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestManager(t, func(cfg *config.Config) {
+				cfg.Journal.EntryHeaderTemplate = "---\ntitle: {{.Date}}\n---\n# Morning pages for {{.Weekday}}\nIntention:\n\n"
+				cfg.Journal.GoalType = tt.goalType
+				cfg.Journal.WordCountGoal = 3
+			})
+			entry, _, err := m.CreateEntryAt(time.Date(2024, 2, 14, 7, 30, 0, 0, time.Local))
+			if err != nil {
+				t.Fatalf("CreateEntryAt: %v", err)
+			}
+			if entry.Header == "" || entry.Content != entry.Header {
+				t.Fatalf("new entry content = %q, want the rendered header %q", entry.Content, entry.Header)
+			}
+
+			entry.Content += tt.prose
+			if tt.editHeader {
+				entry.Content = "# My own heading\n" + entry.Content
+			}
+			if err := m.SaveEntry(entry); err != nil {
+				t.Fatalf("SaveEntry: %v", err)
+			}
+
+			// And the same once read back
+			read, err := m.ReadEntry(entry.FilePath)
+			if err != nil {
+				t.Fatalf("ReadEntry: %v", err)
+			}
+			for _, e := range []*JournalEntry{entry, read} {
+				if got, _ := m.Progress(e); got != tt.wantProgress {
+					t.Errorf("progress = %d, want %d", got, tt.wantProgress)
+				}
+				if e.IsCompleted != tt.wantCompleted {
+					t.Errorf("IsCompleted = %v, want %v", e.IsCompleted, tt.wantCompleted)
+				}
+			}
+		})
+	}
+}
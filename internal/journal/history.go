@@ -0,0 +1,76 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Sample is a single word count measurement taken during a writing session
+type Sample struct {
+	Time      time.Time `json:"time"`
+	WordCount int       `json:"word_count"`
+}
+
+// historyPath returns the sidecar file used to persist an entry's word count history
+func historyPath(entryPath string) string {
+	return strings.TrimSuffix(entryPath, ".md") + ".history.json"
+}
+
+// RecordSample appends the entry's current word count to its history
+func (m *Manager) RecordSample(entry *JournalEntry, at time.Time) {
+	entry.WordCountHistory = append(entry.WordCountHistory, Sample{
+		Time:      at,
+		WordCount: m.countEntryWords(entry),
+	})
+}
+
+// SaveHistory writes the entry's word count history to its sidecar file
+func (m *Manager) SaveHistory(entry *JournalEntry) error {
+	if len(entry.WordCountHistory) == 0 {
+		return nil
+	}
+
+	path := historyPath(entry.FilePath)
+	unlock := m.lockFile(path)
+	defer unlock()
+
+	data, err := json.MarshalIndent(entry.WordCountHistory, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal word count history: %w", err)
+	}
+
+	if err := m.store.Write(path, data); err != nil {
+		return fmt.Errorf("failed to write word count history: %w", err)
+	}
+
+	return nil
+}
+
+// loadHistory reads the entry's word count history from its sidecar file, if any
+func (m *Manager) loadHistory(entry *JournalEntry) {
+	data, _, err := m.store.Read(historyPath(entry.FilePath))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			m.logger.Warn("Failed to read word count history",
+				zap.String("file", entry.FileName),
+				zap.Error(err))
+		}
+		return
+	}
+
+	var samples []Sample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		m.logger.Warn("Failed to parse word count history",
+			zap.String("file", entry.FileName),
+			zap.Error(err))
+		return
+	}
+
+	entry.WordCountHistory = samples
+}
@@ -0,0 +1,48 @@
+package journal
+
+import (
+	"os/exec"
+
+	"go.uber.org/zap"
+)
+
+// runHooks fires the configured save and completion hooks after an entry
+// was written. wasCompleted is the entry's completion state before the save.
+func (m *Manager) runHooks(entry *JournalEntry, wasCompleted bool) {
+	if hook := m.config.Journal.OnSave; hook != "" {
+		m.runHook("on_save", hook, entry.FilePath)
+	}
+
+	if hook := m.config.Journal.OnComplete; hook != "" && entry.IsCompleted && !wasCompleted {
+		// Only fire once per entry, even if the count dips below the goal and back
+		m.hookMu.Lock()
+		fired := m.completedHooks[entry.FilePath]
+		m.completedHooks[entry.FilePath] = true
+		m.hookMu.Unlock()
+
+		if !fired {
+			m.runHook("on_complete", hook, entry.FilePath)
+		}
+	}
+}
+
+// runHook executes a shell command in the background with the entry path
+// as its first argument ($1). Failures are logged, never returned.
+func (m *Manager) runHook(name, command, entryPath string) {
+	cmd := exec.Command("sh", "-c", command+` "$1"`, "momentum-hook", entryPath)
+
+	go func() {
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			m.logger.Warn("Journal hook failed",
+				zap.String("hook", name),
+				zap.String("command", command),
+				zap.String("output", string(output)),
+				zap.Error(err))
+			return
+		}
+		m.logger.Debug("Journal hook finished",
+			zap.String("hook", name),
+			zap.String("file", entryPath))
+	}()
+}
@@ -0,0 +1,105 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// hookLog returns a hook command appending its $1 to a new log file, and
+// the log's path
+func hookLog(t *testing.T, name string) (string, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	return `printf '%s\n' >>` + path, path
+}
+
+// waitForLines waits for the hooks, which run in the background, to have
+// written n lines to the log at path, and returns them
+func waitForLines(t *testing.T, path string, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(path)
+		lines := strings.Fields(string(data))
+		if len(lines) >= n {
+			return lines
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s has %d lines, want %d", filepath.Base(path), len(lines), n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	onSave, saveLog := hookLog(t, "save.log")
+	onComplete, completeLog := hookLog(t, "complete.log")
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.EntryHeaderTemplate = ""
+		cfg.Journal.WordCountGoal = 3
+		cfg.Journal.OnSave = onSave
+		cfg.Journal.OnComplete = onComplete
+	})
+
+	entry, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	// Reach the goal, dip below it, and reach it again
+	for _, content := range []string{"one", "one two three", "one", "one two three four"} {
+		entry.Content = content
+		if err := m.SaveEntry(entry); err != nil {
+			t.Fatalf("SaveEntry: %v", err)
+		}
+	}
+
+	// Creating the entry saved it too
+	saves := waitForLines(t, saveLog, 5)
+	for _, path := range saves {
+		if path != entry.FilePath {
+			t.Errorf("on_save got %q, want the entry path %q", path, entry.FilePath)
+		}
+	}
+
+	waitForLines(t, completeLog, 1)
+	time.Sleep(100 * time.Millisecond) // Give a second completion hook the chance to run
+	completions := waitForLines(t, completeLog, 1)
+	if len(completions) != 1 || completions[0] != entry.FilePath {
+		t.Errorf("on_complete ran with %q, want once with %q", completions, entry.FilePath)
+	}
+}
+
+func TestHookFailureIsLogged(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Journal.StorageDir = testStorageDir
+	cfg.Journal.OnSave = "echo broken >&2; exit 3"
+	core, logs := observer.New(zapcore.WarnLevel)
+	m, err := NewManager(cfg, zap.New(core), WithStore(NewMemoryStore()))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	if _, err := m.CreateEntry(); err != nil {
+		t.Fatalf("a failing hook failed the save: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logs.FilterMessage("Journal hook failed").Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("hook failure wasn't logged")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	entry := logs.FilterMessage("Journal hook failed").All()[0]
+	if fields := entry.ContextMap(); fields["hook"] != "on_save" || !strings.Contains(fields["output"].(string), "broken") {
+		t.Errorf("logged %v", fields)
+	}
+}
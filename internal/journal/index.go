@@ -0,0 +1,238 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// IndexFile is the cache of entry metadata ListEntries keeps in StorageDir.
+// Deleting it is always safe; it is rebuilt on the next listing
+const IndexFile = ".index.json"
+
+// indexVersion changes whenever the index format does, discarding old ones
+const indexVersion = 2
+
+// Stater is implemented by stores that can report a file's modification
+// time and size without reading it. ListEntries only keeps an index for
+// stores that implement it
+type Stater interface {
+	Stat(path string) (time.Time, int64, error)
+}
+
+// Stat returns the file's modification time and size
+func (FileStore) Stat(path string) (time.Time, int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return info.ModTime(), info.Size(), nil
+}
+
+// indexRecord is what the index knows about one entry file: everything
+// ListEntries returns but the content, which is only read when asked for
+type indexRecord struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+
+	CreatedAt  time.Time      `json:"created_at"`
+	ModifiedAt time.Time      `json:"modified_at"`
+	WordCount  int            `json:"word_count"`
+	Completed  bool           `json:"completed"`
+	Goal       int            `json:"goal,omitempty"`
+	Header     string         `json:"header,omitempty"`
+	Links      []string       `json:"links,omitempty"`
+	Partial    bool           `json:"partial,omitempty"`
+	ParseError string         `json:"parse_error,omitempty"`
+	Extra      map[string]any `json:"extra,omitempty"`
+}
+
+// newIndexRecord records the metadata of an entry read from a file with
+// the given modification time and size
+func newIndexRecord(entry *JournalEntry, modTime time.Time, size int64) indexRecord {
+	return indexRecord{
+		ModTime:    modTime,
+		Size:       size,
+		CreatedAt:  entry.CreatedAt,
+		ModifiedAt: entry.ModifiedAt,
+		WordCount:  entry.WordCount,
+		Completed:  entry.IsCompleted,
+		Goal:       entry.Goal,
+		Header:     entry.Header,
+		Links:      entry.Links,
+		Partial:    entry.Partial,
+		ParseError: entry.ParseError,
+		Extra:      entry.Extra,
+	}
+}
+
+// entry returns the recorded entry at path, its content left to be read
+// by LoadContent
+func (r indexRecord) entry(m *Manager, path string) *JournalEntry {
+	return &JournalEntry{
+		FilePath:    path,
+		FileName:    filepath.Base(path),
+		CreatedAt:   r.CreatedAt,
+		ModifiedAt:  r.ModifiedAt,
+		WordCount:   r.WordCount,
+		IsCompleted: r.Completed,
+		Goal:        r.Goal,
+		Header:      r.Header,
+		Links:       r.Links,
+		Partial:     r.Partial,
+		ParseError:  r.ParseError,
+		Extra:       r.Extra,
+		loadContent: func() (string, error) {
+			entry, err := m.ReadEntry(path)
+			if err != nil {
+				return "", err
+			}
+			return entry.Content, nil
+		},
+	}
+}
+
+// LoadContent reads the entry's content if ListEntries took the entry
+// from the index, which leaves it out. Entries read from their file
+// already have it
+func (e *JournalEntry) LoadContent() error {
+	if e.loadContent == nil {
+		return nil
+	}
+	content, err := e.loadContent()
+	if err != nil {
+		return fmt.Errorf("failed to read journal entry content: %w", err)
+	}
+	e.Content = content
+	e.loadContent = nil
+	return nil
+}
+
+// entryIndex caches entries by their path relative to StorageDir. A record
+// is only used while the file's modification time and size still match
+type entryIndex struct {
+	Version int                    `json:"version"`
+	Counter string                 `json:"counter"` // Word counter the counts were made with
+	Entries map[string]indexRecord `json:"entries"`
+
+	stater  Stater
+	changed bool // Set when the index needs writing back
+}
+
+// counterName names the word counter selected in the configuration
+func (m *Manager) counterName() string {
+	switch {
+	case m.config.Journal.CJKCounting:
+		return "cjk"
+	case m.config.Journal.UnicodeCounting:
+		return "unicode"
+	default:
+		return "fields"
+	}
+}
+
+// indexPath returns where the index is kept
+func (m *Manager) indexPath() string {
+	return filepath.Join(m.config.Journal.StorageDir, IndexFile)
+}
+
+// loadIndex returns the index for ListEntries to consult, or nil if it
+// can't keep one. Encrypted journals never get one, since it would hold
+// their entries in plain text. A missing, stale or unreadable index starts
+// over empty
+func (m *Manager) loadIndex() *entryIndex {
+	stater, ok := m.store.(Stater)
+	if !ok || m.passphrase != "" {
+		return nil
+	}
+
+	fresh := &entryIndex{
+		Version: indexVersion,
+		Counter: m.counterName(),
+		Entries: map[string]indexRecord{},
+		stater:  stater,
+	}
+
+	data, _, err := m.store.Read(m.indexPath())
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			m.logger.Warn("Failed to read entry index", zap.Error(err))
+		}
+		return fresh
+	}
+
+	var index entryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		m.logger.Warn("Failed to parse entry index; rebuilding it", zap.Error(err))
+		return fresh
+	}
+	if index.Version != fresh.Version || index.Counter != fresh.Counter || index.Entries == nil {
+		return fresh
+	}
+	index.stater = stater
+	return &index
+}
+
+// readIndexed returns the entry at name, relative to StorageDir, from the
+// index if the file hasn't changed since it was recorded, and reads it
+// otherwise. An entry from the index has its content read on LoadContent.
+// The history sidecar and completion are always fresh, since they can
+// change without the entry file changing
+func (m *Manager) readIndexed(index *entryIndex, name string) (*JournalEntry, error) {
+	path := filepath.Join(m.config.Journal.StorageDir, name)
+	if index == nil {
+		return m.ReadEntry(path)
+	}
+
+	modTime, size, err := index.stater.Stat(path)
+	if err != nil {
+		return m.ReadEntry(path)
+	}
+
+	if record, ok := index.Entries[name]; ok && record.ModTime.Equal(modTime) && record.Size == size {
+		entry := record.entry(m, path)
+		m.loadHistory(entry)
+		entry.IsCompleted = m.isComplete(entry)
+		return entry, nil
+	}
+
+	entry, err := m.ReadEntry(path)
+	if err != nil {
+		return nil, err
+	}
+	index.Entries[name] = newIndexRecord(entry, modTime, size)
+	index.changed = true
+	return entry, nil
+}
+
+// saveIndex drops records of files that are gone and writes the index back
+// if anything changed, so an unchanged journal never rewrites it
+func (m *Manager) saveIndex(index *entryIndex, seen map[string]bool) error {
+	if index == nil || m.dryRun {
+		return nil
+	}
+	for name := range index.Entries {
+		if !seen[name] {
+			delete(index.Entries, name)
+			index.changed = true
+		}
+	}
+	if !index.changed {
+		return nil
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entry index: %w", err)
+	}
+	if err := m.store.Write(m.indexPath(), data); err != nil {
+		return fmt.Errorf("failed to write entry index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package journal
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// newIndexedManager returns a manager over a FileStore, which ListEntries
+// keeps an index for, in a temp directory.
+func newIndexedManager(t *testing.T) *Manager {
+	t.Helper()
+	dir := t.TempDir()
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.StorageDir = dir
+		cfg.Journal.WordCountGoal = 3
+	}, WithStore(FileStore{FileMode: 0o644, DirMode: 0o755}))
+	return m
+}
+
+func TestIndexKeepsMetadataOnly(t *testing.T) {
+	m := newIndexedManager(t)
+	entry, _, err := m.CreateEntryAt(time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	entry.Content = "a private thought about [[other]]"
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+
+	if _, err := m.ListEntries(); err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	data, err := os.ReadFile(m.indexPath())
+	if err != nil {
+		t.Fatalf("index wasn't written: %v", err)
+	}
+	if strings.Contains(string(data), "private") {
+		t.Errorf("index holds the entry's content: %s", data)
+	}
+
+	// The second listing comes from the index, without the content
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	listed := entries[0]
+	if listed.Content != "" {
+		t.Errorf("indexed entry has content %q before LoadContent", listed.Content)
+	}
+	if listed.WordCount != 5 || !listed.IsCompleted || !listed.CreatedAt.Equal(entry.CreatedAt) {
+		t.Errorf("indexed entry = %+v, want the saved metadata", listed)
+	}
+	if len(listed.Links) != 1 || listed.Links[0] != "other" {
+		t.Errorf("Links = %v, want [other]", listed.Links)
+	}
+
+	if err := listed.LoadContent(); err != nil {
+		t.Fatalf("LoadContent: %v", err)
+	}
+	if listed.Content != entry.Content {
+		t.Errorf("loaded Content = %q, want %q", listed.Content, entry.Content)
+	}
+}
+
+func TestIndexRereadsChangedFiles(t *testing.T) {
+	m := newIndexedManager(t)
+	entry, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	if _, err := m.ListEntries(); err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+
+	// Another editor rewrites the file
+	if err := os.WriteFile(entry.FilePath, []byte("written elsewhere today"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(entry.FilePath, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Content != "written elsewhere today" || entries[0].WordCount != 3 {
+		t.Errorf("got %+v, want the file read again", entries)
+	}
+}
+
+func TestContainingLoadsContent(t *testing.T) {
+	m := newIndexedManager(t)
+	for _, content := range []string{"apples and pears", "just pears"} {
+		entry, err := m.CreateEntry()
+		if err != nil {
+			t.Fatalf("CreateEntry: %v", err)
+		}
+		entry.Content = content
+		if err := m.SaveEntry(entry); err != nil {
+			t.Fatalf("SaveEntry: %v", err)
+		}
+	}
+	if _, err := m.ListEntries(); err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	matches := Containing(entries, "APPLES")
+	if len(matches) != 1 || matches[0].Content != "apples and pears" {
+		t.Errorf("Containing = %+v, want the entry about apples", matches)
+	}
+}
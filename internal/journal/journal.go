@@ -1,13 +1,16 @@
 package journal
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
 
 	//	"regexp" // Removed as we are simplifying CountWords
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config" // Adjusted import path
 	"go.uber.org/zap"
@@ -21,35 +24,146 @@ type JournalEntry struct {
 	ModifiedAt  time.Time `json:"modified_at"`
 	WordCount   int       `json:"word_count"`
 	Content     string    `json:"content"`
-	IsCompleted bool      `json:"is_completed"` // True if the entry meets the word count goal
+	IsCompleted bool      `json:"is_completed"`     // True if the entry meets its goal
+	Goal        int       `json:"goal,omitempty"`   // Per-entry word count goal from frontmatter; 0 uses the configured goal
+	Header      string    `json:"header,omitempty"` // Header the entry was created with; not counted toward the goal
+	Links       []string  `json:"links,omitempty"`  // Targets of the entry's [[wiki-links]]
+
+	WordCountHistory []Sample `json:"word_count_history,omitempty"` // Samples taken while writing
+
+	Partial    bool   `json:"partial,omitempty"`     // True if the file could only be partly parsed
+	ParseError string `json:"parse_error,omitempty"` // Why the file was only partly parsed
+
+	Extra map[string]any `json:"extra,omitempty"` // Frontmatter fields other tools added, written back on save
+
+	loadContent func() (string, error) // Reads Content for entries listed from the index, see LoadContent
 }
 
-// Manager handles journal operations
+// Manager handles journal operations. It is safe for concurrent use: saves
+// and deletes of the same entry are serialized, and since writes are atomic
+// a concurrent ReadEntry sees either the old or the new content.
 type Manager struct {
 	config *config.Config
 	logger *zap.Logger
+	store  Store        // Where entry files are kept
+	goal   GoalStrategy // Measures progress toward entry goals
+	dryRun bool         // Log file changes instead of making them
+
+	hookMu         sync.Mutex
+	completedHooks map[string]bool // Entries whose completion hook already ran
+
+	fileLocksMu sync.Mutex
+	fileLocks   map[string]*sync.Mutex // Per-file locks taken by lockFile
+
+	createMu sync.Mutex // Held while CreateEntry picks a free file name
+
+	passphrase string // Encrypts entry bodies when set; never written anywhere
+	keysMu     sync.Mutex
+	keys       map[string][]byte // Derived keys by salt
+	salt       []byte            // The journal's salt, once read from SaltFile
+}
+
+// Option configures optional Manager behavior
+type Option func(*Manager)
+
+// WithDryRun makes the manager validate and log the file changes it would
+// make without touching disk
+func WithDryRun(dryRun bool) Option {
+	return func(m *Manager) {
+		m.dryRun = dryRun
+	}
+}
+
+// WithStore makes the manager keep entries in the given store instead of
+// the local filesystem
+func WithStore(store Store) Option {
+	return func(m *Manager) {
+		m.store = store
+	}
 }
 
 // NewManager creates a new journal manager
-func NewManager(cfg *config.Config, logger *zap.Logger) (*Manager, error) {
+func NewManager(cfg *config.Config, logger *zap.Logger, opts ...Option) (*Manager, error) {
+	fileMode, err := config.ParseFileMode(cfg.Journal.FileMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid journal.file_mode: %w", err)
+	}
+	dirMode, err := config.ParseFileMode(cfg.Journal.DirMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid journal.dir_mode: %w", err)
+	}
+
 	manager := &Manager{
 		config: cfg,
 		logger: logger,
+		store:  FileStore{FileMode: fileMode, DirMode: dirMode},
+
+		completedHooks: make(map[string]bool),
+	}
+	manager.goal = newGoalStrategy(cfg.Journal.GoalType, manager)
+	for _, opt := range opts {
+		opt(manager)
+	}
+
+	// Never fall back to writing plaintext when encryption is configured
+	if cfg.Journal.Encrypt && manager.passphrase == "" {
+		return nil, fmt.Errorf("journal.encrypt is on but no passphrase was given")
+	}
+
+	// Refuse a mistyped passphrase up front, before anything is encrypted
+	// with it
+	if manager.passphrase != "" {
+		if err := manager.CheckPassphrase(); err != nil {
+			return nil, err
+		}
+	}
+
+	if manager.dryRun {
+		if _, err := manager.store.List(cfg.Journal.StorageDir); errors.Is(err, fs.ErrNotExist) {
+			logger.Info("Dry run: would create journal directory",
+				zap.String("dir", cfg.Journal.StorageDir))
+		}
+		return manager, nil
 	}
 
 	// Ensure journal directory exists
-	if err := os.MkdirAll(cfg.Journal.StorageDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	if err := manager.store.Create(cfg.Journal.StorageDir); err != nil {
+		return nil, fmt.Errorf("failed to prepare journal directory: %w", err)
 	}
 
 	return manager, nil
 }
 
-// CreateEntry creates a new journal entry
+// CreateEntry creates a new journal entry in a file of its own, never one
+// that already exists
 func (m *Manager) CreateEntry() (*JournalEntry, error) {
-	now := time.Now()
-	fileName := fmt.Sprintf("%s-morning-pages.md", now.Format("2006-01-02T15:04"))
-	filePath := filepath.Join(m.config.Journal.StorageDir, fileName)
+	entry, _, err := m.CreateEntryAt(time.Now())
+	return entry, err
+}
+
+// CreateEntryAt creates a new journal entry dated now, which may be in the
+// past to backfill a missed day. A date that looks wrong for the journal is
+// logged and returned as a warning, see EntryTimeWarning
+func (m *Manager) CreateEntryAt(now time.Time) (*JournalEntry, string, error) {
+	// Checked before the entry exists, since it then becomes the latest
+	warning := m.EntryTimeWarning(now)
+	if warning != "" {
+		m.logger.Warn("Unexpected date for new journal entry",
+			zap.Time("created_at", now),
+			zap.String("warning", warning))
+	}
+
+	m.createMu.Lock()
+	defer m.createMu.Unlock()
+
+	// Names only go down to the minute, so a second entry in the same
+	// minute gets a numbered suffix instead of replacing the first
+	dir := EntryDir(m.config.Journal.StorageDir, m.config.Journal.FolderLayout, now)
+	fileName, err := m.freeEntryName(fmt.Sprintf("%s-morning-pages.md", now.Format("2006-01-02T15:04")))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to name journal entry: %w", err)
+	}
+	filePath := filepath.Join(dir, fileName)
 
 	entry := &JournalEntry{
 		FilePath:   filePath,
@@ -60,44 +174,120 @@ func (m *Manager) CreateEntry() (*JournalEntry, error) {
 		Content:    "",
 	}
 
+	// Start from the configured header, remembering it so it isn't
+	// counted toward the goal
+	if tmpl := m.config.Journal.EntryHeaderTemplate; tmpl != "" {
+		header, err := renderHeader(tmpl, now)
+		if err != nil {
+			return nil, "", err
+		}
+		entry.Header = header
+		entry.Content = header
+	}
+
 	// Create initial file with metadata
 	if err := m.SaveEntry(entry); err != nil {
-		return nil, fmt.Errorf("failed to create journal entry: %w", err)
+		return nil, "", fmt.Errorf("failed to create journal entry: %w", err)
 	}
 
 	m.logger.Info("Created new journal entry",
 		zap.String("file", fileName),
 		zap.Time("created_at", now))
 
-	return entry, nil
+	return entry, warning, nil
+}
+
+// freeEntryName returns name, or name with a numbered suffix if an entry of
+// that name is already in the journal. Entries are opened and linked by name
+// wherever they are filed, so the name must be unique across folders.
+func (m *Manager) freeEntryName(name string) (string, error) {
+	stem := strings.TrimSuffix(name, ".md")
+	for i := 1; ; i++ {
+		_, err := m.ResolveEntryPath(name)
+		if errors.Is(err, ErrNotFound) {
+			return name, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check for %s: %w", name, err)
+		}
+		name = fmt.Sprintf("%s-%d.md", stem, i)
+	}
 }
 
-// SaveEntry saves a journal entry to disk
+// EntryDir returns the directory an entry created at t is filed in under
+// the given folder layout
+func EntryDir(storageDir, layout string, t time.Time) string {
+	switch layout {
+	case config.FolderLayoutByMonth:
+		return filepath.Join(storageDir, t.Format("2006"), t.Format("01"))
+	case config.FolderLayoutByYear:
+		return filepath.Join(storageDir, t.Format("2006"))
+	default:
+		return storageDir
+	}
+}
+
+// SaveEntry saves a journal entry to disk. Concurrent saves of the same
+// entry run one after the other, and the last one wins.
 func (m *Manager) SaveEntry(entry *JournalEntry) error {
+	unlock := m.lockFile(entry.FilePath)
+	defer unlock()
+
 	// Update modified time
 	entry.ModifiedAt = time.Now()
 
-	// Update word count
-	entry.WordCount = CountWords(entry.Content)
+	// Update word count and links
+	entry.WordCount = m.countEntryWords(entry)
+	entry.Links = ExtractLinks(entry.Content)
 
 	// Check if completed
-	entry.IsCompleted = entry.WordCount >= m.config.Journal.WordCountGoal
+	wasCompleted := entry.IsCompleted
+	entry.IsCompleted = m.isComplete(entry)
+
+	if m.dryRun {
+		m.logger.Info("Dry run: would save journal entry",
+			zap.String("file", entry.FilePath),
+			zap.Int("word_count", entry.WordCount))
+		return nil
+	}
 
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(entry.FilePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	// Record the modified time in the file itself, since copies and syncs
+	// don't reliably preserve file mod times
+	fields := entry.frontmatter()
+	fields["created_at"] = entry.CreatedAt.Truncate(time.Second)
+	fields["modified_at"] = entry.ModifiedAt.Truncate(time.Second)
+
+	// Metadata stays readable; only the body is encrypted
+	body := m.SavedContent(entry.Header, entry.Content)
+	if m.passphrase != "" {
+		var err error
+		if body, err = m.encrypt(body); err != nil {
+			return fmt.Errorf("failed to encrypt journal entry: %w", err)
+		}
+		fields[encryptedKey] = true
 	}
 
-	// Write the file
-	err := os.WriteFile(entry.FilePath, []byte(entry.Content), 0644)
+	data, err := renderFrontmatter(fields, body)
 	if err != nil {
 		return fmt.Errorf("failed to write journal entry: %w", err)
 	}
 
+	// Write the file
+	if err := m.store.Write(entry.FilePath, data); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+
+	// Persist the word count history alongside the entry
+	if err := m.SaveHistory(entry); err != nil {
+		return err
+	}
+
+	m.runHooks(entry, wasCompleted)
+
 	m.logger.Debug("Saved journal entry",
 		zap.String("file", entry.FileName),
 		zap.Int("word_count", entry.WordCount),
+		zap.Int("bytes", len(data)),
 		zap.Time("modified_at", entry.ModifiedAt))
 
 	return nil
@@ -105,70 +295,256 @@ func (m *Manager) SaveEntry(entry *JournalEntry) error {
 
 // ReadEntry reads a journal entry from disk
 func (m *Manager) ReadEntry(filePath string) (*JournalEntry, error) {
-	// Read file info
-	fileInfo, err := os.Stat(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to stat file: %w", err)
+	if err := m.checkInStorage(filePath); err != nil {
+		return nil, err
 	}
 
-	// Read file content
-	content, err := os.ReadFile(filePath)
+	// Read file content and info
+	content, modTime, err := m.store.Read(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, notFound(err)
+	}
+
+	// Flag entries whose frontmatter can't be trusted rather than failing;
+	// their whole content is kept as the body
+	fields, body, err := parseFrontmatter(string(content))
+	partial := err != nil
+
+	if encrypted, _ := fields[encryptedKey].(bool); encrypted {
+		if body, err = m.decrypt(body); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create entry
 	entry := &JournalEntry{
 		FilePath:   filePath,
 		FileName:   filepath.Base(filePath),
-		CreatedAt:  fileInfo.ModTime(), // This is an approximation, as we don't store creation time
-		ModifiedAt: fileInfo.ModTime(),
-		Content:    string(content),
-		WordCount:  CountWords(string(content)),
+		CreatedAt:  modTime, // This is an approximation, as we don't store creation time
+		ModifiedAt: modTime,
+		Content:    body,
+		Partial:    partial,
+	}
+	if partial {
+		entry.ParseError = err.Error()
+	}
+
+	if header, ok := fields["header"].(string); ok {
+		entry.Header = header
+		delete(fields, "header")
+	}
+	entry.WordCount = m.countEntryWords(entry)
+	entry.Links = ExtractLinks(entry.Content)
+
+	// Prefer the recorded modified time over the file's, which copies reset
+	if modifiedAt, ok := frontmatterTime(fields, "modified_at"); ok {
+		entry.ModifiedAt = modifiedAt
+		if entry.CreatedAt.After(modifiedAt) {
+			entry.CreatedAt = modifiedAt
+		}
+		delete(fields, "modified_at")
+	}
+
+	// Entries saved since created_at was recorded know when they were
+	// started, even if backdated
+	if createdAt, ok := frontmatterTime(fields, "created_at"); ok {
+		entry.CreatedAt = createdAt
+		delete(fields, "created_at")
+	}
+
+	// A per-entry goal overrides the configured one
+	if goal, ok := fields["word_count_goal"].(int); ok && goal > 0 {
+		entry.Goal = goal
+		delete(fields, "word_count_goal")
+	}
+
+	// Whatever is left came from elsewhere and is kept as is
+	delete(fields, encryptedKey)
+	if len(fields) > 0 {
+		entry.Extra = fields
 	}
 
+	// Load word count history, if any was recorded
+	m.loadHistory(entry)
+
 	// Check if completed
-	entry.IsCompleted = entry.WordCount >= m.config.Journal.WordCountGoal
+	entry.IsCompleted = m.isComplete(entry)
 
 	return entry, nil
 }
 
-// ListEntries lists all journal entries
-func (m *Manager) ListEntries() ([]*JournalEntry, error) {
+// EffectiveGoal returns the entry's own goal if it has one, and the
+// configured goal otherwise, in the units of the configured goal type
+func (m *Manager) EffectiveGoal(entry *JournalEntry) int {
+	if entry.Goal > 0 {
+		return entry.Goal
+	}
+	return m.config.Journal.WordCountGoal
+}
+
+// ResolveEntryPath turns an entry name (with or without the .md extension),
+// or a path relative to the journal directory, into a path inside it. A
+// name alone also finds entries filed in subdirectories.
+func (m *Manager) ResolveEntryPath(name string) (string, error) {
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+
+	// Paths are taken relative to the journal directory and must stay in it
+	filePath := filepath.Join(m.config.Journal.StorageDir, name)
+	if err := m.checkInStorage(filePath); err != nil {
+		return "", err
+	}
+	if rel, _ := filepath.Rel(m.config.Journal.StorageDir, filePath); inAttachments(rel) {
+		return "", fmt.Errorf("%w: %s is an attachment", ErrNotFound, name)
+	}
+
+	_, _, err := m.store.Read(filePath)
+	if err == nil {
+		return filePath, nil
+	}
+	// A plain file name also finds an entry filed in a subdirectory
+	if errors.Is(err, fs.ErrNotExist) && filepath.Base(name) == name {
+		if nested, ok := m.findNested(name); ok {
+			return nested, nil
+		}
+	}
+	return "", notFound(err)
+}
+
+// findNested looks for an entry file of the given name in the journal
+// directory's subdirectories, taking the first in path order
+func (m *Manager) findNested(name string) (string, bool) {
+	paths, err := m.store.Walk(m.config.Journal.StorageDir)
+	if err != nil {
+		return "", false
+	}
+	for _, rel := range paths {
+		if filepath.Base(rel) == name && !inAttachments(rel) {
+			return filepath.Join(m.config.Journal.StorageDir, rel), true
+		}
+	}
+	return "", false
+}
+
+// inAttachments reports whether a path relative to the journal directory
+// is an attached file rather than an entry. Attachments sit next to their
+// entry, so with a folder layout they are nested as deep as it is.
+func inAttachments(rel string) bool {
+	for _, dir := range strings.Split(filepath.Dir(rel), string(filepath.Separator)) {
+		if dir == AttachmentsDir {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteEntry removes an entry and its sidecar files
+func (m *Manager) DeleteEntry(filePath string) error {
+	if err := m.checkInStorage(filePath); err != nil {
+		return err
+	}
+
+	if m.dryRun {
+		m.logger.Info("Dry run: would delete journal entry", zap.String("file", filePath))
+		return nil
+	}
+
+	unlock := m.lockFile(filePath)
+	defer unlock()
+
+	if err := m.store.Delete(filePath); err != nil {
+		return fmt.Errorf("failed to delete journal entry: %w", notFound(err))
+	}
+
+	// Sidecars are optional, so only report real failures
+	for _, sidecar := range []string{historyPath(filePath), conversationPath(filePath), embeddingPath(filePath)} {
+		if err := m.store.Delete(sidecar); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			m.logger.Warn("Failed to delete sidecar file",
+				zap.String("file", sidecar),
+				zap.Error(err))
+		}
+	}
+
+	m.logger.Info("Deleted journal entry", zap.String("file", filepath.Base(filePath)))
+	return nil
+}
+
+// ListOption configures which entries ListEntries returns
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	includePartial bool
+}
+
+// IncludePartial makes ListEntries return entries with malformed
+// frontmatter, flagged as Partial, instead of skipping them
+func IncludePartial(include bool) ListOption {
+	return func(o *listOptions) {
+		o.includePartial = include
+	}
+}
+
+// ListEntries lists all journal entries. Their metadata is cached in
+// IndexFile, so only files that changed since the last listing are read;
+// the content of the others is left for LoadContent to read
+func (m *Manager) ListEntries(opts ...ListOption) ([]*JournalEntry, error) {
+	var options listOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	entries := []*JournalEntry{}
 
-	// Read directory
-	files, err := os.ReadDir(m.config.Journal.StorageDir)
+	// Read the directory, including entries filed into subdirectories
+	files, err := m.store.Walk(m.config.Journal.StorageDir)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return entries, nil
 		}
 		return nil, fmt.Errorf("failed to read journal directory: %w", err)
 	}
 
-	// Process each file
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
+	index := m.loadIndex()
+	seen := map[string]bool{}
 
-		// Check if it's a markdown file
-		if !strings.HasSuffix(file.Name(), ".md") {
+	// Process each file
+	for _, name := range files {
+		// Check if it's a markdown file, and not one that was attached
+		if !strings.HasSuffix(name, ".md") || inAttachments(name) {
 			continue
 		}
+		seen[name] = true
 
 		// Read the entry
-		entry, err := m.ReadEntry(filepath.Join(m.config.Journal.StorageDir, file.Name()))
+		entry, err := m.readIndexed(index, name)
+		// The same passphrase opens every entry, so one failure means it's wrong
+		if errors.Is(err, ErrWrongPassphrase) {
+			return nil, err
+		}
 		if err != nil {
 			m.logger.Warn("Failed to read journal entry",
-				zap.String("file", file.Name()),
+				zap.String("file", name),
 				zap.Error(err))
 			continue
 		}
 
+		if entry.Partial && !options.includePartial {
+			m.logger.Warn("Skipping partially parsed journal entry",
+				zap.String("file", name),
+				zap.String("error", entry.ParseError))
+			continue
+		}
+
 		entries = append(entries, entry)
 	}
 
+	// The entries were read fine, so a cache that can't be saved only costs
+	// speed next time
+	if err := m.saveIndex(index, seen); err != nil {
+		m.logger.Warn("Failed to save entry index", zap.Error(err))
+	}
+
 	return entries, nil
 }
 
@@ -178,3 +554,87 @@ func CountWords(text string) int {
 	words := strings.Fields(text)
 	return len(words)
 }
+
+// CountWords counts words using the counter selected in the configuration
+func (m *Manager) CountWords(text string) int {
+	return WordCounter(m.config)(text)
+}
+
+// WordCounter returns the word counter selected in the configuration
+func WordCounter(cfg *config.Config) func(string) int {
+	switch {
+	case cfg.Journal.CJKCounting:
+		return CountWordsCJK
+	case cfg.Journal.UnicodeCounting:
+		return CountWordsUnicode
+	default:
+		return CountWords
+	}
+}
+
+// CountWordsUnicode counts runs of letters, digits, and combining marks, so
+// exotic whitespace and standalone punctuation never count as words.
+// Apostrophes and hyphens between letters keep a word together, as in
+// "don't" or "well-known".
+func CountWordsUnicode(text string) int {
+	runes := []rune(text)
+	count := 0
+	inWord := false
+	for i, r := range runes {
+		switch {
+		case isWordRune(r):
+			if !inWord {
+				count++
+				inWord = true
+			}
+		case inWord && isWordJoiner(r) && i+1 < len(runes) && isWordRune(runes[i+1]):
+			// Joins two parts of the same word
+		default:
+			inWord = false
+		}
+	}
+	return count
+}
+
+// isWordRune reports whether r can be part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// isWordJoiner reports whether r joins letters into one word
+func isWordJoiner(r rune) bool {
+	switch r {
+	case '\'', '’', '-', '‐':
+		return true
+	}
+	return false
+}
+
+// CountWordsCJK counts each Chinese/Japanese character as a word, plus each
+// whitespace-separated run of other text. Standalone punctuation is not counted.
+func CountWordsCJK(text string) int {
+	count := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			count++
+			inWord = false
+		case unicode.IsSpace(r):
+			inWord = false
+		case inWord:
+			// Still inside a word, including punctuation like "don't"
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			// Punctuation on its own (e.g. "。" or "—") isn't a word
+		default:
+			count++
+			inWord = true
+		}
+	}
+	return count
+}
+
+// isCJK reports whether r is a Han, Hiragana, or Katakana character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
@@ -0,0 +1,120 @@
+package journal
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// wikiLinkPattern matches [[target]] and [[target|label]] links
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]\n]+)\]\]`)
+
+// ExtractLinks returns the targets of the wiki-links in content, in order
+// of first appearance and without duplicates
+func ExtractLinks(content string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, match := range wikiLinkPattern.FindAllStringSubmatch(content, -1) {
+		target := LinkTarget(match[1])
+		if target == "" || seen[target] {
+			continue
+		}
+		seen[target] = true
+		links = append(links, target)
+	}
+	return links
+}
+
+// LinkTarget returns the entry a link's inner text points at, dropping
+// any "|label" part
+func LinkTarget(inner string) string {
+	target, _, _ := strings.Cut(inner, "|")
+	return strings.TrimSuffix(strings.TrimSpace(target), ".md")
+}
+
+// LinkSpans returns the byte offsets of each wiki-link in line, including
+// its brackets, with the entry it points at
+func LinkSpans(line string) (spans [][2]int, targets []string) {
+	for _, loc := range wikiLinkPattern.FindAllStringSubmatchIndex(line, -1) {
+		spans = append(spans, [2]int{loc[0], loc[1]})
+		targets = append(targets, LinkTarget(line[loc[2]:loc[3]]))
+	}
+	return spans, targets
+}
+
+// resolveLinkName picks the entry file a link points at from names: the
+// entry of that exact name, or else the earliest entry whose name starts
+// with the link, so [[2024-05-01]] finds that day's first entry
+func resolveLinkName(link string, names []string) (string, bool) {
+	if link == "" {
+		return "", false
+	}
+
+	var candidates []string
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		if name == link+".md" {
+			return name, true
+		}
+		if strings.HasPrefix(name, link) {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.Strings(candidates)
+	return candidates[0], true
+}
+
+// ResolveLink returns the path of the entry a wiki-link points at
+func (m *Manager) ResolveLink(link string) (string, error) {
+	paths, err := m.store.Walk(m.config.Journal.StorageDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read journal directory: %w", err)
+	}
+
+	// Links name entries by file name wherever they are filed
+	byName := make(map[string]string, len(paths))
+	names := make([]string, 0, len(paths))
+	for _, rel := range paths {
+		name := filepath.Base(rel)
+		if _, seen := byName[name]; seen || inAttachments(rel) {
+			continue
+		}
+		byName[name] = rel
+		names = append(names, name)
+	}
+
+	name, ok := resolveLinkName(link, names)
+	if !ok {
+		return "", fmt.Errorf("%w: no entry matches [[%s]]", ErrNotFound, link)
+	}
+	return m.ResolveEntryPath(byName[name])
+}
+
+// Backlinks returns the entries whose links resolve to target
+func Backlinks(entries []*JournalEntry, target *JournalEntry) []*JournalEntry {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.FileName)
+	}
+
+	var linking []*JournalEntry
+	for _, entry := range entries {
+		if entry.FileName == target.FileName {
+			continue
+		}
+		for _, link := range entry.Links {
+			if name, ok := resolveLinkName(link, names); ok && name == target.FileName {
+				linking = append(linking, entry)
+				break
+			}
+		}
+	}
+	return linking
+}
@@ -0,0 +1,121 @@
+package journal
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestExtractLinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "no links", content: "just words [not a link]", want: nil},
+		{name: "one link", content: "see [[2024-03-05]] again", want: []string{"2024-03-05"}},
+		{name: "label dropped", content: "[[2024-03-05T09:30-morning-pages|that day]]", want: []string{"2024-03-05T09:30-morning-pages"}},
+		{name: "extension dropped", content: "[[notes.md]]", want: []string{"notes"}},
+		{name: "spaces trimmed", content: "[[ notes ]]", want: []string{"notes"}},
+		{name: "duplicates once, in order", content: "[[b]] [[a]] [[b|again]] [[a.md]]", want: []string{"b", "a"}},
+		{name: "empty link skipped", content: "[[ ]] [[|label]]", want: nil},
+		{name: "no line breaks inside", content: "[[split\nlink]]", want: nil},
+		{name: "nested brackets", content: "[[[inner]]]", want: []string{"inner"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractLinks(tt.content); !slices.Equal(got, tt.want) {
+				t.Errorf("ExtractLinks(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLinkName(t *testing.T) {
+	names := []string{
+		"2024-03-05T21:00-morning-pages.md",
+		"2024-03-05T09:30-morning-pages.md",
+		"2024-03-05T09:30-morning-pages.history.json",
+		"notes.md",
+		"notes-extra.md",
+	}
+	tests := []struct {
+		link   string
+		want   string
+		wantOK bool
+	}{
+		{link: "notes", want: "notes.md", wantOK: true},
+		{link: "2024-03-05T09:30-morning-pages", want: "2024-03-05T09:30-morning-pages.md", wantOK: true},
+		{link: "2024-03-05", want: "2024-03-05T09:30-morning-pages.md", wantOK: true},
+		{link: "notes-e", want: "notes-extra.md", wantOK: true},
+		{link: "2023", wantOK: false},
+		{link: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			got, ok := resolveLinkName(tt.link, names)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("resolveLinkName(%q) = %q, %v; want %q, %v", tt.link, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// createLinked creates an entry at the given time holding content
+func createLinked(t *testing.T, m *Manager, at time.Time, content string) *JournalEntry {
+	t.Helper()
+	entry, _, err := m.CreateEntryAt(at)
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	entry.Content = content
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	return entry
+}
+
+func TestResolveLink(t *testing.T) {
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.FolderLayout = config.FolderLayoutByMonth
+	})
+	target := createLinked(t, m, time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local), "target")
+
+	got, err := m.ResolveLink("2024-03-05")
+	if err != nil || got != target.FilePath {
+		t.Errorf("ResolveLink = %q, %v; want %s", got, err, target.FilePath)
+	}
+	if _, err := m.ResolveLink("2023-01-01"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unresolved link: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBacklinks(t *testing.T) {
+	m, _ := newTestManager(t, nil)
+	day := func(h int) time.Time { return time.Date(2024, 3, 5, h, 0, 0, 0, time.Local) }
+	target := createLinked(t, m, day(7), "links to itself [[2024-03-05T07:00-morning-pages]]")
+	byName := createLinked(t, m, day(8), "see [[2024-03-05T07:00-morning-pages]] and [[2024-03-05T07:00-morning-pages|again]]")
+	byDay := createLinked(t, m, day(9), "that morning [[2024-03-05]]")
+	createLinked(t, m, day(10), "links elsewhere [[2024-03-05T09:00-morning-pages]] and [[nowhere]]")
+	createLinked(t, m, day(11), "no links")
+
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	var names []string
+	for _, entry := range Backlinks(entries, target) {
+		names = append(names, filepath.Base(entry.FilePath))
+	}
+	slices.Sort(names)
+	want := []string{byName.FileName, byDay.FileName}
+	if !slices.Equal(names, want) {
+		t.Errorf("Backlinks = %v, want %v", names, want)
+	}
+}
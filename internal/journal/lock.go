@@ -0,0 +1,27 @@
+package journal
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// lockFile serializes changes to one file across goroutines sharing the
+// Manager, e.g. an autosave tick racing a manual save. It returns the
+// function that releases the lock. Locks are per path, so work on different
+// entries still runs in parallel.
+func (m *Manager) lockFile(path string) func() {
+	m.fileLocksMu.Lock()
+	if m.fileLocks == nil {
+		m.fileLocks = make(map[string]*sync.Mutex)
+	}
+	key := filepath.Clean(path)
+	mu, ok := m.fileLocks[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		m.fileLocks[key] = mu
+	}
+	m.fileLocksMu.Unlock()
+
+	mu.Lock()
+	return mu.Unlock
+}
@@ -0,0 +1,78 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// TestConcurrentSavesAndDeletes races saves and deletes of one entry, as an
+// autosave tick might race a manual save. Run it with -race.
+func TestConcurrentSavesAndDeletes(t *testing.T) {
+	dir := t.TempDir()
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.StorageDir = dir
+	}, WithStore(FileStore{FileMode: 0o644, DirMode: 0o755}))
+
+	entry, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+
+	const savers = 20
+	contents := make(map[string]bool, savers)
+	for i := range savers {
+		contents[strings.Repeat(fmt.Sprintf("save%d ", i), 200)] = true
+	}
+
+	var wg sync.WaitGroup
+	for content := range contents {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			// Each save gets its own copy, as each TUI tick would
+			e := *entry
+			e.Content = content
+			if err := m.SaveEntry(&e); err != nil {
+				t.Errorf("SaveEntry: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			// The file may already be gone; only the race matters here
+			m.DeleteEntry(entry.FilePath)
+		}()
+	}
+	wg.Wait()
+
+	// No temp files were left behind
+	names, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("reading journal directory: %v", err)
+	}
+	for _, name := range names {
+		if strings.Contains(name.Name(), ".tmp-") {
+			t.Errorf("temp file %s was left behind", name.Name())
+		}
+	}
+
+	// Whichever write came last, the file holds all of one save
+	data, err := os.ReadFile(entry.FilePath)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("reading entry: %v", err)
+	}
+	read, err := m.ReadEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v\n%s", err, data)
+	}
+	if !contents[read.Content] {
+		t.Errorf("entry holds a mix of saves: %.80q...", read.Content)
+	}
+}
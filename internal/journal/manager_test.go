@@ -0,0 +1,377 @@
+package journal
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+// testStorageDir is where test managers keep their journal in memory
+const testStorageDir = "/journal"
+
+// newTestManager returns a manager over an empty MemoryStore, with the
+// default config but for any changes made by edit
+func newTestManager(t *testing.T, edit func(*config.Config), opts ...Option) (*Manager, *MemoryStore) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Journal.StorageDir = testStorageDir
+	if edit != nil {
+		edit(cfg)
+	}
+
+	store := NewMemoryStore()
+	m, err := NewManager(cfg, zap.NewNop(), append([]Option{WithStore(store)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return m, store
+}
+
+func TestCreateEntry(t *testing.T) {
+	m, store := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.EntryHeaderTemplate = "# {{.Date}}\n\n"
+	})
+	at := time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local)
+
+	first, _, err := m.CreateEntryAt(at)
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	if want := filepath.Join(testStorageDir, "2024-03-05T09:30-morning-pages.md"); first.FilePath != want {
+		t.Errorf("FilePath = %q, want %q", first.FilePath, want)
+	}
+	if first.Header == "" || first.Content != first.Header {
+		t.Errorf("Content = %q, want just the header %q", first.Content, first.Header)
+	}
+	if _, _, err := store.Read(first.FilePath); err != nil {
+		t.Errorf("entry wasn't written: %v", err)
+	}
+
+	// A second entry in the same minute doesn't replace the first
+	second, _, err := m.CreateEntryAt(at)
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	if want := "2024-03-05T09:30-morning-pages-1.md"; second.FileName != want {
+		t.Errorf("FileName = %q, want %q", second.FileName, want)
+	}
+}
+
+func TestSaveAndReadEntry(t *testing.T) {
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.WordCountGoal = 3
+	})
+	entry, _, err := m.CreateEntryAt(time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+
+	entry.Content = "one two three four [[other]]"
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	if entry.WordCount != 5 || !entry.IsCompleted {
+		t.Errorf("after save WordCount = %d, IsCompleted = %v; want 5, true", entry.WordCount, entry.IsCompleted)
+	}
+
+	read, err := m.ReadEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if read.Content != entry.Content {
+		t.Errorf("Content = %q, want %q", read.Content, entry.Content)
+	}
+	if !read.CreatedAt.Equal(entry.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v", read.CreatedAt, entry.CreatedAt)
+	}
+	if read.WordCount != 5 || !read.IsCompleted {
+		t.Errorf("WordCount = %d, IsCompleted = %v; want 5, true", read.WordCount, read.IsCompleted)
+	}
+	if len(read.Links) != 1 || read.Links[0] != "other" {
+		t.Errorf("Links = %v, want [other]", read.Links)
+	}
+}
+
+func TestReadEntryErrors(t *testing.T) {
+	m, _ := newTestManager(t, nil)
+
+	if _, err := m.ReadEntry(filepath.Join(testStorageDir, "missing.md")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing entry: err = %v, want ErrNotFound", err)
+	}
+	if _, err := m.ReadEntry("/elsewhere/entry.md"); !errors.Is(err, ErrOutsideStorage) {
+		t.Errorf("entry outside the journal: err = %v, want ErrOutsideStorage", err)
+	}
+}
+
+func TestReadEntryModifiedAt(t *testing.T) {
+	modTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorded := time.Date(2024, 3, 5, 10, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		content string
+		want    time.Time
+	}{
+		{name: "yaml timestamp", content: "---\nmodified_at: 2024-03-05T10:15:00Z\n---\nbody", want: recorded},
+		{name: "quoted timestamp", content: "---\nmodified_at: \"2024-03-05T10:15:00Z\"\n---\nbody", want: recorded},
+		{name: "no frontmatter", content: "body", want: modTime},
+		{name: "no modified_at", content: "---\nmood: calm\n---\nbody", want: modTime},
+		{name: "not a time", content: "---\nmodified_at: yesterday\n---\nbody", want: modTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, store := newTestManager(t, nil)
+			path := filepath.Join(testStorageDir, "entry.md")
+			if err := store.Write(path, []byte(tt.content)); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			store.age(t, path, modTime)
+
+			entry, err := m.ReadEntry(path)
+			if err != nil {
+				t.Fatalf("ReadEntry: %v", err)
+			}
+			if !entry.ModifiedAt.Equal(tt.want) {
+				t.Errorf("ModifiedAt = %v, want %v", entry.ModifiedAt, tt.want)
+			}
+			if entry.CreatedAt.After(entry.ModifiedAt) {
+				t.Errorf("CreatedAt %v is after ModifiedAt %v", entry.CreatedAt, entry.ModifiedAt)
+			}
+			if entry.Content != "body" {
+				t.Errorf("Content = %q, want the body alone", entry.Content)
+			}
+		})
+	}
+}
+
+func TestSaveEntryKeepsModifiedAt(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	entry, _, err := m.CreateEntryAt(time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	entry.Content = "words"
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	saved := entry.ModifiedAt.Truncate(time.Second)
+
+	// A copy or sync resets the file's mod time
+	store.age(t, entry.FilePath, saved.Add(48*time.Hour))
+
+	read, err := m.ReadEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if !read.ModifiedAt.Equal(saved) {
+		t.Errorf("ModifiedAt = %v, want the saved %v", read.ModifiedAt, saved)
+	}
+	if _, ok := read.Extra["modified_at"]; ok {
+		t.Error("modified_at left in Extra")
+	}
+}
+
+func TestDeleteEntry(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	entry := createWithHistory(t, m)
+	if !store.has(historyPath(entry.FilePath)) {
+		t.Fatalf("history sidecar wasn't written")
+	}
+
+	if err := m.DeleteEntry(entry.FilePath); err != nil {
+		t.Fatalf("DeleteEntry: %v", err)
+	}
+	for _, path := range []string{entry.FilePath, historyPath(entry.FilePath)} {
+		if store.has(path) {
+			t.Errorf("%s is still in the store", path)
+		}
+	}
+
+	if err := m.DeleteEntry(entry.FilePath); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second delete: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListEntries(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	for _, at := range []time.Time{
+		time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local),
+		time.Date(2024, 3, 6, 9, 30, 0, 0, time.Local),
+	} {
+		if _, _, err := m.CreateEntryAt(at); err != nil {
+			t.Fatalf("CreateEntryAt: %v", err)
+		}
+	}
+	// Neither of these is an entry
+	store.Write(filepath.Join(testStorageDir, "notes.txt"), []byte("not an entry"))
+	store.Write(filepath.Join(testStorageDir, AttachmentsDir, "a.md"), []byte("attached"))
+
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.FileName)
+	}
+	want := "2024-03-05T09:30-morning-pages.md 2024-03-06T09:30-morning-pages.md"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("entries = %s, want %s", got, want)
+	}
+}
+
+func TestListEntriesPartial(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	store.Write(filepath.Join(testStorageDir, "broken.md"), []byte("---\nword_count_goal: [\n---\nbody"))
+
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("got %d entries, want the broken one skipped", len(entries))
+	}
+
+	entries, err = m.ListEntries(IncludePartial(true))
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 1 || !entries[0].Partial {
+		t.Errorf("got %+v, want the broken entry flagged Partial", entries)
+	}
+}
+
+func TestTrashEntry(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	entry := createWithHistory(t, m)
+
+	trashPath, err := m.TrashEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+	if want := filepath.Join(testStorageDir, TrashDir, entry.FileName); trashPath != want {
+		t.Errorf("trash path = %q, want %q", trashPath, want)
+	}
+	if store.has(entry.FilePath) || !store.has(trashPath) {
+		t.Errorf("entry wasn't moved to the trash")
+	}
+	if !store.has(historyPath(trashPath)) {
+		t.Errorf("history sidecar didn't follow the entry")
+	}
+
+	// Trashed entries aren't listed
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("got %d entries, want none", len(entries))
+	}
+
+	// An entry of the same name gets a new name in the trash
+	again, _, err := m.CreateEntryAt(entry.CreatedAt)
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	second, err := m.TrashEntry(again.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+	if second == trashPath {
+		t.Errorf("second trash path %q replaced the first", second)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.MaxSnapshots = 2
+	})
+	entry, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+
+	for _, content := range []string{"one", "one two", "one two three"} {
+		entry.Content = content
+		if err := m.SaveEntry(entry); err != nil {
+			t.Fatalf("SaveEntry: %v", err)
+		}
+		if err := m.Snapshot(entry); err != nil {
+			t.Fatalf("Snapshot: %v", err)
+		}
+	}
+
+	snapshots, err := m.Snapshots(entry)
+	if err != nil {
+		t.Fatalf("Snapshots: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("got %d snapshots, want the oldest pruned to 2", len(snapshots))
+	}
+
+	// The first snapshot was pruned, and the rest hold each save in turn
+	for i, want := range []string{"\none two", "\none two three"} {
+		data, _, err := m.store.Read(snapshots[i].Path)
+		if err != nil {
+			t.Fatalf("reading snapshot: %v", err)
+		}
+		if !strings.HasSuffix(string(data), want) {
+			t.Errorf("snapshot %d = %q, want it to end %q", i, data, want)
+		}
+	}
+}
+
+// createWithHistory creates an entry and saves it with a word count
+// sample, so it has a history sidecar
+func createWithHistory(t *testing.T, m *Manager) *JournalEntry {
+	t.Helper()
+	entry, err := m.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	entry.Content = "a few words"
+	m.RecordSample(entry, time.Now())
+	if err := m.SaveEntry(entry); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	return entry
+}
+
+// has reports whether the store holds a file at path
+func (s *MemoryStore) has(path string) bool {
+	_, _, err := s.Read(path)
+	return err == nil
+}
+
+func TestListEntriesNested(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	for _, rel := range []string{
+		"top.md",
+		filepath.Join("2024", "03", "05", "nested.md"),
+		filepath.Join(".git", "hidden.md"),
+		filepath.Join("2024", AttachmentsDir, "attached.md"),
+	} {
+		store.Write(filepath.Join(testStorageDir, rel), []byte("words"))
+	}
+
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.FilePath)
+	}
+	want := []string{
+		filepath.Join(testStorageDir, "2024", "03", "05", "nested.md"),
+		filepath.Join(testStorageDir, "top.md"),
+	}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("entries = %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,122 @@
+package journal
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memFile is a file held by MemoryStore
+type memFile struct {
+	data    []byte
+	modTime time.Time
+}
+
+// MemoryStore is a Store that keeps files in memory, useful for tests and
+// ephemeral sessions
+type MemoryStore struct {
+	mu    sync.RWMutex
+	files map[string]memFile
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{files: make(map[string]memFile)}
+}
+
+// Create is a no-op since directories are implicit in memory
+func (s *MemoryStore) Create(dir string) error {
+	return nil
+}
+
+// Read returns a copy of the stored file
+func (s *MemoryStore) Read(path string) ([]byte, time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.files[filepath.Clean(path)]
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf("failed to read file %s: %w", path, fs.ErrNotExist)
+	}
+	return append([]byte(nil), f.data...), f.modTime, nil
+}
+
+// Write stores a copy of data under path
+func (s *MemoryStore) Write(path string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files[filepath.Clean(path)] = memFile{
+		data:    append([]byte(nil), data...),
+		modTime: time.Now(),
+	}
+	return nil
+}
+
+// List returns the sorted names of the files directly inside dir
+func (s *MemoryStore) List(dir string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dir = filepath.Clean(dir)
+	names := []string{}
+	for path := range s.files {
+		if filepath.Dir(path) == dir {
+			names = append(names, filepath.Base(path))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Walk returns the sorted paths, relative to dir, of the files under dir
+func (s *MemoryStore) Walk(dir string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dir = filepath.Clean(dir)
+	paths := []string{}
+	for path := range s.files {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if hiddenDir(filepath.Dir(rel)) {
+			continue
+		}
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// hiddenDir reports whether a relative directory path passes through a
+// directory whose name starts with a dot
+func hiddenDir(rel string) bool {
+	if rel == "." {
+		return false
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete removes the file from the store
+func (s *MemoryStore) Delete(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path = filepath.Clean(path)
+	if _, ok := s.files[path]; !ok {
+		return fmt.Errorf("failed to delete file %s: %w", path, fs.ErrNotExist)
+	}
+	delete(s.files, path)
+	return nil
+}
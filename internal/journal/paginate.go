@@ -0,0 +1,21 @@
+package journal
+
+// Paginate returns page (counting from 1) of entries when split into pages
+// of limit entries, along with the index of the page's first entry. A limit
+// of 0 or less returns every entry as a single page; a page past the end is
+// empty.
+func Paginate(entries []*JournalEntry, page, limit int) ([]*JournalEntry, int) {
+	if limit <= 0 {
+		return entries, 0
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * limit
+	if start >= len(entries) {
+		return nil, len(entries)
+	}
+	end := min(start+limit, len(entries))
+	return entries[start:end], start
+}
@@ -0,0 +1,45 @@
+package journal
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	entries := make([]*JournalEntry, 5)
+	for i := range entries {
+		entries[i] = &JournalEntry{FileName: fmt.Sprint(i)}
+	}
+
+	tests := []struct {
+		name      string
+		entries   []*JournalEntry
+		page      int
+		limit     int
+		want      []*JournalEntry
+		wantFirst int
+	}{
+		{name: "first page", entries: entries, page: 1, limit: 2, want: entries[0:2], wantFirst: 0},
+		{name: "middle page", entries: entries, page: 2, limit: 2, want: entries[2:4], wantFirst: 2},
+		{name: "last partial page", entries: entries, page: 3, limit: 2, want: entries[4:5], wantFirst: 4},
+		{name: "exact last page", entries: entries, page: 1, limit: 5, want: entries, wantFirst: 0},
+		{name: "past the end", entries: entries, page: 4, limit: 2, want: nil, wantFirst: 5},
+		{name: "page below one is first", entries: entries, page: 0, limit: 2, want: entries[0:2], wantFirst: 0},
+		{name: "negative page is first", entries: entries, page: -3, limit: 2, want: entries[0:2], wantFirst: 0},
+		{name: "no limit", entries: entries, page: 3, limit: 0, want: entries, wantFirst: 0},
+		{name: "negative limit", entries: entries, page: 1, limit: -1, want: entries, wantFirst: 0},
+		{name: "empty list", entries: nil, page: 1, limit: 2, want: nil, wantFirst: 0},
+		{name: "empty list, no limit", entries: nil, page: 1, limit: 0, want: nil, wantFirst: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, first := Paginate(tt.entries, tt.page, tt.limit)
+			if !slices.Equal(got, tt.want) || first != tt.wantFirst {
+				t.Errorf("Paginate(%d entries, %d, %d) = %d entries from %d, want %d from %d",
+					len(tt.entries), tt.page, tt.limit, len(got), first, len(tt.want), tt.wantFirst)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package journal
+
+import (
+	"math"
+	"sort"
+)
+
+// Related is an entry and how similar it is to the one being compared against
+type Related struct {
+	Entry *JournalEntry `json:"entry"`
+	Score float64       `json:"score"` // Cosine similarity, from -1 to 1
+}
+
+// CosineSimilarity returns the cosine of the angle between two vectors.
+// Vectors of different lengths, or with no magnitude, score 0.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// RankBySimilarity scores each candidate against target and returns the n
+// most similar, best first. Candidates are given as parallel slices of
+// entries and their vectors; n <= 0 returns them all.
+func RankBySimilarity(target []float32, entries []*JournalEntry, vectors [][]float32, n int) []Related {
+	ranked := make([]Related, 0, len(entries))
+	for i, entry := range entries {
+		ranked = append(ranked, Related{Entry: entry, Score: CosineSimilarity(target, vectors[i])})
+	}
+
+	// Ties keep the input order so results are stable across runs
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	if n > 0 && len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
@@ -0,0 +1,74 @@
+package journal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{name: "same direction", a: []float32{1, 2, 3}, b: []float32{2, 4, 6}, want: 1},
+		{name: "opposite", a: []float32{1, 0}, b: []float32{-3, 0}, want: -1},
+		{name: "orthogonal", a: []float32{1, 0}, b: []float32{0, 5}, want: 0},
+		{name: "45 degrees", a: []float32{1, 0}, b: []float32{1, 1}, want: math.Sqrt2 / 2},
+		{name: "different lengths", a: []float32{1, 2}, b: []float32{1, 2, 3}, want: 0},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 1}, want: 0},
+		{name: "empty", want: 0},
+	}
+	for _, tt := range tests {
+		if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: CosineSimilarity = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankBySimilarity(t *testing.T) {
+	target := []float32{1, 0, 0}
+	entries := []*JournalEntry{
+		{FileName: "unrelated.md"},
+		{FileName: "close.md"},
+		{FileName: "same.md"},
+		{FileName: "opposite.md"},
+		{FileName: "also-close.md"},
+		{FileName: "wrong-model.md"},
+	}
+	vectors := [][]float32{
+		{0, 1, 0},
+		{0.9, 0.1, 0},
+		{2, 0, 0},
+		{-1, 0, 0},
+		{0.9, 0, 0.1},
+		{1, 0},
+	}
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{n: 0, want: []string{"same.md", "close.md", "also-close.md", "unrelated.md", "wrong-model.md", "opposite.md"}},
+		{n: 2, want: []string{"same.md", "close.md"}},
+		{n: 10, want: []string{"same.md", "close.md", "also-close.md", "unrelated.md", "wrong-model.md", "opposite.md"}},
+	}
+	for _, tt := range tests {
+		ranked := RankBySimilarity(target, entries, vectors, tt.n)
+		if len(ranked) != len(tt.want) {
+			t.Fatalf("n=%d: got %d results, want %d", tt.n, len(ranked), len(tt.want))
+		}
+		for i, r := range ranked {
+			if r.Entry.FileName != tt.want[i] {
+				t.Errorf("n=%d: #%d is %s (%.3f), want %s", tt.n, i+1, r.Entry.FileName, r.Score, tt.want[i])
+			}
+			if i > 0 && r.Score > ranked[i-1].Score {
+				t.Errorf("n=%d: scores out of order at #%d", tt.n, i+1)
+			}
+		}
+	}
+
+	if ranked := RankBySimilarity(target, nil, nil, 5); len(ranked) != 0 {
+		t.Errorf("no candidates ranked %v", ranked)
+	}
+}
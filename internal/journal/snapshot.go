@@ -0,0 +1,131 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SnapshotsDir is the hidden directory, next to the entries, that holds
+// each entry's snapshots in a directory of its own
+const SnapshotsDir = ".snapshots"
+
+// snapshotTimeFormat names snapshot files by when they were taken, in an
+// order that sorts by time
+const snapshotTimeFormat = "2006-01-02T15-04-05.000"
+
+// SnapshotInfo describes one snapshot of an entry
+type SnapshotInfo struct {
+	Path string    // Where the snapshot is stored
+	Time time.Time // When it was taken
+	Size int       // Bytes, as stored
+}
+
+// snapshotDir returns the directory holding the entry's snapshots
+func snapshotDir(entry *JournalEntry) string {
+	return filepath.Join(filepath.Dir(entry.FilePath), SnapshotsDir, strings.TrimSuffix(entry.FileName, ".md"))
+}
+
+// Snapshot copies the entry file, as last saved, into its snapshot
+// directory and prunes the oldest snapshots beyond Journal.MaxSnapshots.
+// The copy is taken byte for byte, so encrypted entries stay encrypted.
+func (m *Manager) Snapshot(entry *JournalEntry) error {
+	data, _, err := m.store.Read(entry.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read entry for snapshot: %w", err)
+	}
+
+	dir := snapshotDir(entry)
+	name, err := m.freeName(dir, time.Now().Format(snapshotTimeFormat)+".md")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+
+	if m.dryRun {
+		m.logger.Info("Dry run: would save snapshot", zap.String("file", path))
+		return nil
+	}
+
+	if err := m.store.Write(path, data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	m.logger.Debug("Saved snapshot",
+		zap.String("entry", entry.FileName),
+		zap.String("file", path))
+
+	return m.pruneSnapshots(entry)
+}
+
+// pruneSnapshots deletes the oldest snapshots beyond Journal.MaxSnapshots;
+// a limit of 0 keeps them all
+func (m *Manager) pruneSnapshots(entry *JournalEntry) error {
+	limit := m.config.Journal.MaxSnapshots
+	if limit <= 0 {
+		return nil
+	}
+
+	snapshots, err := m.Snapshots(entry)
+	if err != nil {
+		return err
+	}
+	for len(snapshots) > limit {
+		if err := m.store.Delete(snapshots[0].Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to prune snapshot: %w", err)
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// Snapshots lists the entry's snapshots, oldest first
+func (m *Manager) Snapshots(entry *JournalEntry) ([]SnapshotInfo, error) {
+	dir := snapshotDir(entry)
+	names, err := m.store.List(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	var snapshots []SnapshotInfo
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, modTime, err := m.store.Read(path)
+		if err != nil {
+			m.logger.Warn("Failed to read snapshot",
+				zap.String("file", path),
+				zap.Error(err))
+			continue
+		}
+		// The name records when it was taken; fall back to the file time
+		// for snapshots that were renamed
+		stamp := strings.TrimSuffix(name, filepath.Ext(name))
+		taken, err := time.ParseInLocation(snapshotTimeFormat, stamp[:min(len(stamp), len(snapshotTimeFormat))], time.Local)
+		if err != nil {
+			taken = modTime
+		}
+		snapshots = append(snapshots, SnapshotInfo{Path: path, Time: taken, Size: len(data)})
+	}
+
+	// Snapshots taken in the same millisecond carry numbered suffixes, and
+	// the unsuffixed one came first: shorter names, then higher numbers
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		if len(a.Path) != len(b.Path) {
+			return len(a.Path) < len(b.Path)
+		}
+		return a.Path < b.Path
+	})
+	return snapshots, nil
+}
@@ -0,0 +1,170 @@
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Period is a span of time that entries can be grouped by
+type Period string
+
+const (
+	PeriodDay   Period = "day"   // Calendar day
+	PeriodWeek  Period = "week"  // ISO 8601 week, starting Monday
+	PeriodMonth Period = "month" // Calendar month
+	PeriodYear  Period = "year"  // Calendar year
+)
+
+// PeriodStat summarizes the entries created within one period
+type PeriodStat struct {
+	Label     string    `json:"label"` // e.g. "2024-W07", "2024-02" or "2024"
+	Start     time.Time `json:"start"`
+	Entries   int       `json:"entries"`
+	Words     int       `json:"words"`
+	Completed int       `json:"completed"` // Entries that met the word count goal
+}
+
+// periodStart returns the start of the period containing t and its label
+func periodStart(t time.Time, period Period) (time.Time, string) {
+	year, month, day := t.Date()
+	switch period {
+	case PeriodDay:
+		start := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+		return start, start.Format("2006-01-02")
+	case PeriodMonth:
+		start := time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+		return start, start.Format("2006-01")
+	case PeriodYear:
+		start := time.Date(year, 1, 1, 0, 0, 0, 0, t.Location())
+		return start, start.Format("2006")
+	default:
+		// ISO weeks start on Monday; Go's Weekday starts on Sunday
+		offset := (int(t.Weekday()) + 6) % 7
+		start := time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+		isoYear, isoWeek := t.ISOWeek()
+		return start, fmt.Sprintf("%d-W%02d", isoYear, isoWeek)
+	}
+}
+
+// AggregateByPeriod groups entries into weeks, months or years by CreatedAt, so an
+// entry written across a period boundary counts toward the period it was
+// started in. Periods without entries are left out; the rest are returned
+// oldest first.
+func AggregateByPeriod(entries []*JournalEntry, period Period) []PeriodStat {
+	byLabel := make(map[string]*PeriodStat)
+	for _, entry := range entries {
+		start, label := periodStart(entry.CreatedAt, period)
+		stat, ok := byLabel[label]
+		if !ok {
+			stat = &PeriodStat{Label: label, Start: start}
+			byLabel[label] = stat
+		}
+		stat.Entries++
+		stat.Words += entry.WordCount
+		if entry.IsCompleted {
+			stat.Completed++
+		}
+	}
+
+	stats := make([]PeriodStat, 0, len(byLabel))
+	for _, stat := range byLabel {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Start.Before(stats[j].Start)
+	})
+	return stats
+}
+
+// Streak counts the consecutive days, up to today, with at least one entry.
+// A streak that reached yesterday is still running, since there is time
+// left to write today.
+func Streak(entries []*JournalEntry, now time.Time) int {
+	return currentRun(entryDays(entries, now.Location(), false), now)
+}
+
+// ComputeCompletedStreak counts consecutive days with at least one completed
+// entry, so a day with several entries counts if any of them met the goal.
+// current is the run up to today, still running if it reached yesterday as
+// for Streak, and longest is the longest run there has been
+func ComputeCompletedStreak(entries []*JournalEntry, now time.Time) (current, longest int) {
+	days := entryDays(entries, now.Location(), true)
+	current = currentRun(days, now)
+
+	labels := make([]string, 0, len(days))
+	for label := range days {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	run := 0
+	var prev time.Time
+	for _, label := range labels {
+		day, _ := time.ParseInLocation("2006-01-02", label, now.Location())
+		if run > 0 && prev.AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+		prev = day
+	}
+	return current, longest
+}
+
+// entryDays returns the days, as YYYY-MM-DD in loc, with at least one
+// entry, or with completedOnly, at least one completed entry
+func entryDays(entries []*JournalEntry, loc *time.Location, completedOnly bool) map[string]bool {
+	days := make(map[string]bool)
+	for _, entry := range entries {
+		if completedOnly && !entry.IsCompleted {
+			continue
+		}
+		_, label := periodStart(entry.CreatedAt.In(loc), PeriodDay)
+		days[label] = true
+	}
+	return days
+}
+
+// currentRun counts the consecutive days in days up to today, or up to
+// yesterday if there is nothing today yet
+func currentRun(days map[string]bool, now time.Time) int {
+	day, _ := periodStart(now, PeriodDay)
+	if !days[day.Format("2006-01-02")] {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	run := 0
+	for days[day.Format("2006-01-02")] {
+		run++
+		day = day.AddDate(0, 0, -1)
+	}
+	return run
+}
+
+// EntriesOn returns the entries created on the same calendar day as t,
+// oldest first
+func EntriesOn(entries []*JournalEntry, t time.Time) []*JournalEntry {
+	_, want := periodStart(t, PeriodDay)
+	var matched []*JournalEntry
+	for _, entry := range entries {
+		if _, label := periodStart(entry.CreatedAt.In(t.Location()), PeriodDay); label == want {
+			matched = append(matched, entry)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+	})
+	return matched
+}
+
+// SumWordsForDate totals the words of all entries created on the same
+// calendar day as date, so sessions spread over a day count together
+func SumWordsForDate(entries []*JournalEntry, date time.Time) int {
+	total := 0
+	for _, entry := range EntriesOn(entries, date) {
+		total += entry.WordCount
+	}
+	return total
+}
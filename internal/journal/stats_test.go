@@ -0,0 +1,227 @@
+package journal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeCompletedStreak(t *testing.T) {
+	now := time.Date(2024, 3, 10, 21, 0, 0, 0, time.Local)
+	day := func(offset int, completed bool) *JournalEntry {
+		return &JournalEntry{
+			CreatedAt:   time.Date(2024, 3, 10+offset, 8, 0, 0, 0, time.Local),
+			IsCompleted: completed,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		entries     []*JournalEntry
+		wantCurrent int
+		wantLongest int
+	}{
+		{name: "no entries"},
+		{
+			name:        "through today",
+			entries:     []*JournalEntry{day(-2, true), day(-1, true), day(0, true)},
+			wantCurrent: 3, wantLongest: 3,
+		},
+		{
+			name:        "today not yet done",
+			entries:     []*JournalEntry{day(-2, true), day(-1, true), day(0, false)},
+			wantCurrent: 2, wantLongest: 2,
+		},
+		{
+			name:        "today not yet written",
+			entries:     []*JournalEntry{day(-2, true), day(-1, true)},
+			wantCurrent: 2, wantLongest: 2,
+		},
+		{
+			name:        "gap yesterday ends the run",
+			entries:     []*JournalEntry{day(-3, true), day(-2, true), day(0, true)},
+			wantCurrent: 1, wantLongest: 2,
+		},
+		{
+			name:        "incomplete day is a gap",
+			entries:     []*JournalEntry{day(-3, true), day(-2, false), day(-1, true), day(0, true)},
+			wantCurrent: 2, wantLongest: 2,
+		},
+		{
+			name:        "over after two missed days",
+			entries:     []*JournalEntry{day(-4, true), day(-3, true)},
+			wantCurrent: 0, wantLongest: 2,
+		},
+		{
+			name: "longest is in the past",
+			entries: []*JournalEntry{
+				day(-9, true), day(-8, true), day(-7, true), day(-6, true),
+				day(-1, true), day(0, true),
+			},
+			wantCurrent: 2, wantLongest: 4,
+		},
+		{
+			name:        "any completed entry counts for the day",
+			entries:     []*JournalEntry{day(-1, false), day(-1, true), day(0, false)},
+			wantCurrent: 1, wantLongest: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := ComputeCompletedStreak(tt.entries, now)
+			if current != tt.wantCurrent || longest != tt.wantLongest {
+				t.Errorf("got current %d, longest %d; want %d, %d", current, longest, tt.wantCurrent, tt.wantLongest)
+			}
+		})
+	}
+}
+
+func TestAggregateByPeriod(t *testing.T) {
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.Local)
+	}
+	entry := func(created time.Time, words int, completed bool) *JournalEntry {
+		return &JournalEntry{CreatedAt: created, WordCount: words, IsCompleted: completed}
+	}
+	entries := []*JournalEntry{
+		entry(at(2024, 3, 11, 0, 0), 40, true),    // Monday, starts 2024-W11
+		entry(at(2024, 3, 10, 23, 59), 10, false), // Sunday, ends 2024-W10
+		entry(at(2024, 3, 4, 8, 0), 20, true),     // Monday of 2024-W10
+		entry(at(2024, 2, 29, 22, 0), 30, false),  // Leap day, 2024-W09
+		entry(at(2024, 12, 30, 7, 0), 50, true),   // ISO 2025-W01, still 2024
+		entry(at(2021, 1, 3, 7, 0), 60, true),     // ISO 2020-W53, in 2021
+	}
+
+	type row struct {
+		label     string
+		start     time.Time
+		entries   int
+		words     int
+		completed int
+	}
+	tests := []struct {
+		period Period
+		want   []row
+	}{
+		{
+			period: PeriodWeek,
+			want: []row{
+				{"2020-W53", at(2020, 12, 28, 0, 0), 1, 60, 1},
+				{"2024-W09", at(2024, 2, 26, 0, 0), 1, 30, 0},
+				{"2024-W10", at(2024, 3, 4, 0, 0), 2, 30, 1},
+				{"2024-W11", at(2024, 3, 11, 0, 0), 1, 40, 1},
+				{"2025-W01", at(2024, 12, 30, 0, 0), 1, 50, 1},
+			},
+		},
+		{
+			period: PeriodMonth,
+			want: []row{
+				{"2021-01", at(2021, 1, 1, 0, 0), 1, 60, 1},
+				{"2024-02", at(2024, 2, 1, 0, 0), 1, 30, 0},
+				{"2024-03", at(2024, 3, 1, 0, 0), 3, 70, 2},
+				{"2024-12", at(2024, 12, 1, 0, 0), 1, 50, 1},
+			},
+		},
+		{
+			period: PeriodYear,
+			want: []row{
+				{"2021", at(2021, 1, 1, 0, 0), 1, 60, 1},
+				{"2024", at(2024, 1, 1, 0, 0), 5, 150, 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.period), func(t *testing.T) {
+			stats := AggregateByPeriod(entries, tt.period)
+			if len(stats) != len(tt.want) {
+				t.Fatalf("got %d periods, want %d: %+v", len(stats), len(tt.want), stats)
+			}
+			for i, want := range tt.want {
+				got := stats[i]
+				if got.Label != want.label || !got.Start.Equal(want.start) || got.Entries != want.entries || got.Words != want.words || got.Completed != want.completed {
+					t.Errorf("period %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+
+	if got := AggregateByPeriod(nil, PeriodWeek); len(got) != 0 {
+		t.Errorf("no entries gave %+v", got)
+	}
+}
+
+func TestStreak(t *testing.T) {
+	now := time.Date(2024, 3, 10, 21, 0, 0, 0, time.Local)
+	day := func(offset int) *JournalEntry {
+		return &JournalEntry{CreatedAt: time.Date(2024, 3, 10+offset, 8, 0, 0, 0, time.Local)}
+	}
+
+	tests := []struct {
+		name    string
+		entries []*JournalEntry
+		want    int
+	}{
+		{name: "no entries"},
+		{name: "today only", entries: []*JournalEntry{day(0)}, want: 1},
+		{name: "through today", entries: []*JournalEntry{day(-2), day(-1), day(0)}, want: 3},
+		{name: "running from yesterday", entries: []*JournalEntry{day(-2), day(-1)}, want: 2},
+		{name: "over after a missed day", entries: []*JournalEntry{day(-3), day(-2)}},
+		{name: "gap ends the run", entries: []*JournalEntry{day(-4), day(-2), day(-1), day(0)}, want: 3},
+		{name: "several entries a day count once", entries: []*JournalEntry{day(-1), day(-1), day(0), day(0)}, want: 2},
+		{name: "incomplete entries count", entries: []*JournalEntry{{CreatedAt: now.Add(-time.Hour), IsCompleted: false}}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Streak(tt.entries, now); got != tt.want {
+				t.Errorf("Streak = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntriesOn(t *testing.T) {
+	at := func(day, hour int, words int) *JournalEntry {
+		return &JournalEntry{CreatedAt: time.Date(2024, 3, day, hour, 0, 0, 0, time.Local), WordCount: words}
+	}
+	evening, morning, yesterday, midnight := at(10, 20, 30), at(10, 7, 40), at(9, 23, 50), at(11, 0, 60)
+	entries := []*JournalEntry{evening, yesterday, morning, midnight}
+
+	got := EntriesOn(entries, time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local))
+	if len(got) != 2 || got[0] != morning || got[1] != evening {
+		t.Errorf("EntriesOn = %v, want the morning then the evening entry", got)
+	}
+	if got := EntriesOn(entries, time.Date(2024, 3, 12, 12, 0, 0, 0, time.Local)); len(got) != 0 {
+		t.Errorf("EntriesOn an empty day = %v", got)
+	}
+}
+
+func TestSumWordsForDate(t *testing.T) {
+	at := func(day, hour int, words int) *JournalEntry {
+		return &JournalEntry{CreatedAt: time.Date(2024, 3, day, hour, 0, 0, 0, time.Local), WordCount: words}
+	}
+	entries := []*JournalEntry{
+		at(10, 7, 400),  // Morning
+		at(10, 21, 400), // Evening
+		at(9, 23, 50),   // Late the day before
+		at(11, 0, 60),   // Just after midnight
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want int
+	}{
+		{name: "morning and evening", date: time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local), want: 800},
+		{name: "one entry", date: time.Date(2024, 3, 11, 23, 59, 0, 0, time.Local), want: 60},
+		{name: "no entries", date: time.Date(2024, 3, 12, 12, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SumWordsForDate(entries, tt.date); got != tt.want {
+				t.Errorf("SumWordsForDate = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
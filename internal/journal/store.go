@@ -0,0 +1,178 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/fsutil"
+)
+
+// Store abstracts where journal files are kept so the Manager can work
+// against backends other than the local filesystem
+type Store interface {
+	// Create prepares the location that files under dir are stored in
+	Create(dir string) error
+	// Read returns a file's content and last modification time
+	Read(path string) ([]byte, time.Time, error)
+	// Write replaces a file's content, creating it if needed
+	Write(path string, data []byte) error
+	// List returns the names of the files directly inside dir
+	List(dir string) ([]string, error)
+	// Walk returns the paths, relative to dir, of the files in dir and its
+	// subdirectories, leaving out hidden directories such as .git
+	Walk(dir string) ([]string, error)
+	// Delete removes a file
+	Delete(path string) error
+}
+
+// Permissions FileStore uses when none are configured
+const (
+	defaultFileMode os.FileMode = 0644
+	defaultDirMode  os.FileMode = 0755
+)
+
+// FileStore is the default Store, backed by the local filesystem. Zero
+// modes fall back to 0644 for files and 0755 for directories.
+type FileStore struct {
+	FileMode os.FileMode // Permissions of files it writes
+	DirMode  os.FileMode // Permissions of directories it creates
+}
+
+// fileMode returns the permissions for written files
+func (s FileStore) fileMode() os.FileMode {
+	if s.FileMode == 0 {
+		return defaultFileMode
+	}
+	return s.FileMode
+}
+
+// dirMode returns the permissions for created directories
+func (s FileStore) dirMode() os.FileMode {
+	if s.DirMode == 0 {
+		return defaultDirMode
+	}
+	return s.DirMode
+}
+
+// Create makes sure the directory exists and that files in it can be
+// listed and written, so misconfiguration is reported up front rather than
+// at the first autosave
+func (s FileStore) Create(dir string) error {
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		return fmt.Errorf("storage_dir %s exists but is not a directory; point it at a directory instead", dir)
+	}
+
+	if err := os.MkdirAll(dir, s.dirMode()); err != nil {
+		return err
+	}
+
+	if _, err := os.ReadDir(dir); err != nil {
+		return fmt.Errorf("storage_dir %s is not readable; check its permissions: %w", dir, err)
+	}
+
+	probe, err := os.CreateTemp(dir, ".momentum-write-check-*")
+	if err != nil {
+		return fmt.Errorf("storage_dir %s is not writable; check its permissions: %w", dir, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return nil
+}
+
+// Read reads the file and its modification time from disk
+func (FileStore) Read(path string) ([]byte, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return content, info.ModTime(), nil
+}
+
+// Write atomically replaces the file, creating its parent directory if
+// necessary, so a crash mid-save never leaves a half-written entry
+func (s FileStore) Write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), s.dirMode()); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return fsutil.WriteFileAtomic(path, data, s.fileMode())
+}
+
+// List returns the regular files in dir
+func (FileStore) List(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		names = append(names, file.Name())
+	}
+	return names, nil
+}
+
+// Walk returns the regular files under dir, sorted
+func (FileStore) Walk(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// Delete removes the file from disk
+func (FileStore) Delete(path string) error {
+	return os.Remove(path)
+}
+
+// freeName returns name, or name with a numbered suffix if a file of that
+// name is already in dir
+func (m *Manager) freeName(dir, name string) (string, error) {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		_, _, err := m.store.Read(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			return name, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check for %s: %w", name, err)
+		}
+		name = fmt.Sprintf("%s-%d%s", stem, i, ext)
+	}
+}
@@ -0,0 +1,119 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+func TestFileStoreCreate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "journal")
+	if err := (FileStore{}).Create(dir); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Create left %v behind", entries)
+	}
+}
+
+func TestNewManagerStorageDirErrors(t *testing.T) {
+	newManager := func(dir string) error {
+		cfg := config.DefaultConfig()
+		cfg.Journal.StorageDir = dir
+		_, err := NewManager(cfg, zap.NewNop())
+		return err
+	}
+
+	t.Run("file in place", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "journal")
+		if err := os.WriteFile(file, []byte("not a directory"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		err := newManager(file)
+		if err == nil || !strings.Contains(err.Error(), "exists but is not a directory") {
+			t.Errorf("err = %v, want it to say the path is not a directory", err)
+		}
+	})
+
+	t.Run("unwritable", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root can write to any directory")
+		}
+		dir := filepath.Join(t.TempDir(), "journal")
+		if err := os.Mkdir(dir, 0o500); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.Chmod(dir, 0o755) })
+		err := newManager(dir)
+		if err == nil || !strings.Contains(err.Error(), "is not writable") {
+			t.Errorf("err = %v, want it to say the directory is not writable", err)
+		}
+	})
+
+	t.Run("unreadable", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root can read any directory")
+		}
+		dir := filepath.Join(t.TempDir(), "journal")
+		if err := os.Mkdir(dir, 0o300); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.Chmod(dir, 0o755) })
+		err := newManager(dir)
+		if err == nil || !strings.Contains(err.Error(), "is not readable") {
+			t.Errorf("err = %v, want it to say the directory is not readable", err)
+		}
+	})
+}
+
+func TestWalk(t *testing.T) {
+	files := []string{
+		"top.md",
+		filepath.Join("2024", "03", "05", "nested.md"),
+		filepath.Join("2024", "notes.txt"),
+		filepath.Join(".git", "objects", "ab"),
+		filepath.Join("2024", ".hidden", "skipped.md"),
+	}
+	want := []string{
+		filepath.Join("2024", "03", "05", "nested.md"),
+		filepath.Join("2024", "notes.txt"),
+		"top.md",
+	}
+
+	stores := map[string]func(t *testing.T) (Store, string){
+		"file": func(t *testing.T) (Store, string) {
+			return FileStore{}, t.TempDir()
+		},
+		"memory": func(t *testing.T) (Store, string) {
+			store := NewMemoryStore()
+			store.Write("/elsewhere.md", []byte("outside the journal"))
+			return store, "/journal"
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store, dir := newStore(t)
+			for _, file := range files {
+				if err := store.Write(filepath.Join(dir, file), []byte("text")); err != nil {
+					t.Fatalf("Write %s: %v", file, err)
+				}
+			}
+
+			got, err := store.Walk(dir)
+			if err != nil {
+				t.Fatalf("Walk: %v", err)
+			}
+			if strings.Join(got, " ") != strings.Join(want, " ") {
+				t.Errorf("Walk = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package journal
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// WordCount is a word and the number of times it appears
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// DefaultStopwords are common English words left out of theme summaries
+var DefaultStopwords = makeStopwords(`a about above after again against all am an and any are as at be
+because been before being below between both but by can could did do does doing down during each few
+for from further had has have having he her here hers herself him himself his how i if in into is it
+its itself just like me more most my myself no nor not now of off on once only or other our ours
+ourselves out over own really same she should so some such than that the their theirs them themselves
+then there these they this those through to too under until up very was we were what when where which
+while who whom why will with would you your yours yourself yourselves i'm i've i'd i'll it's don't
+didn't can't that's there's going get got know think feel want one also much even still well`)
+
+// makeStopwords builds a stopword set from a whitespace-separated list
+func makeStopwords(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(list) {
+		set[w] = true
+	}
+	return set
+}
+
+// TopWords returns the n most frequent words across the entries, ignoring
+// stopwords, frontmatter, code blocks, and markdown syntax. Ties are broken
+// alphabetically. Entries whose content can't be read are left out.
+func TopWords(entries []*JournalEntry, n int, stopwords map[string]bool) []WordCount {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		if entry.LoadContent() != nil {
+			continue
+		}
+		for _, word := range tokenize(entry.Content) {
+			if !stopwords[word] {
+				counts[word]++
+			}
+		}
+	}
+
+	ranked := make([]WordCount, 0, len(counts))
+	for word, count := range counts {
+		ranked = append(ranked, WordCount{Word: word, Count: count})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return ranked[i].Word < ranked[j].Word
+	})
+
+	if n >= 0 && len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// tokenize lowercases prose and splits it into words, dropping frontmatter,
+// fenced code blocks, and markdown punctuation. Apostrophes inside words
+// are kept so contractions stay whole.
+func tokenize(content string) []string {
+	_, body, _ := splitFrontmatter(content)
+
+	var words []string
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		fields := strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+		})
+		for _, f := range fields {
+			f = strings.Trim(strings.ReplaceAll(f, "’", "'"), "'")
+			if f != "" && strings.IndexFunc(f, unicode.IsLetter) >= 0 {
+				words = append(words, f)
+			}
+		}
+	}
+	return words
+}
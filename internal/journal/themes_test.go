@@ -0,0 +1,83 @@
+package journal
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTopWords(t *testing.T) {
+	entry := func(content string) *JournalEntry { return &JournalEntry{Content: content} }
+	tests := []struct {
+		name      string
+		entries   []*JournalEntry
+		n         int
+		stopwords map[string]bool
+		want      []WordCount
+	}{
+		{
+			name:      "stopwords left out",
+			entries:   []*JournalEntry{entry("I think the garden is the best part of the day")},
+			n:         10,
+			stopwords: DefaultStopwords,
+			want:      []WordCount{{"best", 1}, {"day", 1}, {"garden", 1}, {"part", 1}},
+		},
+		{
+			name:      "no stopwords",
+			entries:   []*JournalEntry{entry("the cat and the hat")},
+			n:         2,
+			stopwords: nil,
+			want:      []WordCount{{"the", 2}, {"and", 1}},
+		},
+		{
+			name:      "case folded across entries",
+			entries:   []*JournalEntry{entry("Garden GARDEN"), entry("garden rain"), entry("Rain")},
+			n:         5,
+			stopwords: DefaultStopwords,
+			want:      []WordCount{{"garden", 3}, {"rain", 2}},
+		},
+		{
+			name:      "ties alphabetical",
+			entries:   []*JournalEntry{entry("zebra apple mango apple zebra mango")},
+			n:         2,
+			stopwords: DefaultStopwords,
+			want:      []WordCount{{"apple", 2}, {"mango", 2}},
+		},
+		{
+			name:      "markdown, code and frontmatter skipped",
+			entries:   []*JournalEntry{entry("---\ntitle: secret\n---\n# **Walk** _walk_\n```\ncode code\n```\n[[walk]] 42")},
+			n:         5,
+			stopwords: DefaultStopwords,
+			want:      []WordCount{{"walk", 3}},
+		},
+		{
+			name:      "contractions kept whole",
+			entries:   []*JournalEntry{entry("Won’t won't 'quoted'")},
+			n:         5,
+			stopwords: DefaultStopwords,
+			want:      []WordCount{{"won't", 2}, {"quoted", 1}},
+		},
+		{
+			name:      "negative n keeps all",
+			entries:   []*JournalEntry{entry("one two three")},
+			n:         -1,
+			stopwords: nil,
+			want:      []WordCount{{"one", 1}, {"three", 1}, {"two", 1}},
+		},
+		{
+			name:      "unreadable entries skipped",
+			entries:   []*JournalEntry{entry("kept"), {loadContent: func() (string, error) { return "", errors.New("gone") }}},
+			n:         5,
+			stopwords: nil,
+			want:      []WordCount{{"kept", 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TopWords(tt.entries, tt.n, tt.stopwords); !slices.Equal(got, tt.want) {
+				t.Errorf("TopWords = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package journal
+
+import "strings"
+
+// maxBlankLines is how many blank lines in a row Tidy keeps
+const maxBlankLines = 2
+
+// Tidy normalizes whitespace without touching the prose: it strips trailing
+// whitespace from lines, collapses runs of more than two blank lines to two,
+// and ends the text with exactly one newline. Fenced code blocks are left
+// exactly as written.
+func Tidy(content string) string {
+	var (
+		out   []string
+		fence string // Marker of the open code fence, if any
+		blank int    // Blank lines in a row so far
+	)
+	for _, line := range strings.Split(content, "\n") {
+		if fence != "" {
+			out = append(out, line)
+			if IsFenceClose(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank++
+			if blank > maxBlankLines {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		fence = FenceMarker(line)
+		out = append(out, line)
+	}
+
+	tidy := strings.TrimRight(strings.Join(out, "\n"), "\n")
+	if strings.TrimSpace(tidy) == "" {
+		return ""
+	}
+	return tidy + "\n"
+}
+
+// FenceMarker returns the backticks or tildes that open a fenced code block
+// on line, or "" if line doesn't open one
+func FenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return "" // Indented code, not a fence
+	}
+	for _, c := range []string{"`", "~"} {
+		n := len(trimmed) - len(strings.TrimLeft(trimmed, c))
+		if n >= 3 {
+			return trimmed[:n]
+		}
+	}
+	return ""
+}
+
+// IsFenceClose reports whether line closes a code block opened with fence
+func IsFenceClose(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// SavedContent returns content as SaveEntry writes it: tidied when
+// journal.format_on_save is set, leaving the header alone so it still
+// matches, and unchanged otherwise
+func (m *Manager) SavedContent(header, content string) string {
+	if !m.config.Journal.FormatOnSave {
+		return content
+	}
+	if header != "" && strings.HasPrefix(content, header) {
+		return header + Tidy(content[len(header):])
+	}
+	return Tidy(content)
+}
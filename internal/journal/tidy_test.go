@@ -0,0 +1,97 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestTidy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "already tidy", content: "one\n\ntwo\n", want: "one\n\ntwo\n"},
+		{name: "trailing spaces and tabs", content: "one  \ntwo\t \n", want: "one\ntwo\n"},
+		{name: "keeps leading indent", content: "  - item\n\tcode\n", want: "  - item\n\tcode\n"},
+		{name: "keeps two blank lines", content: "one\n\n\ntwo\n", want: "one\n\n\ntwo\n"},
+		{name: "collapses more blank lines", content: "one\n\n\n\n\n\ntwo\n", want: "one\n\n\ntwo\n"},
+		{name: "whitespace-only lines are blank", content: "one\n \n\t\n  \n\ntwo\n", want: "one\n\n\ntwo\n"},
+		{name: "adds the final newline", content: "one", want: "one\n"},
+		{name: "drops extra final newlines", content: "one\n\n\n", want: "one\n"},
+		{name: "leading blank lines collapse", content: "\n\n\n\none\n", want: "\n\none\n"},
+		{name: "empty", content: "", want: ""},
+		{name: "only whitespace", content: " \n\n\t\n", want: ""},
+		{name: "prose untouched", content: "Hello,   world.  Two  spaces.\n", want: "Hello,   world.  Two  spaces.\n"},
+		{
+			name:    "code fence kept as written",
+			content: "text  \n```go\nx := 1   \n\n\n\n\ny := 2\t\n```\nafter  \n",
+			want:    "text\n```go\nx := 1   \n\n\n\n\ny := 2\t\n```\nafter\n",
+		},
+		{
+			name:    "tilde fence",
+			content: "~~~\n  a  \n~~~\nb  \n",
+			want:    "~~~\n  a  \n~~~\nb\n",
+		},
+		{
+			name:    "longer fence needs a long enough close",
+			content: "````\n```\nin  \n````\nout  \n",
+			want:    "````\n```\nin  \n````\nout\n",
+		},
+		{
+			name:    "indented code is not a fence",
+			content: "    ```\nafter  \n",
+			want:    "    ```\nafter\n",
+		},
+		{
+			name:    "unclosed fence runs to the end",
+			content: "```\nin  \n\n\n\n",
+			want:    "```\nin  \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tidy(tt.content); got != tt.want {
+				t.Errorf("Tidy(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOnSave(t *testing.T) {
+	for _, formatOnSave := range []bool{true, false} {
+		m, _ := newTestManager(t, func(cfg *config.Config) {
+			cfg.Journal.EntryHeaderTemplate = "# {{.Date}}  \n\n"
+			cfg.Journal.FormatOnSave = formatOnSave
+		})
+		entry, _, err := m.CreateEntryAt(time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local))
+		if err != nil {
+			t.Fatalf("CreateEntryAt: %v", err)
+		}
+
+		body := "words  \n\n\n\n\nmore\t\n\n"
+		entry.Content = entry.Header + body
+		if err := m.SaveEntry(entry); err != nil {
+			t.Fatalf("SaveEntry: %v", err)
+		}
+		saved, err := m.ReadEntry(entry.FilePath)
+		if err != nil {
+			t.Fatalf("ReadEntry: %v", err)
+		}
+
+		want := entry.Header + body
+		if formatOnSave {
+			// The header is kept as rendered, trailing spaces and all
+			want = entry.Header + "words\n\n\nmore\n"
+		}
+		if saved.Content != want {
+			t.Errorf("format_on_save %v saved %q, want %q", formatOnSave, saved.Content, want)
+		}
+		if got := m.SavedContent(entry.Header, entry.Content); got != want {
+			t.Errorf("format_on_save %v: SavedContent = %q, want %q", formatOnSave, got, want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// TrashDir is the hidden directory, in the journal directory, that removed
+// entries are moved to so they can still be restored
+const TrashDir = ".trash"
+
+// TrashEntry moves an entry and its sidecar files into the trash
+// directory, renaming them if the trash already has an entry by that name,
+// and returns where the entry went. In a dry run nothing is moved.
+func (m *Manager) TrashEntry(filePath string) (string, error) {
+	if err := m.checkInStorage(filePath); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(m.config.Journal.StorageDir, TrashDir)
+	name, err := m.freeName(dir, filepath.Base(filePath))
+	if err != nil {
+		return "", err
+	}
+	trashPath := filepath.Join(dir, name)
+
+	if m.dryRun {
+		m.logger.Info("Dry run: would move journal entry to trash",
+			zap.String("file", filePath),
+			zap.String("trash", trashPath))
+		return trashPath, nil
+	}
+
+	if err := m.moveEntry(filePath, trashPath); err != nil {
+		return "", fmt.Errorf("failed to move journal entry to trash: %w", err)
+	}
+	return trashPath, nil
+}
+
+// RestoreEntry moves an entry and its sidecar files out of the trash and
+// back to where the folder layout files it, renaming it if the journal
+// already has an entry by that name, and returns where it went. In a dry
+// run nothing is moved.
+func (m *Manager) RestoreEntry(trashPath string) (string, error) {
+	if err := m.checkInStorage(trashPath); err != nil {
+		return "", err
+	}
+	if filepath.Dir(trashPath) != filepath.Join(m.config.Journal.StorageDir, TrashDir) {
+		return "", fmt.Errorf("%w: %s is not in the trash", ErrNotFound, filepath.Base(trashPath))
+	}
+
+	data, modTime, err := m.store.Read(trashPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read trashed entry: %w", notFound(err))
+	}
+
+	// File it under the date it was started, as when it was created
+	createdAt := modTime
+	if fields, _, err := parseFrontmatter(string(data)); err == nil {
+		if t, ok := frontmatterTime(fields, "created_at"); ok {
+			createdAt = t
+		}
+	}
+
+	m.createMu.Lock()
+	defer m.createMu.Unlock()
+
+	name, err := m.freeEntryName(filepath.Base(trashPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to name restored entry: %w", err)
+	}
+	filePath := filepath.Join(EntryDir(m.config.Journal.StorageDir, m.config.Journal.FolderLayout, createdAt), name)
+
+	if m.dryRun {
+		m.logger.Info("Dry run: would restore journal entry from trash",
+			zap.String("trash", trashPath),
+			zap.String("file", filePath))
+		return filePath, nil
+	}
+
+	if err := m.moveEntry(trashPath, filePath); err != nil {
+		return "", fmt.Errorf("failed to restore journal entry: %w", err)
+	}
+	return filePath, nil
+}
+
+// moveEntry copies an entry and whichever sidecar files it has to newPath,
+// renaming the sidecars to match, then deletes the originals
+func (m *Manager) moveEntry(oldPath, newPath string) error {
+	data, _, err := m.store.Read(oldPath)
+	if err != nil {
+		return notFound(err)
+	}
+	if err := m.store.Write(newPath, data); err != nil {
+		return err
+	}
+
+	// Sidecars follow the entry's new name so they stay paired with it
+	oldStem := strings.TrimSuffix(oldPath, ".md")
+	newStem := strings.TrimSuffix(newPath, ".md")
+	for _, suffix := range sidecarSuffixes {
+		sidecar := oldStem + suffix
+		data, _, err := m.store.Read(sidecar)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err == nil {
+			err = m.store.Write(newStem+suffix, data)
+		}
+		if err != nil {
+			m.logger.Warn("Failed to move sidecar file",
+				zap.String("file", sidecar),
+				zap.Error(err))
+		}
+	}
+
+	return m.DeleteEntry(oldPath)
+}
@@ -0,0 +1,163 @@
+package journal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestRestoreEntry(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	entry := createWithHistory(t, m)
+
+	trashPath, err := m.TrashEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+
+	restored, err := m.RestoreEntry(trashPath)
+	if err != nil {
+		t.Fatalf("RestoreEntry: %v", err)
+	}
+	if restored != entry.FilePath {
+		t.Errorf("restored to %q, want back at %q", restored, entry.FilePath)
+	}
+	if store.has(trashPath) || store.has(historyPath(trashPath)) {
+		t.Errorf("entry or its history is still in the trash")
+	}
+
+	got, err := m.ReadEntry(restored)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if got.Content != entry.Content || len(got.WordCountHistory) != 1 {
+		t.Errorf("restored entry = %q with %d samples, want %q with its history", got.Content, len(got.WordCountHistory), entry.Content)
+	}
+	if !got.CreatedAt.Equal(entry.CreatedAt.Truncate(time.Second)) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, entry.CreatedAt)
+	}
+}
+
+func TestRestoreEntryNameCollisions(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	at := time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local)
+
+	// Two entries of the same name trashed one after the other
+	first, _, err := m.CreateEntryAt(at)
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	firstTrash, err := m.TrashEntry(first.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+	second, _, err := m.CreateEntryAt(at)
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	if second.FilePath != first.FilePath {
+		t.Fatalf("second entry at %q, want the freed name %q", second.FilePath, first.FilePath)
+	}
+	secondTrash, err := m.TrashEntry(second.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+	if want := filepath.Join(testStorageDir, TrashDir, "2024-03-05T09:30-morning-pages-1.md"); secondTrash != want {
+		t.Errorf("second trash path = %q, want %q", secondTrash, want)
+	}
+
+	// A live entry now holds the name the first one had
+	live, _, err := m.CreateEntryAt(at)
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+
+	restored, err := m.RestoreEntry(firstTrash)
+	if err != nil {
+		t.Fatalf("RestoreEntry: %v", err)
+	}
+	if restored == live.FilePath {
+		t.Fatalf("restore replaced the live entry at %q", restored)
+	}
+	if want := filepath.Join(testStorageDir, "2024-03-05T09:30-morning-pages-1.md"); restored != want {
+		t.Errorf("restored to %q, want %q", restored, want)
+	}
+
+	// The second keeps its numbered trash name unless that is taken too
+	restoredSecond, err := m.RestoreEntry(secondTrash)
+	if err != nil {
+		t.Fatalf("RestoreEntry: %v", err)
+	}
+	if want := filepath.Join(testStorageDir, "2024-03-05T09:30-morning-pages-1-1.md"); restoredSecond != want {
+		t.Errorf("second restored to %q, want %q", restoredSecond, want)
+	}
+
+	for _, path := range []string{live.FilePath, restored, restoredSecond} {
+		if !store.has(path) {
+			t.Errorf("%s is missing", path)
+		}
+	}
+	entries, err := m.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("got %d entries, want 3", len(entries))
+	}
+}
+
+func TestRestoreEntryFolderLayout(t *testing.T) {
+	m, _ := newTestManager(t, func(cfg *config.Config) {
+		cfg.Journal.FolderLayout = config.FolderLayoutByMonth
+	})
+	entry, _, err := m.CreateEntryAt(time.Date(2023, 11, 20, 7, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	trashPath, err := m.TrashEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+	if filepath.Dir(trashPath) != filepath.Join(testStorageDir, TrashDir) {
+		t.Errorf("trash path = %q, want it flat in the trash", trashPath)
+	}
+
+	restored, err := m.RestoreEntry(trashPath)
+	if err != nil {
+		t.Fatalf("RestoreEntry: %v", err)
+	}
+	if want := filepath.Join(testStorageDir, "2023", "11", entry.FileName); restored != want {
+		t.Errorf("restored to %q, want %q", restored, want)
+	}
+}
+
+func TestRestoreEntryRejects(t *testing.T) {
+	m, store := newTestManager(t, nil)
+	entry := createWithHistory(t, m)
+
+	if _, err := m.RestoreEntry(entry.FilePath); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring a live entry: err = %v, want ErrNotFound", err)
+	}
+	if _, err := m.RestoreEntry(filepath.Join(testStorageDir, TrashDir, "missing.md")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring a missing entry: err = %v, want ErrNotFound", err)
+	}
+	if _, err := m.RestoreEntry("/elsewhere/.trash/x.md"); !errors.Is(err, ErrOutsideStorage) {
+		t.Errorf("restoring outside the journal: err = %v, want ErrOutsideStorage", err)
+	}
+
+	trashPath, err := m.TrashEntry(entry.FilePath)
+	if err != nil {
+		t.Fatalf("TrashEntry: %v", err)
+	}
+	dryRun, _ := newTestManager(t, nil, WithStore(store), WithDryRun(true))
+	restored, err := dryRun.RestoreEntry(trashPath)
+	if err != nil {
+		t.Fatalf("dry run RestoreEntry: %v", err)
+	}
+	if restored != entry.FilePath || store.has(restored) || !store.has(trashPath) {
+		t.Errorf("dry run restore to %q moved the entry", restored)
+	}
+}
@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		basic   int
+		unicode int
+		cjk     int
+	}{
+		{name: "empty", text: "", basic: 0, unicode: 0, cjk: 0},
+		{name: "latin", text: "a  b\tc\n", basic: 3, unicode: 3, cjk: 3},
+		{name: "contractions and hyphens", text: "don't stop well-known", basic: 3, unicode: 3, cjk: 3},
+		{name: "standalone punctuation", text: "hello — world !!", basic: 4, unicode: 2, cjk: 2},
+		{name: "zero width space", text: "a\u200bb", basic: 1, unicode: 2, cjk: 1},
+		{name: "non-breaking space", text: "a\u00a0b c", basic: 3, unicode: 3, cjk: 3},
+		{name: "narrow non-breaking space", text: "10\u202f000 mots", basic: 3, unicode: 3, cjk: 3},
+		{name: "word joiner", text: "a\u2060b", basic: 1, unicode: 2, cjk: 1},
+		{name: "accented", text: "café résumé naïve Ærøskøbing", basic: 4, unicode: 4, cjk: 4},
+		{name: "accented with punctuation", text: "«Déjà vu», dit-elle…", basic: 3, unicode: 3, cjk: 3},
+		{name: "quoted letter", text: "rock 'n' roll", basic: 3, unicode: 3, cjk: 3},
+		{name: "numbers", text: "123 4,5", basic: 2, unicode: 3, cjk: 2},
+		{name: "combining marks", text: "nai\u0308ve cafe\u0301", basic: 2, unicode: 2, cjk: 2},
+		{name: "chinese", text: "我爱写作", basic: 1, unicode: 1, cjk: 4},
+		{name: "japanese", text: "今日は晴れ。", basic: 1, unicode: 1, cjk: 5},
+		{name: "katakana", text: "カタカナ", basic: 1, unicode: 1, cjk: 4},
+		{name: "mixed script", text: "我爱Go语言", basic: 1, unicode: 1, cjk: 5},
+		{name: "mixed with spaces", text: "私は Go と Rust が好きです。", basic: 5, unicode: 5, cjk: 10},
+		{name: "hangul counts by spaces", text: "안녕 하세요", basic: 2, unicode: 2, cjk: 2},
+		{name: "emoji alone", text: "🎉🎉", basic: 1, unicode: 0, cjk: 0},
+		{name: "emoji between words", text: "I love 🍜 ramen", basic: 4, unicode: 3, cjk: 3},
+		{name: "emoji with cjk", text: "ラーメン🍜美味しい", basic: 1, unicode: 2, cjk: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountWords(tt.text); got != tt.basic {
+				t.Errorf("CountWords(%q) = %d, want %d", tt.text, got, tt.basic)
+			}
+			if got := CountWordsUnicode(tt.text); got != tt.unicode {
+				t.Errorf("CountWordsUnicode(%q) = %d, want %d", tt.text, got, tt.unicode)
+			}
+			if got := CountWordsCJK(tt.text); got != tt.cjk {
+				t.Errorf("CountWordsCJK(%q) = %d, want %d", tt.text, got, tt.cjk)
+			}
+		})
+	}
+}
+
+func TestWordCounter(t *testing.T) {
+	text := "我爱 Go — 123"
+	tests := []struct {
+		name    string
+		unicode bool
+		cjk     bool
+		want    int
+	}{
+		{name: "basic", want: 4},
+		{name: "unicode", unicode: true, want: 3},
+		{name: "cjk", cjk: true, want: 4},
+		{name: "cjk wins", unicode: true, cjk: true, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Journal.UnicodeCounting = tt.unicode
+			cfg.Journal.CJKCounting = tt.cjk
+			if got := WordCounter(cfg)(text); got != tt.want {
+				t.Errorf("count = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package llm
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultCacheSize is how many responses the cache keeps before evicting
+// the least recently used one.
+const defaultCacheSize = 128
+
+// cacheKey identifies a response by model and prompt. The prompt is hashed
+// so long journal text isn't kept around as a map key.
+type cacheKey struct {
+	model  string
+	prompt [sha256.Size]byte
+}
+
+// cacheEntry is a cached response and when it stops being valid
+type cacheEntry struct {
+	key      cacheKey
+	response string
+	expires  time.Time
+}
+
+// cachingClient wraps a Client with an in-memory LRU cache of responses to
+// identical prompts. Errors are never cached.
+type cachingClient struct {
+	client Client
+	model  string
+	ttl    time.Duration
+	size   int
+	logger *zap.Logger
+	now    func() time.Time // Replaceable clock
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	order   *list.List // Most recently used at the front
+}
+
+// newCachingClient wraps client with a cache holding up to size responses
+// for ttl each
+func newCachingClient(client Client, model string, ttl time.Duration, size int, logger *zap.Logger) *cachingClient {
+	return &cachingClient{
+		client:  client,
+		model:   model,
+		ttl:     ttl,
+		size:    size,
+		logger:  logger,
+		now:     time.Now,
+		entries: make(map[cacheKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Generate returns a cached response for the prompt if one is still valid,
+// and otherwise asks the wrapped client and caches its answer
+func (c *cachingClient) Generate(ctx context.Context, prompt string) (string, error) {
+	key := cacheKey{model: c.model, prompt: sha256.Sum256([]byte(prompt))}
+
+	if response, ok := c.get(key); ok {
+		c.logger.Debug("LLM cache hit", zap.String("model", c.model))
+		return response, nil
+	}
+
+	response, err := c.client.Generate(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	c.put(key, response)
+	return response, nil
+}
+
+// Stream replays a cached response as a single chunk if one is still
+// valid. Otherwise it streams from the wrapped client and caches the
+// response once it completes without error.
+func (c *cachingClient) Stream(ctx context.Context, prompt string) (<-chan Chunk, error) {
+	key := cacheKey{model: c.model, prompt: sha256.Sum256([]byte(prompt))}
+
+	if response, ok := c.get(key); ok {
+		c.logger.Debug("LLM cache hit", zap.String("model", c.model))
+		return singleChunk(response), nil
+	}
+
+	in, err := c.client.Stream(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan Chunk)
+	go func() {
+		defer close(out)
+		var b strings.Builder
+		failed := false
+		for chunk := range in {
+			failed = failed || chunk.Err != nil
+			b.WriteString(chunk.Text)
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// The producer stops on the same context and closes in
+				failed = true
+			}
+		}
+		if !failed {
+			c.put(key, b.String())
+		}
+	}()
+	return out, nil
+}
+
+// Embed passes through to the wrapped client; callers cache embeddings
+// alongside the entries they belong to
+func (c *cachingClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	return c.client.Embed(ctx, text)
+}
+
+// Delay reports the wrapped client's rate limit delay, if it has one.
+// Cache hits never wait, but there's no telling in advance which requests
+// will hit.
+func (c *cachingClient) Delay() time.Duration {
+	if t, ok := c.client.(Throttler); ok {
+		return t.Delay()
+	}
+	return 0
+}
+
+// get returns the cached response for key unless it is missing or expired
+func (c *cachingClient) get(key cacheKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return "", false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.response, true
+}
+
+// put caches a response, evicting the least recently used entry when full
+func (c *cachingClient) put(key cacheKey, response string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, response: response, expires: c.now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,196 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// countingClient answers each prompt with a numbered reply, so a test can
+// tell a cached response from a fresh one
+type countingClient struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (c *countingClient) Generate(ctx context.Context, prompt string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.err != nil {
+		return "", c.err
+	}
+	return fmt.Sprintf("%s #%d", prompt, c.calls), nil
+}
+
+func (c *countingClient) Stream(ctx context.Context, prompt string) (<-chan Chunk, error) {
+	text, err := c.Generate(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return singleChunk(text), nil
+}
+
+func (c *countingClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	return []float32{1}, nil
+}
+
+// fakeClock is a settable time source
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestCache returns a cache over a counting client with a fake clock
+func newTestCache(ttl time.Duration, size int) (*cachingClient, *countingClient, *fakeClock) {
+	inner := &countingClient{}
+	clock := newFakeClock()
+	cache := newCachingClient(inner, "test-model", ttl, size, zap.NewNop())
+	cache.now = clock.Now
+	return cache, inner, clock
+}
+
+func generate(t *testing.T, c Client, prompt string) string {
+	t.Helper()
+	got, err := c.Generate(context.Background(), prompt)
+	if err != nil {
+		t.Fatalf("Generate(%q) failed: %v", prompt, err)
+	}
+	return got
+}
+
+func TestCacheHit(t *testing.T) {
+	cache, inner, _ := newTestCache(time.Minute, 4)
+
+	first := generate(t, cache, "hello")
+	if second := generate(t, cache, "hello"); second != first {
+		t.Errorf("second response = %q, want cached %q", second, first)
+	}
+	if inner.calls != 1 {
+		t.Errorf("client called %d times, want 1", inner.calls)
+	}
+
+	generate(t, cache, "other")
+	if inner.calls != 2 {
+		t.Errorf("a different prompt should miss; client called %d times, want 2", inner.calls)
+	}
+
+	ch, err := cache.Stream(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	if got, _ := collectStream(ch); got != first {
+		t.Errorf("streamed %q, want cached %q", got, first)
+	}
+	if inner.calls != 2 {
+		t.Errorf("a cached stream should not reach the client; called %d times", inner.calls)
+	}
+}
+
+func TestCacheStreamFillsCache(t *testing.T) {
+	cache, inner, _ := newTestCache(time.Minute, 4)
+
+	ch, err := cache.Stream(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	streamed, err := collectStream(ch)
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+
+	// The response is cached before the channel closes
+	if got := generate(t, cache, "hello"); got != streamed {
+		t.Errorf("Generate after Stream = %q, want %q", got, streamed)
+	}
+	if inner.calls != 1 {
+		t.Errorf("client called %d times, want 1", inner.calls)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	cache, inner, clock := newTestCache(time.Minute, 4)
+
+	first := generate(t, cache, "hello")
+
+	clock.Advance(time.Minute - time.Second)
+	if got := generate(t, cache, "hello"); got != first {
+		t.Errorf("before expiry got %q, want cached %q", got, first)
+	}
+
+	// Hits don't extend an entry's life
+	clock.Advance(time.Second)
+	if got := generate(t, cache, "hello"); got == first {
+		t.Errorf("at expiry got the cached %q", got)
+	}
+	if inner.calls != 2 {
+		t.Errorf("client called %d times, want 2", inner.calls)
+	}
+	if cache.order.Len() != 1 || len(cache.entries) != 1 {
+		t.Errorf("expired entry not replaced: %d in list, %d in map", cache.order.Len(), len(cache.entries))
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache, inner, _ := newTestCache(time.Hour, 2)
+
+	a := generate(t, cache, "a")
+	generate(t, cache, "b")
+	generate(t, cache, "a") // a is now the most recently used
+	generate(t, cache, "c") // evicts b
+	if inner.calls != 3 {
+		t.Fatalf("client called %d times, want 3", inner.calls)
+	}
+
+	if got := generate(t, cache, "a"); got != a {
+		t.Errorf("a was evicted: got %q, want %q", got, a)
+	}
+	if inner.calls != 3 {
+		t.Errorf("a should still be cached; client called %d times", inner.calls)
+	}
+
+	generate(t, cache, "b")
+	if inner.calls != 4 {
+		t.Errorf("b should have been evicted; client called %d times, want 4", inner.calls)
+	}
+	if cache.order.Len() != 2 || len(cache.entries) != 2 {
+		t.Errorf("cache holds %d in list, %d in map, want 2", cache.order.Len(), len(cache.entries))
+	}
+}
+
+func TestCacheSkipsErrors(t *testing.T) {
+	cache, inner, _ := newTestCache(time.Minute, 4)
+	inner.err = errors.New("provider down")
+
+	if _, err := cache.Generate(context.Background(), "hello"); err == nil {
+		t.Fatal("expected the client's error")
+	}
+
+	inner.err = nil
+	generate(t, cache, "hello")
+	if inner.calls != 2 {
+		t.Errorf("error should not be cached; client called %d times, want 2", inner.calls)
+	}
+}
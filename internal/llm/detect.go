@@ -0,0 +1,97 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+// defaultOllamaEndpoint is where a local Ollama serves generations
+const defaultOllamaEndpoint = "http://localhost:11434/api/generate"
+
+// probeTimeout bounds the check for a running Ollama at startup
+const probeTimeout = 500 * time.Millisecond
+
+// isAuto reports whether the provider should be picked at startup
+func isAuto(provider string) bool {
+	return provider == "" || provider == "auto"
+}
+
+// detectProvider picks Ollama when it answers at the configured (or
+// default) endpoint, and OpenRouter when an API key is set. reachable
+// reports whether a server answers at a base URL.
+func detectProvider(cfg *config.Config, reachable func(baseURL string) bool) (string, error) {
+	base := ollamaBaseURL(cfg.LLM.Endpoint)
+	if reachable(base) {
+		return "ollama", nil
+	}
+	if cfg.LLM.APIKey != "" {
+		return "openrouter", nil
+	}
+	return "", fmt.Errorf("no LLM provider found: Ollama is not running at %s and llm.api_key is not set", base)
+}
+
+// ollamaBaseURL returns the scheme and host of an Ollama generate
+// endpoint, or of the default one when endpoint isn't an Ollama endpoint
+func ollamaBaseURL(endpoint string) string {
+	if !strings.HasSuffix(endpoint, "/api/generate") {
+		endpoint = defaultOllamaEndpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		u, _ = url.Parse(defaultOllamaEndpoint)
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// ollamaReachable reports whether an Ollama server answers at baseURL
+func ollamaReachable(baseURL string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/tags", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// resolveProvider returns cfg with an "auto" provider replaced by the
+// detected one, adjusting the endpoint to suit it. Other configs are
+// returned as is.
+func resolveProvider(cfg *config.Config, logger *zap.Logger, reachable func(baseURL string) bool) (*config.Config, error) {
+	if !isAuto(cfg.LLM.Provider) {
+		return cfg, nil
+	}
+
+	provider, err := detectProvider(cfg, reachable)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *cfg
+	resolved.LLM.Provider = provider
+	isOllamaEndpoint := strings.HasSuffix(cfg.LLM.Endpoint, "/api/generate")
+	switch {
+	case provider == "ollama" && !isOllamaEndpoint:
+		resolved.LLM.Endpoint = defaultOllamaEndpoint
+	case provider == "openrouter" && isOllamaEndpoint:
+		resolved.LLM.Endpoint = "" // Falls back to the OpenRouter default
+	}
+
+	logger.Info("Auto-detected LLM provider",
+		zap.String("provider", provider),
+		zap.String("endpoint", resolved.LLM.Endpoint))
+	return &resolved, nil
+}
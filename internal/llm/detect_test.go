@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		ollamaUp  bool
+		apiKey    string
+		want      string
+		wantProbe string
+	}{
+		{name: "ollama up, no key", ollamaUp: true, want: "ollama", wantProbe: "http://localhost:11434"},
+		{name: "ollama up, key set", ollamaUp: true, apiKey: "sk", want: "ollama", wantProbe: "http://localhost:11434"},
+		{name: "ollama down, key set", apiKey: "sk", want: "openrouter", wantProbe: "http://localhost:11434"},
+		{name: "ollama down, no key", wantProbe: "http://localhost:11434"},
+		{name: "configured ollama probed", endpoint: "http://box:8080/api/generate", ollamaUp: true, want: "ollama", wantProbe: "http://box:8080"},
+		{name: "openrouter endpoint ignored", endpoint: "https://openrouter.ai/api/v1/chat/completions", apiKey: "sk", want: "openrouter", wantProbe: "http://localhost:11434"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.LLM.Endpoint = tt.endpoint
+			cfg.LLM.APIKey = tt.apiKey
+
+			var probed string
+			got, err := detectProvider(cfg, func(baseURL string) bool {
+				probed = baseURL
+				return tt.ollamaUp
+			})
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+			} else if err != nil || got != tt.want {
+				t.Errorf("detectProvider = %q, %v; want %q", got, err, tt.want)
+			}
+			if probed != tt.wantProbe {
+				t.Errorf("probed %q, want %q", probed, tt.wantProbe)
+			}
+		})
+	}
+}
+
+func TestOllamaReachable(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   bool
+	}{
+		{name: "ollama answers", status: http.StatusOK, want: true},
+		{name: "something else answers", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/tags" {
+					http.NotFound(w, r)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			if got := ollamaReachable(srv.URL); got != tt.want {
+				t.Errorf("ollamaReachable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("nothing listening", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+		if ollamaReachable(srv.URL) {
+			t.Error("closed server reported reachable")
+		}
+	})
+}
+
+func TestResolveProviderLogs(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	cfg := config.DefaultConfig()
+	cfg.LLM.Provider = "auto"
+	cfg.LLM.APIKey = "sk"
+
+	if _, err := resolveProvider(cfg, zap.New(core), func(string) bool { return false }); err != nil {
+		t.Fatalf("resolveProvider failed: %v", err)
+	}
+	entries := logs.FilterMessage("Auto-detected LLM provider").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d detections, want 1", len(entries))
+	}
+	if got := entries[0].ContextMap()["provider"]; got != "openrouter" {
+		t.Errorf("logged provider %v, want openrouter", got)
+	}
+}
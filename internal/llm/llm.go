@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+// requestTimeout bounds a single HTTP round trip to the provider.
+const requestTimeout = 2 * time.Minute
+
+// Client is the common interface for LLM providers
+type Client interface {
+	// Generate sends a prompt to the model and returns the full response text
+	Generate(ctx context.Context, prompt string) (string, error)
+	// Stream sends a prompt to the model and returns its response as it is
+	// produced. The channel is closed once the response is complete.
+	Stream(ctx context.Context, prompt string) (<-chan Chunk, error)
+	// Embed returns the embedding vector for text
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// EmbeddingModel returns the model used for embeddings, falling back to
+// the generation model
+func EmbeddingModel(cfg *config.Config) string {
+	if cfg.LLM.EmbeddingModel != "" {
+		return cfg.LLM.EmbeddingModel
+	}
+	return cfg.LLM.ModelName
+}
+
+// NewClient creates a client for the provider selected in the configuration,
+// throttled to LLM.RequestsPerMinute and caching responses to identical
+// prompts when LLM.CacheTTL is set. An
+// "auto" provider uses a local Ollama if one is running and OpenRouter if
+// an API key is set.
+func NewClient(cfg *config.Config, logger *zap.Logger) (Client, error) {
+	cfg, err := resolveProvider(cfg, logger, ollamaReachable)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newProviderClient(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Throttle what reaches the provider; cache hits don't count
+	if cfg.LLM.RequestsPerMinute > 0 {
+		client = newRateLimitedClient(client, cfg.LLM.RequestsPerMinute, logger)
+	}
+
+	if cfg.LLM.CacheTTL > 0 {
+		ttl := time.Duration(cfg.LLM.CacheTTL) * time.Second
+		return newCachingClient(client, cfg.LLM.ModelName, ttl, defaultCacheSize, logger), nil
+	}
+	return client, nil
+}
+
+// newProviderClient creates the uncached client for the configured provider
+func newProviderClient(cfg *config.Config, logger *zap.Logger) (Client, error) {
+	httpClient := &http.Client{Timeout: requestTimeout}
+
+	switch cfg.LLM.Provider {
+	case "mock":
+		return &mockClient{}, nil
+	case "ollama":
+		return &ollamaClient{
+			httpClient:     httpClient,
+			endpoint:       cfg.LLM.Endpoint,
+			embedEndpoint:  embeddingEndpoint(cfg.LLM.EmbeddingEndpoint, cfg.LLM.Endpoint, "/api/generate", "/api/embeddings"),
+			model:          cfg.LLM.ModelName,
+			embeddingModel: EmbeddingModel(cfg),
+			maxTokens:      cfg.LLM.MaxTokens,
+			temp:           cfg.LLM.Temperature,
+			stream:         cfg.LLM.Stream,
+			logger:         logger,
+		}, nil
+	case "openrouter":
+		endpoint := cfg.LLM.Endpoint
+		if endpoint == "" {
+			endpoint = defaultOpenRouterEndpoint
+		}
+		return &openRouterClient{
+			httpClient:     httpClient,
+			endpoint:       endpoint,
+			embedEndpoint:  embeddingEndpoint(cfg.LLM.EmbeddingEndpoint, endpoint, "/chat/completions", "/embeddings"),
+			apiKey:         cfg.LLM.APIKey,
+			model:          cfg.LLM.ModelName,
+			embeddingModel: EmbeddingModel(cfg),
+			maxTokens:      cfg.LLM.MaxTokens,
+			temp:           cfg.LLM.Temperature,
+			stream:         cfg.LLM.Stream,
+			logger:         logger,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown LLM provider: %q", cfg.LLM.Provider)
+	}
+}
+
+// embeddingEndpoint returns the configured embeddings endpoint, or derives
+// one from the generation endpoint by swapping its path suffix
+func embeddingEndpoint(configured, endpoint, suffix, replacement string) string {
+	if configured != "" {
+		return configured
+	}
+	if base, ok := strings.CutSuffix(endpoint, suffix); ok {
+		return base + replacement
+	}
+	return endpoint
+}
@@ -0,0 +1,71 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+func TestEmbeddingEndpoint(t *testing.T) {
+	tests := []struct {
+		configured, endpoint, suffix, replacement string
+		want                                      string
+	}{
+		{"", "http://localhost:11434/api/generate", "/api/generate", "/api/embeddings", "http://localhost:11434/api/embeddings"},
+		{"http://embed/api", "http://localhost:11434/api/generate", "/api/generate", "/api/embeddings", "http://embed/api"},
+		{"", "https://openrouter.ai/api/v1/chat/completions", "/chat/completions", "/embeddings", "https://openrouter.ai/api/v1/embeddings"},
+		{"", "http://proxy/custom", "/chat/completions", "/embeddings", "http://proxy/custom"},
+	}
+
+	for _, tt := range tests {
+		if got := embeddingEndpoint(tt.configured, tt.endpoint, tt.suffix, tt.replacement); got != tt.want {
+			t.Errorf("embeddingEndpoint(%q, %q) = %q, want %q", tt.configured, tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestResolveProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		provider     string
+		endpoint     string
+		apiKey       string
+		ollamaUp     bool
+		wantProvider string
+		wantEndpoint string
+		wantErr      bool
+	}{
+		{name: "explicit provider kept", provider: "openrouter", endpoint: "http://x/api/generate", wantProvider: "openrouter", wantEndpoint: "http://x/api/generate"},
+		{name: "ollama running", provider: "auto", ollamaUp: true, wantProvider: "ollama", wantEndpoint: defaultOllamaEndpoint},
+		{name: "ollama endpoint kept", provider: "", endpoint: "http://box:11434/api/generate", ollamaUp: true, wantProvider: "ollama", wantEndpoint: "http://box:11434/api/generate"},
+		{name: "api key fallback", provider: "auto", endpoint: "http://localhost:11434/api/generate", apiKey: "sk", wantProvider: "openrouter", wantEndpoint: ""},
+		{name: "nothing found", provider: "auto", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.LLM.Provider = tt.provider
+			cfg.LLM.Endpoint = tt.endpoint
+			cfg.LLM.APIKey = tt.apiKey
+
+			got, err := resolveProvider(cfg, zap.NewNop(), func(string) bool { return tt.ollamaUp })
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got provider %q", got.LLM.Provider)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveProvider failed: %v", err)
+			}
+			if got.LLM.Provider != tt.wantProvider || got.LLM.Endpoint != tt.wantEndpoint {
+				t.Errorf("resolved %q at %q, want %q at %q", got.LLM.Provider, got.LLM.Endpoint, tt.wantProvider, tt.wantEndpoint)
+			}
+			if cfg.LLM.Provider != tt.provider {
+				t.Errorf("config was modified")
+			}
+		})
+	}
+}
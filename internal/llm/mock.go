@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// mockWordDelay paces the mock's streamed words like a slow model
+const mockWordDelay = 30 * time.Millisecond
+
+// mockEmbeddingSize is the length of the mock's embedding vectors
+const mockEmbeddingSize = 64
+
+// mockClient is an offline provider ("mock") that answers every prompt with
+// a canned reply, so the interface can be tried without a model
+type mockClient struct{}
+
+// Generate returns the canned reply for the prompt
+func (c *mockClient) Generate(ctx context.Context, prompt string) (string, error) {
+	return mockReply(prompt), nil
+}
+
+// Stream sends the canned reply a word at a time
+func (c *mockClient) Stream(ctx context.Context, prompt string) (<-chan Chunk, error) {
+	words := strings.SplitAfter(mockReply(prompt), " ")
+	ch := make(chan Chunk)
+	go func() {
+		defer close(ch)
+		for _, word := range words {
+			select {
+			case <-time.After(mockWordDelay):
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case ch <- Chunk{Text: word}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// Embed hashes the words of text into a bag-of-words vector, so texts
+// sharing words come out similar
+func (c *mockClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	vector := make([]float32, mockEmbeddingSize)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New32a()
+		h.Write([]byte(word))
+		vector[h.Sum32()%mockEmbeddingSize]++
+	}
+	return vector, nil
+}
+
+// mockReply is the canned answer to a prompt
+func mockReply(prompt string) string {
+	return fmt.Sprintf("This is the mock provider. Your prompt had %d words; set llm.provider to ollama or openrouter for real answers.",
+		len(strings.Fields(prompt)))
+}
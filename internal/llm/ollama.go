@@ -0,0 +1,217 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// ollamaClient talks to a local Ollama instance via /api/generate and
+// /api/embeddings
+type ollamaClient struct {
+	httpClient     *http.Client
+	endpoint       string
+	embedEndpoint  string
+	model          string
+	embeddingModel string
+	maxTokens      int
+	temp           float64
+	stream         bool
+	logger         *zap.Logger
+}
+
+type ollamaRequest struct {
+	Model   string        `json:"model"`
+	Prompt  string        `json:"prompt"`
+	Stream  bool          `json:"stream"`
+	Options ollamaOptions `json:"options"`
+}
+
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+type ollamaResponse struct {
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+	Error    string `json:"error,omitempty"`
+}
+
+type ollamaEmbedRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type ollamaEmbedResponse struct {
+	Embedding []float32 `json:"embedding"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Generate sends the prompt to Ollama and returns the full response
+func (c *ollamaClient) Generate(ctx context.Context, prompt string) (string, error) {
+	if c.stream {
+		ch, err := c.Stream(ctx, prompt)
+		if err != nil {
+			return "", err
+		}
+		return collectStream(ch)
+	}
+
+	resp, err := c.post(ctx, prompt, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	text, err := readOllamaResponse(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ollama returned %s", resp.Status)
+	}
+
+	c.logger.Debug("Ollama generation complete",
+		zap.String("model", c.model),
+		zap.Int("response_len", len(text)))
+
+	return text, nil
+}
+
+// Stream sends the prompt to Ollama and returns the response as it arrives
+func (c *ollamaClient) Stream(ctx context.Context, prompt string) (<-chan Chunk, error) {
+	resp, err := c.post(ctx, prompt, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Errors come back as a single JSON object rather than a stream
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if _, err := readOllamaResponse(resp.Body); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ollama returned %s", resp.Status)
+	}
+
+	c.logger.Debug("Ollama stream started", zap.String("model", c.model))
+	return streamBody(ctx, resp.Body, scanOllamaStream), nil
+}
+
+// post sends a generate request; the caller closes the response body
+func (c *ollamaClient) post(ctx context.Context, prompt string, stream bool) (*http.Response, error) {
+	body, err := json.Marshal(ollamaRequest{
+		Model:  c.model,
+		Prompt: prompt,
+		Stream: stream,
+		Options: ollamaOptions{
+			Temperature: c.temp,
+			NumPredict:  c.maxTokens,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ollama request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ollama request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach ollama: %w", err)
+	}
+	return resp, nil
+}
+
+// readOllamaResponse decodes a single non-streaming response object
+func readOllamaResponse(r io.Reader) (string, error) {
+	var out ollamaResponse
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return "", fmt.Errorf("failed to decode ollama response: %w", err)
+	}
+	if out.Error != "" {
+		return "", fmt.Errorf("ollama error: %s", out.Error)
+	}
+	return out.Response, nil
+}
+
+// scanOllamaStream emits the text of each newline-delimited JSON chunk of
+// a streaming response until the final chunk, or until emit returns false
+func scanOllamaStream(r io.Reader, emit func(string) bool) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk ollamaResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return fmt.Errorf("failed to decode ollama stream chunk: %w", err)
+		}
+		if chunk.Error != "" {
+			return fmt.Errorf("ollama error: %s", chunk.Error)
+		}
+
+		if chunk.Response != "" && !emit(chunk.Response) {
+			return nil
+		}
+		if chunk.Done {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read ollama stream: %w", err)
+	}
+	return nil
+}
+
+// Embed asks Ollama for the embedding of text
+func (c *ollamaClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(ollamaEmbedRequest{Model: c.embeddingModel, Prompt: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ollama embedding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ollama embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out ollamaEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode ollama embedding response: %w", err)
+	}
+	if out.Error != "" {
+		return nil, fmt.Errorf("ollama error: %s", out.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama returned %s", resp.Status)
+	}
+	if len(out.Embedding) == 0 {
+		return nil, fmt.Errorf("ollama returned an empty embedding")
+	}
+
+	c.logger.Debug("Ollama embedding complete",
+		zap.String("model", c.embeddingModel),
+		zap.Int("dimensions", len(out.Embedding)))
+
+	return out.Embedding, nil
+}
@@ -0,0 +1,138 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// newTestOllama returns a client for an httptest server running handler
+func newTestOllama(t *testing.T, stream bool, handler http.HandlerFunc) *ollamaClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &ollamaClient{
+		httpClient:     srv.Client(),
+		endpoint:       srv.URL + "/api/generate",
+		embedEndpoint:  srv.URL + "/api/embeddings",
+		model:          "llama3",
+		embeddingModel: "nomic-embed-text",
+		temp:           0.5,
+		stream:         stream,
+		logger:         zap.NewNop(),
+	}
+}
+
+func TestOllamaGenerate(t *testing.T) {
+	var got ollamaRequest
+	c := newTestOllama(t, false, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/generate" || r.Method != http.MethodPost {
+			t.Errorf("request to %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		fmt.Fprint(w, `{"response":"Keep going.","done":true}`)
+	})
+
+	text, err := c.Generate(context.Background(), "What next?")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if text != "Keep going." {
+		t.Errorf("Generate = %q", text)
+	}
+	if got.Model != "llama3" || got.Prompt != "What next?" || got.Stream || got.Options.Temperature != 0.5 {
+		t.Errorf("sent %+v", got)
+	}
+}
+
+func TestOllamaStream(t *testing.T) {
+	c := newTestOllama(t, true, func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			t.Error("stream not requested")
+		}
+		fmt.Fprintln(w, `{"response":"Keep ","done":false}`)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `{"response":"going.","done":false}`)
+		fmt.Fprintln(w, `{"response":"","done":true}`)
+		fmt.Fprintln(w, `{"response":"ignored","done":false}`)
+	})
+
+	ch, err := c.Stream(context.Background(), "What next?")
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	var chunks []string
+	for chunk := range ch {
+		if chunk.Err != nil {
+			t.Fatalf("stream failed: %v", chunk.Err)
+		}
+		chunks = append(chunks, chunk.Text)
+	}
+	if strings.Join(chunks, "|") != "Keep |going." {
+		t.Errorf("chunks = %q", chunks)
+	}
+
+	// With streaming on, Generate collects the stream
+	if text, err := c.Generate(context.Background(), "What next?"); err != nil || text != "Keep going." {
+		t.Errorf("Generate = %q, %v", text, err)
+	}
+}
+
+func TestOllamaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  bool
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "error body", status: http.StatusNotFound, body: `{"error":"model 'llama3' not found"}`, wantErr: "ollama error: model 'llama3' not found"},
+		{name: "bare status", status: http.StatusInternalServerError, body: `{}`, wantErr: "ollama returned 500 Internal Server Error"},
+		{name: "not json", status: http.StatusBadGateway, body: `<html>`, wantErr: "failed to decode ollama response"},
+		{name: "stream error body", stream: true, status: http.StatusNotFound, body: `{"error":"model 'llama3' not found"}`, wantErr: "ollama error: model 'llama3' not found"},
+		{name: "stream bare status", stream: true, status: http.StatusServiceUnavailable, body: `{}`, wantErr: "ollama returned 503 Service Unavailable"},
+		{name: "error mid-stream", stream: true, status: http.StatusOK, body: "{\"response\":\"Keep \"}\n{\"error\":\"out of memory\"}\n", wantErr: "ollama error: out of memory"},
+		{name: "bad chunk", stream: true, status: http.StatusOK, body: "{\"response\":\"Keep \"}\nnot json\n", wantErr: "failed to decode ollama stream chunk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestOllama(t, tt.stream, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			_, err := c.Generate(context.Background(), "What next?")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOllamaEmbed(t *testing.T) {
+	c := newTestOllama(t, false, func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaEmbedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/api/embeddings" || req.Model != "nomic-embed-text" || req.Prompt != "hello" {
+			t.Errorf("sent %+v to %s", req, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"embedding":[0.5,-1]}`)
+	})
+
+	got, err := c.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != 0.5 || got[1] != -1 {
+		t.Errorf("Embed = %v", got)
+	}
+}
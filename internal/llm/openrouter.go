@@ -0,0 +1,236 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// defaultOpenRouterEndpoint is used when no endpoint is configured
+const defaultOpenRouterEndpoint = "https://openrouter.ai/api/v1/chat/completions"
+
+// openRouterClient talks to an OpenRouter-compatible chat completions API
+type openRouterClient struct {
+	httpClient     *http.Client
+	endpoint       string
+	embedEndpoint  string
+	apiKey         string
+	model          string
+	embeddingModel string
+	maxTokens      int
+	temp           float64
+	stream         bool
+	logger         *zap.Logger
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	Temperature float64       `json:"temperature"`
+	Stream      bool          `json:"stream"`
+}
+
+type chatError struct {
+	Message string `json:"message"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *chatError `json:"error,omitempty"`
+}
+
+type chatStreamChunk struct {
+	Choices []struct {
+		Delta chatMessage `json:"delta"`
+	} `json:"choices"`
+	Error *chatError `json:"error,omitempty"`
+}
+
+type embeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *chatError `json:"error,omitempty"`
+}
+
+// Generate sends the prompt as a single user message and returns the reply
+func (c *openRouterClient) Generate(ctx context.Context, prompt string) (string, error) {
+	if c.stream {
+		ch, err := c.Stream(ctx, prompt)
+		if err != nil {
+			return "", err
+		}
+		return collectStream(ch)
+	}
+
+	resp, err := c.post(ctx, prompt, false)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	text, err := readChatResponse(resp.Body, resp.Status)
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debug("OpenRouter generation complete",
+		zap.String("model", c.model),
+		zap.Int("response_len", len(text)))
+
+	return text, nil
+}
+
+// Stream sends the prompt as a single user message and returns the reply
+// as it arrives
+func (c *openRouterClient) Stream(ctx context.Context, prompt string) (<-chan Chunk, error) {
+	resp, err := c.post(ctx, prompt, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Errors are always returned as a plain JSON body, even for streaming requests
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if _, err := readChatResponse(resp.Body, resp.Status); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("openrouter returned %s", resp.Status)
+	}
+
+	c.logger.Debug("OpenRouter stream started", zap.String("model", c.model))
+	return streamBody(ctx, resp.Body, scanChatStream), nil
+}
+
+// post sends a chat completion request; the caller closes the response body
+func (c *openRouterClient) post(ctx context.Context, prompt string, stream bool) (*http.Response, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:       c.model,
+		Messages:    []chatMessage{{Role: "user", Content: prompt}},
+		MaxTokens:   c.maxTokens,
+		Temperature: c.temp,
+		Stream:      stream,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal openrouter request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create openrouter request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach openrouter: %w", err)
+	}
+	return resp, nil
+}
+
+// readChatResponse decodes a single non-streaming chat completion
+func readChatResponse(r io.Reader, status string) (string, error) {
+	var out chatResponse
+	if err := json.NewDecoder(r).Decode(&out); err != nil {
+		return "", fmt.Errorf("failed to decode openrouter response: %w", err)
+	}
+	if out.Error != nil {
+		return "", fmt.Errorf("openrouter returned %s: %s", status, out.Error.Message)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("openrouter returned no choices")
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+// scanChatStream emits the delta of each server-sent event in a streaming
+// response until [DONE], or until emit returns false
+func scanChatStream(r io.Reader, emit func(string) bool) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Skip blank lines and SSE comments (OpenRouter sends keep-alives)
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var chunk chatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to decode openrouter stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("openrouter error: %s", chunk.Error.Message)
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			if !emit(chunk.Choices[0].Delta.Content) {
+				return nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read openrouter stream: %w", err)
+	}
+	return nil
+}
+
+// Embed asks the OpenAI-compatible embeddings API for the embedding of text
+func (c *openRouterClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(embeddingRequest{Model: c.embeddingModel, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal openrouter embedding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create openrouter embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach openrouter: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode openrouter embedding response: %w", err)
+	}
+	if out.Error != nil {
+		return nil, fmt.Errorf("openrouter returned %s: %s", resp.Status, out.Error.Message)
+	}
+	if len(out.Data) == 0 || len(out.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("openrouter returned no embedding")
+	}
+
+	c.logger.Debug("OpenRouter embedding complete",
+		zap.String("model", c.embeddingModel),
+		zap.Int("dimensions", len(out.Data[0].Embedding)))
+
+	return out.Data[0].Embedding, nil
+}
@@ -0,0 +1,140 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// newTestOpenRouter returns a client for an httptest server running handler
+func newTestOpenRouter(t *testing.T, stream bool, handler http.HandlerFunc) *openRouterClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &openRouterClient{
+		httpClient:     srv.Client(),
+		endpoint:       srv.URL + "/chat/completions",
+		embedEndpoint:  srv.URL + "/embeddings",
+		apiKey:         "sk-test",
+		model:          "meta-llama/llama-3-8b-instruct",
+		embeddingModel: "text-embedding-3-small",
+		maxTokens:      200,
+		temp:           0.7,
+		stream:         stream,
+		logger:         zap.NewNop(),
+	}
+}
+
+func TestOpenRouterGenerate(t *testing.T) {
+	var got chatRequest
+	c := newTestOpenRouter(t, false, func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer sk-test" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"Keep going."}}]}`)
+	})
+
+	text, err := c.Generate(context.Background(), "What next?")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if text != "Keep going." {
+		t.Errorf("Generate = %q", text)
+	}
+	if got.Model != c.model || got.MaxTokens != 200 || got.Stream ||
+		len(got.Messages) != 1 || got.Messages[0] != (chatMessage{Role: "user", Content: "What next?"}) {
+		t.Errorf("sent %+v", got)
+	}
+}
+
+func TestOpenRouterStream(t *testing.T) {
+	c := newTestOpenRouter(t, true, func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			t.Error("stream not requested")
+		}
+		fmt.Fprint(w, ": OPENROUTER PROCESSING\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Keep \"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"going.\"}}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"ignored\"}}]}\n\n")
+	})
+
+	ch, err := c.Stream(context.Background(), "What next?")
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	var chunks []string
+	for chunk := range ch {
+		if chunk.Err != nil {
+			t.Fatalf("stream failed: %v", chunk.Err)
+		}
+		chunks = append(chunks, chunk.Text)
+	}
+	if strings.Join(chunks, "|") != "Keep |going." {
+		t.Errorf("chunks = %q", chunks)
+	}
+
+	if text, err := c.Generate(context.Background(), "What next?"); err != nil || text != "Keep going." {
+		t.Errorf("Generate = %q, %v", text, err)
+	}
+}
+
+func TestOpenRouterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  bool
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "error body", status: http.StatusUnauthorized, body: `{"error":{"message":"No auth credentials found"}}`, wantErr: "openrouter returned 401 Unauthorized: No auth credentials found"},
+		{name: "no choices", status: http.StatusOK, body: `{"choices":[]}`, wantErr: "openrouter returned no choices"},
+		{name: "not json", status: http.StatusBadGateway, body: `<html>`, wantErr: "failed to decode openrouter response"},
+		{name: "stream error body", stream: true, status: http.StatusTooManyRequests, body: `{"error":{"message":"Rate limit exceeded"}}`, wantErr: "openrouter returned 429 Too Many Requests: Rate limit exceeded"},
+		{name: "error mid-stream", stream: true, status: http.StatusOK, body: "data: {\"choices\":[{\"delta\":{\"content\":\"Keep \"}}]}\n\ndata: {\"error\":{\"message\":\"provider crashed\"}}\n\n", wantErr: "openrouter error: provider crashed"},
+		{name: "bad chunk", stream: true, status: http.StatusOK, body: "data: {oops\n\n", wantErr: "failed to decode openrouter stream chunk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestOpenRouter(t, tt.stream, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			_, err := c.Generate(context.Background(), "What next?")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpenRouterEmbed(t *testing.T) {
+	c := newTestOpenRouter(t, false, func(w http.ResponseWriter, r *http.Request) {
+		var req embeddingRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/embeddings" || req.Model != "text-embedding-3-small" || req.Input != "hello" {
+			t.Errorf("sent %+v to %s", req, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data":[{"embedding":[0.25,1]}]}`)
+	})
+
+	got, err := c.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != 0.25 || got[1] != 1 {
+		t.Errorf("Embed = %v", got)
+	}
+}
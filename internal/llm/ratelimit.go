@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// Throttler is implemented by clients that hold requests back to stay
+// under a rate limit
+type Throttler interface {
+	// Delay returns how long a request made now would wait before being sent
+	Delay() time.Duration
+}
+
+// rateLimitedClient wraps a Client with a token bucket, so requests beyond
+// the configured rate wait their turn instead of being refused by the
+// provider
+type rateLimitedClient struct {
+	client  Client
+	limiter *rate.Limiter
+	logger  *zap.Logger
+	now     func() time.Time                                 // Replaceable clock
+	sleep   func(ctx context.Context, d time.Duration) error // Replaceable wait
+}
+
+// newRateLimitedClient allows perMinute requests a minute, one at a time:
+// the bucket holds a single token, so requests are spread evenly rather
+// than let through in bursts
+func newRateLimitedClient(client Client, perMinute int, logger *zap.Logger) *rateLimitedClient {
+	return &rateLimitedClient{
+		client:  client,
+		limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), 1),
+		logger:  logger,
+		now:     time.Now,
+		sleep:   sleepContext,
+	}
+}
+
+// sleepContext waits for d, or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// wait takes a token, sleeping until one is available. A request abandoned
+// while waiting gives its token back.
+func (c *rateLimitedClient) wait(ctx context.Context) error {
+	now := c.now()
+	r := c.limiter.ReserveN(now, 1)
+	delay := r.DelayFrom(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	c.logger.Debug("Rate limit reached, delaying LLM request", zap.Duration("delay", delay))
+	if err := c.sleep(ctx, delay); err != nil {
+		r.CancelAt(c.now())
+		return err
+	}
+	return nil
+}
+
+// Delay returns how long until the next token is available
+func (c *rateLimitedClient) Delay() time.Duration {
+	missing := 1 - c.limiter.TokensAt(c.now())
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / float64(c.limiter.Limit()) * float64(time.Second))
+}
+
+// Generate waits for the rate limit and then asks the wrapped client
+func (c *rateLimitedClient) Generate(ctx context.Context, prompt string) (string, error) {
+	if err := c.wait(ctx); err != nil {
+		return "", err
+	}
+	return c.client.Generate(ctx, prompt)
+}
+
+// Stream waits for the rate limit and then streams from the wrapped client
+func (c *rateLimitedClient) Stream(ctx context.Context, prompt string) (<-chan Chunk, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.Stream(ctx, prompt)
+}
+
+// Embed waits for the rate limit and then asks the wrapped client
+func (c *rateLimitedClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.Embed(ctx, text)
+}
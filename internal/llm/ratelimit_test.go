@@ -0,0 +1,131 @@
+package llm
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// newTestRateLimit returns a client allowing perMinute requests over a
+// counting client, with a fake clock. Its sleeps are recorded and, when
+// advance is set, move the clock forward.
+func newTestRateLimit(perMinute int, advance bool) (*rateLimitedClient, *countingClient, *fakeClock, func() []time.Duration) {
+	inner := &countingClient{}
+	clock := newFakeClock()
+	c := newRateLimitedClient(inner, perMinute, zap.NewNop())
+	c.now = clock.Now
+
+	var mu sync.Mutex
+	var slept []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		slept = append(slept, d)
+		mu.Unlock()
+		if advance {
+			clock.Advance(d)
+		}
+		return ctx.Err()
+	}
+	sleeps := func() []time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(slept)
+	}
+	return c, inner, clock, sleeps
+}
+
+func TestRateLimitSpacesRequests(t *testing.T) {
+	c, inner, clock, sleeps := newTestRateLimit(60, true)
+
+	generate(t, c, "one")
+	if got := sleeps(); len(got) != 0 {
+		t.Fatalf("first request slept %v", got)
+	}
+	if got := c.Delay(); got != time.Second {
+		t.Errorf("Delay after a request = %v, want 1s", got)
+	}
+
+	clock.Advance(400 * time.Millisecond)
+	if got := c.Delay(); got != 600*time.Millisecond {
+		t.Errorf("Delay 400ms later = %v, want 600ms", got)
+	}
+
+	generate(t, c, "two")
+	generate(t, c, "three")
+	if got, want := sleeps(), []time.Duration{600 * time.Millisecond, time.Second}; !slices.Equal(got, want) {
+		t.Errorf("slept %v, want %v", got, want)
+	}
+
+	// Idle time doesn't bank a burst: the bucket holds one token
+	clock.Advance(10 * time.Second)
+	if got := c.Delay(); got != 0 {
+		t.Errorf("Delay after idling = %v, want 0", got)
+	}
+	generate(t, c, "four")
+	generate(t, c, "five")
+	if got := sleeps(); len(got) != 3 || got[2] != time.Second {
+		t.Errorf("after idling slept %v, want one more 1s wait", got)
+	}
+	if inner.calls != 5 {
+		t.Errorf("client called %d times, want 5", inner.calls)
+	}
+}
+
+func TestRateLimitCancelReturnsToken(t *testing.T) {
+	c, inner, _, sleeps := newTestRateLimit(60, false)
+
+	generate(t, c, "one")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Generate(ctx, "abandoned"); err == nil {
+		t.Fatal("expected the cancelled request to fail")
+	}
+	if inner.calls != 1 {
+		t.Errorf("cancelled request reached the client")
+	}
+
+	// The abandoned reservation is given back, so the next request waits
+	// for the same slot rather than the one after it
+	generate(t, c, "two")
+	if got, want := sleeps(), []time.Duration{time.Second, time.Second}; !slices.Equal(got, want) {
+		t.Errorf("slept %v, want %v", got, want)
+	}
+}
+
+func TestRateLimitConcurrentCallers(t *testing.T) {
+	const callers = 8
+	c, inner, _, sleeps := newTestRateLimit(60, false)
+
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Generate(context.Background(), "hello"); err != nil {
+				t.Errorf("Generate failed: %v", err)
+			}
+			c.Delay()
+		}()
+	}
+	wg.Wait()
+
+	// Every caller reserves at the same instant, so each gets its own
+	// slot: one goes now and the rest wait 1s, 2s, ...
+	got := sleeps()
+	slices.Sort(got)
+	want := make([]time.Duration, callers-1)
+	for i := range want {
+		want[i] = time.Duration(i+1) * time.Second
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("slept %v, want %v", got, want)
+	}
+	if inner.calls != callers {
+		t.Errorf("client called %d times, want %d", inner.calls, callers)
+	}
+}
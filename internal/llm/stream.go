@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// Chunk is one piece of a streamed response. A chunk carries either text
+// or the error that ended the stream; the channel is closed once the
+// response is complete.
+type Chunk struct {
+	Text string
+	Err  error
+}
+
+// streamBody parses a streaming response body in the background, sending
+// each piece of text as a chunk. The body is closed when parsing ends or
+// ctx is cancelled.
+func streamBody(ctx context.Context, body io.ReadCloser, scan func(r io.Reader, emit func(string) bool) error) <-chan Chunk {
+	ch := make(chan Chunk)
+	go func() {
+		defer close(ch)
+		defer body.Close()
+
+		send := func(c Chunk) bool {
+			select {
+			case ch <- c:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		err := scan(body, func(text string) bool {
+			return send(Chunk{Text: text})
+		})
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			send(Chunk{Err: err})
+		}
+	}()
+	return ch
+}
+
+// singleChunk returns a closed stream holding just text, for responses
+// that are already complete
+func singleChunk(text string) <-chan Chunk {
+	ch := make(chan Chunk, 1)
+	ch <- Chunk{Text: text}
+	close(ch)
+	return ch
+}
+
+// collectStream reads a stream to the end and returns the full text
+func collectStream(ch <-chan Chunk) (string, error) {
+	var b strings.Builder
+	for chunk := range ch {
+		if chunk.Err != nil {
+			return "", chunk.Err
+		}
+		b.WriteString(chunk.Text)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,228 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"go.uber.org/zap"
+)
+
+// replyServer answers Ollama and OpenRouter requests with "Keep going.",
+// streamed or in one piece as each request asks, and records which it was
+func replyServer(t *testing.T, streamed *bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Stream bool `json:"stream"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		*streamed = req.Stream
+
+		switch {
+		case r.URL.Path == "/api/generate" && req.Stream:
+			fmt.Fprintln(w, `{"response":"Keep "}`)
+			fmt.Fprintln(w, `{"response":"going.","done":true}`)
+		case r.URL.Path == "/api/generate":
+			fmt.Fprint(w, `{"response":"Keep going.","done":true}`)
+		case req.Stream:
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Keep \"}}]}\n\n")
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"going.\"}}]}\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		default:
+			fmt.Fprint(w, `{"choices":[{"message":{"content":"Keep going."}}]}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestStreamSetting(t *testing.T) {
+	for _, provider := range []string{"ollama", "openrouter"} {
+		for _, stream := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s stream=%v", provider, stream), func(t *testing.T) {
+				var streamed bool
+				srv := replyServer(t, &streamed)
+
+				cfg := config.DefaultConfig()
+				cfg.LLM.Provider = provider
+				cfg.LLM.Stream = stream
+				cfg.LLM.Endpoint = srv.URL + "/chat/completions"
+				if provider == "ollama" {
+					cfg.LLM.Endpoint = srv.URL + "/api/generate"
+				}
+				client, err := newProviderClient(cfg, zap.NewNop())
+				if err != nil {
+					t.Fatalf("newProviderClient: %v", err)
+				}
+
+				text, err := client.Generate(context.Background(), "What next?")
+				if err != nil {
+					t.Fatalf("Generate failed: %v", err)
+				}
+				if text != "Keep going." {
+					t.Errorf("Generate = %q", text)
+				}
+				if streamed != stream {
+					t.Errorf("requested stream = %v, want %v", streamed, stream)
+				}
+
+				// Stream always streams, whatever the setting
+				ch, err := client.Stream(context.Background(), "What next?")
+				if err != nil {
+					t.Fatalf("Stream failed: %v", err)
+				}
+				if text, err := collectStream(ch); err != nil || text != "Keep going." {
+					t.Errorf("Stream = %q, %v", text, err)
+				}
+				if !streamed {
+					t.Error("Stream sent a buffered request")
+				}
+			})
+		}
+	}
+}
+
+// closeTracker records whether a streamed body was closed
+type closeTracker struct {
+	io.Reader
+	closed chan struct{}
+}
+
+func (c *closeTracker) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func TestStreamBody(t *testing.T) {
+	scanErr := errors.New("bad line")
+	tests := []struct {
+		name    string
+		err     error
+		want    []string
+		wantErr error
+	}{
+		{name: "complete", want: []string{"Keep ", "going."}},
+		{name: "scan error", err: scanErr, want: []string{"Keep ", "going."}, wantErr: scanErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &closeTracker{Reader: strings.NewReader(""), closed: make(chan struct{})}
+			ch := streamBody(context.Background(), body, func(r io.Reader, emit func(string) bool) error {
+				for _, text := range []string{"Keep ", "going."} {
+					if !emit(text) {
+						return nil
+					}
+				}
+				return tt.err
+			})
+
+			var chunks []string
+			var err error
+			for chunk := range ch {
+				if chunk.Err != nil {
+					err = chunk.Err
+					continue
+				}
+				if err != nil {
+					t.Errorf("chunk %q after the error", chunk.Text)
+				}
+				chunks = append(chunks, chunk.Text)
+			}
+			if strings.Join(chunks, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("chunks = %q, want %q", chunks, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			select {
+			case <-body.closed:
+			default:
+				t.Error("body not closed")
+			}
+		})
+	}
+}
+
+func TestStreamBodyCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	body := &closeTracker{Reader: strings.NewReader(""), closed: make(chan struct{})}
+	ch := streamBody(ctx, body, func(r io.Reader, emit func(string) bool) error {
+		for emit("more ") {
+		}
+		return nil
+	})
+
+	if chunk := <-ch; chunk.Text != "more " {
+		t.Fatalf("first chunk = %+v", chunk)
+	}
+	cancel()
+	for range ch {
+	}
+	select {
+	case <-body.closed:
+	case <-time.After(time.Second):
+		t.Error("body not closed after cancel")
+	}
+}
+
+func TestCollectStream(t *testing.T) {
+	if text, err := collectStream(singleChunk("Keep going.")); err != nil || text != "Keep going." {
+		t.Errorf("collectStream(singleChunk) = %q, %v", text, err)
+	}
+
+	ch := make(chan Chunk, 3)
+	ch <- Chunk{Text: "Keep "}
+	ch <- Chunk{Err: errors.New("connection reset")}
+	close(ch)
+	if text, err := collectStream(ch); err == nil || text != "" {
+		t.Errorf("collectStream with error = %q, %v", text, err)
+	}
+}
+
+func TestMockStream(t *testing.T) {
+	c := &mockClient{}
+	prompt := "What should I write about next?"
+
+	ch, err := c.Stream(context.Background(), prompt)
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	var chunks []string
+	for chunk := range ch {
+		if chunk.Err != nil {
+			t.Fatalf("stream failed: %v", chunk.Err)
+		}
+		chunks = append(chunks, chunk.Text)
+	}
+	if len(chunks) < 2 {
+		t.Errorf("reply sent in %d chunks, want one per word", len(chunks))
+	}
+	if got := strings.Join(chunks, ""); got != mockReply(prompt) {
+		t.Errorf("stream = %q, want %q", got, mockReply(prompt))
+	}
+
+	// Cancelling stops the stream early and closes the channel
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err = c.Stream(ctx, prompt)
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	<-ch
+	cancel()
+	n := 0
+	for range ch {
+		n++
+	}
+	if n >= len(chunks)-1 {
+		t.Errorf("received %d more chunks after cancel", n)
+	}
+}
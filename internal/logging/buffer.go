@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Buffer holds log entries written before the real logger can be built,
+// such as those logged while loading the config that picks its format.
+// Replaying them afterwards keeps the output in a single format.
+type Buffer struct {
+	mu      sync.Mutex
+	entries []bufferedEntry
+}
+
+type bufferedEntry struct {
+	entry  zapcore.Entry
+	fields []zapcore.Field
+}
+
+// NewBufferedLogger returns a logger that keeps every entry in the returned
+// Buffer until it is replayed
+func NewBufferedLogger() (*zap.Logger, *Buffer) {
+	buf := &Buffer{}
+	return zap.New(bufferCore{buf: buf}, zap.AddCaller()), buf
+}
+
+// Replay writes the buffered entries to logger, which drops those below
+// its level, and empties the buffer
+func (b *Buffer) Replay(logger *zap.Logger) {
+	b.mu.Lock()
+	entries := b.entries
+	b.entries = nil
+	b.mu.Unlock()
+
+	for _, e := range entries {
+		if ce := logger.Check(e.entry.Level, e.entry.Message); ce != nil {
+			ce.Time = e.entry.Time
+			ce.Caller = e.entry.Caller
+			ce.Write(e.fields...)
+		}
+	}
+}
+
+// bufferCore is the zapcore.Core behind NewBufferedLogger
+type bufferCore struct {
+	buf    *Buffer
+	fields []zapcore.Field // Added with With
+}
+
+func (c bufferCore) Enabled(zapcore.Level) bool { return true }
+
+func (c bufferCore) With(fields []zapcore.Field) zapcore.Core {
+	return bufferCore{buf: c.buf, fields: append(append([]zapcore.Field{}, c.fields...), fields...)}
+}
+
+func (c bufferCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(entry, c)
+}
+
+func (c bufferCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.buf.mu.Lock()
+	defer c.buf.mu.Unlock()
+	all := append(append([]zapcore.Field{}, c.fields...), fields...)
+	c.buf.entries = append(c.buf.entries, bufferedEntry{entry: entry, fields: all})
+	return nil
+}
+
+func (c bufferCore) Sync() error { return nil }
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -8,10 +9,50 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-// NewLogger creates a new configured logger
-func NewLogger(debug bool) (*zap.Logger, error) {
+// Log formats accepted by NewLogger
+const (
+	FormatConsole = "console" // Colored, human-readable lines
+	FormatJSON    = "json"    // One JSON object per line, for log collectors
+)
+
+// Formats lists the accepted log formats
+var Formats = []string{FormatConsole, FormatJSON}
+
+// encodingFor returns the zap encoding for a log format; an empty format
+// means console
+func encodingFor(format string) (string, error) {
+	switch format {
+	case FormatConsole, "":
+		return "console", nil
+	case FormatJSON:
+		return "json", nil
+	default:
+		return "", fmt.Errorf("unknown log format %q (want console or json)", format)
+	}
+}
+
+// NewLogger creates a new configured logger writing to stderr in the given
+// format
+func NewLogger(debug bool, format string) (*zap.Logger, error) {
+	config, err := loggerConfig(debug, format)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the logger
+	return config.Build()
+}
+
+// loggerConfig returns the zap config NewLogger builds its logger from
+func loggerConfig(debug bool, format string) (zap.Config, error) {
+	encoding, err := encodingFor(format)
+	if err != nil {
+		return zap.Config{}, err
+	}
+
 	// Use development config for more console-friendly output
 	config := zap.NewDevelopmentConfig()
+	config.Encoding = encoding
 
 	// Set the log level based on debug flag
 	if debug {
@@ -24,13 +65,18 @@ func NewLogger(debug bool) (*zap.Logger, error) {
 	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // Keep colored levels
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
+	// Log collectors expect the usual field names and no color codes
+	if encoding == "json" {
+		config.EncoderConfig = zap.NewProductionEncoderConfig()
+		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	}
+
 	// OutputPaths and ErrorOutputPaths are typically stderr for DevelopmentConfig
 	// Let's stick with the DevelopmentConfig defaults for now.
 	// config.OutputPaths = []string{"stdout"}
 	// config.ErrorOutputPaths = []string{"stderr"}
 
-	// Create the logger
-	return config.Build()
+	return config, nil
 }
 
 // FileLogger creates a logger that also writes to a file
@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestEncodingFor(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{format: FormatConsole, want: "console"},
+		{format: "", want: "console"},
+		{format: FormatJSON, want: "json"},
+		{format: "xml", wantErr: true},
+		{format: "JSON", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := encodingFor(tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("encodingFor(%q) = %q, want an error", tt.format, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("encodingFor(%q) = %q, %v; want %q", tt.format, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// encode renders one entry with the encoder NewLogger would use for format
+func encode(t *testing.T, format string) string {
+	t.Helper()
+	cfg, err := loggerConfig(false, format)
+	if err != nil {
+		t.Fatalf("loggerConfig(%q): %v", format, err)
+	}
+	var enc zapcore.Encoder
+	switch cfg.Encoding {
+	case "json":
+		enc = zapcore.NewJSONEncoder(cfg.EncoderConfig)
+	case "console":
+		enc = zapcore.NewConsoleEncoder(cfg.EncoderConfig)
+	default:
+		t.Fatalf("unexpected encoding %q", cfg.Encoding)
+	}
+
+	entry := zapcore.Entry{Level: zapcore.InfoLevel, Time: time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC), Message: "Loaded configuration"}
+	buf, err := enc.EncodeEntry(entry, []zapcore.Field{zap.String("path", "/tmp/config.yaml")})
+	if err != nil {
+		t.Fatalf("EncodeEntry: %v", err)
+	}
+	return buf.String()
+}
+
+func TestLoggerEncoders(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		line := encode(t, FormatJSON)
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			t.Fatalf("json output isn't JSON: %v\n%s", err, line)
+		}
+		want := map[string]any{"level": "info", "msg": "Loaded configuration", "path": "/tmp/config.yaml", "ts": "2024-03-05T09:30:00.000Z"}
+		for key, value := range want {
+			if fields[key] != value {
+				t.Errorf("%s = %v, want %v", key, fields[key], value)
+			}
+		}
+		if strings.Contains(line, "\x1b[") {
+			t.Errorf("json output has color codes: %q", line)
+		}
+	})
+
+	t.Run("console", func(t *testing.T) {
+		line := encode(t, FormatConsole)
+		for _, want := range []string{"2024-03-05T09:30:00.000Z", "\x1b[", "INFO", "Loaded configuration", `{"path": "/tmp/config.yaml"}`} {
+			if !strings.Contains(line, want) {
+				t.Errorf("console output %q doesn't contain %q", line, want)
+			}
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "{") {
+			t.Errorf("console output is JSON: %q", line)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := NewLogger(false, "xml"); err == nil {
+			t.Errorf("NewLogger accepted an unknown format")
+		}
+	})
+}
+
+func TestBufferReplay(t *testing.T) {
+	buffered, buf := NewBufferedLogger()
+	buffered.Debug("Checking config")
+	buffered.With(zap.String("path", "/tmp/config.yaml")).Info("Loaded configuration", zap.Int("size", 42))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	if logs.Len() != 0 {
+		t.Fatal("entries logged before the replay")
+	}
+	buf.Replay(zap.New(core))
+
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("replayed %d entries, want the 1 at info level: %v", len(entries), entries)
+	}
+	got := entries[0]
+	if got.Message != "Loaded configuration" {
+		t.Errorf("message = %q", got.Message)
+	}
+	fields := got.ContextMap()
+	if fields["path"] != "/tmp/config.yaml" || fields["size"] != int64(42) {
+		t.Errorf("fields = %v, want path and size", fields)
+	}
+
+	// Replaying again writes nothing more
+	buf.Replay(zap.New(core))
+	if logs.Len() != 1 {
+		t.Errorf("second replay wrote %d entries", logs.Len()-1)
+	}
+}
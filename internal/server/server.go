@@ -0,0 +1,145 @@
+package server
+
+import (
+	"errors"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/export"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"go.uber.org/zap"
+)
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Momentum Journal</title></head>
+<body>
+<h1>Momentum Journal</h1>
+{{if .}}<table>
+<tr><th>Date</th><th>Time</th><th>Words</th><th>Complete</th><th>Entry</th></tr>
+{{range .}}<tr>
+<td>{{.CreatedAt.Format "2006-01-02"}}</td>
+<td>{{.CreatedAt.Format "15:04"}}</td>
+<td>{{.WordCount}}</td>
+<td>{{.IsCompleted}}</td>
+<td><a href="/entries/{{.FileName}}">{{.FileName}}</a></td>
+</tr>
+{{end}}</table>{{else}}<p>No journal entries found.</p>{{end}}
+</body>
+</html>
+`))
+
+var entryTemplate = template.Must(template.New("entry").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Entry.FileName}}</title></head>
+<body>
+<p><a href="/">&larr; All entries</a></p>
+<h1>{{.Entry.FileName}}</h1>
+<p>{{.Entry.WordCount}} words</p>
+<article>
+{{.Body}}
+</article>
+</body>
+</html>
+`))
+
+// NewHandler returns a read-only HTTP handler that lists entries and
+// renders each one as HTML
+func NewHandler(manager *journal.Manager, logger *zap.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", indexHandler(manager, logger))
+	mux.HandleFunc("GET /entries/{name}", entryHandler(manager, logger))
+	return localHostOnly(mux, logger)
+}
+
+// localHostOnly rejects requests not addressed to the loopback host and
+// port the server listens on. Listening on 127.0.0.1 isn't enough on its
+// own: a web page can rebind its domain's DNS to 127.0.0.1 and read the
+// journal, but its requests still carry its own Host.
+func localHostOnly(next http.Handler, logger *zap.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLocalHost(r) {
+			logger.Warn("Rejected request for another host", zap.String("host", r.Host))
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLocalHost reports whether the request's Host is 127.0.0.1 or localhost
+// with the port the request came in on
+func isLocalHost(r *http.Request) bool {
+	local, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	if !ok {
+		return false
+	}
+	_, localPort, err := net.SplitHostPort(local.String())
+	if err != nil {
+		return false
+	}
+	host, port, err := net.SplitHostPort(r.Host)
+	if err != nil || port != localPort {
+		return false
+	}
+	return host == "127.0.0.1" || strings.EqualFold(host, "localhost")
+}
+
+// indexHandler lists all journal entries
+func indexHandler(manager *journal.Manager, logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		entries, err := manager.ListEntries()
+		if err != nil {
+			logger.Error("Failed to list journal entries", zap.Error(err))
+			http.Error(w, "failed to list journal entries", http.StatusInternalServerError)
+			return
+		}
+
+		if err := indexTemplate.Execute(w, entries); err != nil {
+			logger.Error("Failed to render index", zap.Error(err))
+		}
+	}
+}
+
+// entryHandler renders a single entry as HTML
+func entryHandler(manager *journal.Manager, logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filePath, err := manager.ResolveEntryPath(r.PathValue("name"))
+		if errors.Is(err, journal.ErrNotFound) || errors.Is(err, journal.ErrOutsideStorage) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			logger.Error("Failed to resolve journal entry", zap.Error(err))
+			http.Error(w, "failed to read journal entry", http.StatusInternalServerError)
+			return
+		}
+
+		entry, err := manager.ReadEntry(filePath)
+		if err != nil {
+			logger.Error("Failed to read journal entry", zap.String("file", filePath), zap.Error(err))
+			http.Error(w, "failed to read journal entry", http.StatusInternalServerError)
+			return
+		}
+
+		body, err := export.EntryHTML(entry)
+		if err != nil {
+			logger.Error("Failed to render journal entry", zap.String("file", filePath), zap.Error(err))
+			http.Error(w, "failed to render journal entry", http.StatusInternalServerError)
+			return
+		}
+
+		data := struct {
+			Entry *journal.JournalEntry
+			Body  template.HTML
+		}{
+			Entry: entry,
+			Body:  template.HTML(body), // goldmark escapes raw HTML by default
+		}
+		if err := entryTemplate.Execute(w, data); err != nil {
+			logger.Error("Failed to render entry page", zap.Error(err))
+		}
+	}
+}
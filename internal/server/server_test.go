@@ -0,0 +1,222 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"go.uber.org/zap"
+)
+
+// newTestServer serves a MemoryStore journal holding files, keyed by path
+// relative to the journal directory
+func newTestServer(t *testing.T, files map[string]string) *httptest.Server {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Journal.StorageDir = "/journal"
+
+	store := journal.NewMemoryStore()
+	for name, content := range files {
+		if err := store.Write(filepath.Join("/", name), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager, err := journal.NewManager(cfg, zap.NewNop(), journal.WithStore(store))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	srv := httptest.NewServer(NewHandler(manager, zap.NewNop()))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// get fetches path from srv and returns the status and body
+func get(t *testing.T, srv *httptest.Server, path string) (int, string) {
+	t.Helper()
+	resp, err := srv.Client().Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestServer(t *testing.T) {
+	files := map[string]string{
+		"journal/2024-03-05T09:30-morning-pages.md": "---\nmodified_at: 2024-03-05T09:45:00Z\n---\nSome **bold** words <script>alert(1)</script>\n",
+		"journal/2024/03/filed-away.md":             "Filed in a subdirectory\n",
+		"journal/odd <name>.md":                     "Odd name\n",
+		"secret.md":                                 "Outside the journal\n",
+	}
+	srv := newTestServer(t, files)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:       "index lists entries",
+			path:       "/",
+			wantStatus: http.StatusOK,
+			want: []string{
+				`<a href="/entries/2024-03-05T09:30-morning-pages.md">`,
+				`<a href="/entries/filed-away.md">`,
+			},
+		},
+		{
+			name:       "index escapes names",
+			path:       "/",
+			wantStatus: http.StatusOK,
+			want:       []string{`odd &lt;name&gt;.md`, `href="/entries/odd%20%3cname%3e.md"`},
+			notWant:    []string{`<name>`},
+		},
+		{
+			name:       "entry renders markdown",
+			path:       "/entries/2024-03-05T09:30-morning-pages.md",
+			wantStatus: http.StatusOK,
+			want:       []string{"<strong>bold</strong>", "<h1>2024-03-05T09:30-morning-pages.md</h1>"},
+			notWant:    []string{"<script>"},
+		},
+		{
+			name:       "entry without extension",
+			path:       "/entries/2024-03-05T09:30-morning-pages",
+			wantStatus: http.StatusOK,
+			want:       []string{"<strong>bold</strong>"},
+		},
+		{
+			name:       "entry in a subdirectory",
+			path:       "/entries/filed-away.md",
+			wantStatus: http.StatusOK,
+			want:       []string{"Filed in a subdirectory"},
+		},
+		{
+			name:       "escaped entry name",
+			path:       "/entries/odd%20%3Cname%3E.md",
+			wantStatus: http.StatusOK,
+			want:       []string{"Odd name"},
+		},
+		{
+			name:       "missing entry",
+			path:       "/entries/missing.md",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "escaped path out of the journal",
+			path:       "/entries/..%2Fsecret.md",
+			wantStatus: http.StatusNotFound,
+			notWant:    []string{"Outside the journal"},
+		},
+		{
+			name:       "lowercase escaped path out of the journal",
+			path:       "/entries/..%2fsecret.md",
+			wantStatus: http.StatusNotFound,
+			notWant:    []string{"Outside the journal"},
+		},
+		{
+			name:       "escaped dots out of the journal",
+			path:       "/entries/%2e%2e%2fsecret.md",
+			wantStatus: http.StatusNotFound,
+			notWant:    []string{"Outside the journal"},
+		},
+		{
+			name:       "unknown route",
+			path:       "/nowhere",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := get(t, srv, tt.path)
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body doesn't contain %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("body contains %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
+
+func TestServerEmptyJournal(t *testing.T) {
+	srv := newTestServer(t, nil)
+	status, body := get(t, srv, "/")
+	if status != http.StatusOK || !strings.Contains(body, "No journal entries found.") {
+		t.Errorf("got %d %q, want the empty journal message", status, body)
+	}
+}
+
+func TestServerReadOnly(t *testing.T) {
+	srv := newTestServer(t, nil)
+	resp, err := srv.Client().Post(srv.URL+"/", "text/plain", strings.NewReader("x"))
+	if err != nil {
+		t.Fatalf("POST /: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestServerRejectsOtherHosts(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"journal/2024-03-05T09:30-morning-pages.md": "Private words\n",
+	})
+	port := srv.URL[strings.LastIndex(srv.URL, ":")+1:]
+
+	tests := []struct {
+		host       string
+		wantStatus int
+	}{
+		{host: "127.0.0.1:" + port, wantStatus: http.StatusOK},
+		{host: "localhost:" + port, wantStatus: http.StatusOK},
+		{host: "evil.example:" + port, wantStatus: http.StatusForbidden},
+		{host: "evil.example", wantStatus: http.StatusForbidden},
+		{host: "localhost:1", wantStatus: http.StatusForbidden},
+		{host: "127.0.0.1", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			for _, path := range []string{"/", "/entries/2024-03-05T09:30-morning-pages.md"} {
+				req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Host = tt.host
+				resp, err := srv.Client().Do(req)
+				if err != nil {
+					t.Fatalf("GET %s: %v", path, err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("GET %s: status = %d, want %d", path, resp.StatusCode, tt.wantStatus)
+				}
+				if tt.wantStatus == http.StatusForbidden && strings.Contains(string(body), "Private words") {
+					t.Errorf("GET %s served the entry to another host", path)
+				}
+			}
+		})
+	}
+}
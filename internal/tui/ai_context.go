@@ -0,0 +1,38 @@
+package tui
+
+import "strings"
+
+// lastNParagraphs returns text from the start of its nth-last paragraph
+// onward, where paragraphs are separated by blank lines. Anything after the
+// last paragraph, like the trailing whitespace at the cursor, is kept. n of
+// 0 or less, or more than there are paragraphs, returns the whole text.
+func lastNParagraphs(text string, n int) string {
+	if n <= 0 {
+		return text
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	offset := len(text)
+	inParagraph := false
+	for i := len(lines) - 1; i >= 0; i-- {
+		offset -= len(lines[i])
+		if strings.TrimSpace(lines[i]) == "" {
+			if inParagraph {
+				n--
+				if n == 0 {
+					return text[offset+len(lines[i]):]
+				}
+			}
+			inParagraph = false
+			continue
+		}
+		inParagraph = true
+	}
+	return text
+}
+
+// aiContext trims entry text to the llm.context_paragraphs most recent
+// paragraphs before it is sent to the AI.
+func (m model) aiContext(text string) string {
+	return lastNParagraphs(text, m.cfg.LLM.ContextParagraphs)
+}
@@ -0,0 +1,34 @@
+package tui
+
+import "testing"
+
+func TestLastNParagraphs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want string
+	}{
+		{name: "last paragraph", text: "a\n\nb\n\nc", n: 1, want: "c"},
+		{name: "last two", text: "a\n\nb\n\nc", n: 2, want: "b\n\nc"},
+		{name: "exactly all", text: "a\n\nb\n\nc", n: 3, want: "a\n\nb\n\nc"},
+		{name: "more than there are", text: "a\n\nb\n\nc", n: 10, want: "a\n\nb\n\nc"},
+		{name: "zero keeps everything", text: "a\n\nb", n: 0, want: "a\n\nb"},
+		{name: "negative keeps everything", text: "a\n\nb", n: -1, want: "a\n\nb"},
+		{name: "multi-line paragraphs", text: "a\nb\n\nc\nd", n: 1, want: "c\nd"},
+		{name: "run of blank lines", text: "a\n\n\n\nb", n: 1, want: "b"},
+		{name: "whitespace-only separator", text: "a\n \t\nb", n: 1, want: "b"},
+		{name: "keeps trailing blank lines", text: "a\n\nb\n\n", n: 1, want: "b\n\n"},
+		{name: "single paragraph", text: "just one", n: 1, want: "just one"},
+		{name: "only blank lines", text: "\n\n", n: 1, want: "\n\n"},
+		{name: "empty buffer", text: "", n: 2, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastNParagraphs(tt.text, tt.n); got != tt.want {
+				t.Errorf("lastNParagraphs(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newAttachInput creates the status bar field that asks which file to attach.
+func newAttachInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Attach file: "
+	input.Placeholder = "path to a photo or file"
+	input.Cursor.SetMode(cursor.CursorStatic) // The status bar only redraws on key presses
+	return input
+}
+
+// openAttach asks for a file to attach to the entry.
+func (m *model) openAttach() tea.Cmd {
+	m.attaching = true
+	m.attachInput.Reset()
+	cmd := m.attachInput.Focus()
+	m.statusBarModel.SetPrompt(m.attachInput.View())
+	return cmd
+}
+
+// handleAttachKey edits the path while the attach field is open. Enter
+// copies the file into the journal's attachments and links it at the
+// cursor; Esc cancels.
+func (m model) handleAttachKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closeAttach()
+		return m, nil
+	case tea.KeyEnter:
+		path := expandHome(strings.TrimSpace(m.attachInput.Value()))
+		m.closeAttach()
+		if path == "" {
+			return m, nil
+		}
+
+		relPath, err := m.manager.AttachFile(m.entry, path)
+		if err != nil {
+			m.notify("Attach failed: " + err.Error())
+			return m, nil
+		}
+		m.writingModel.InsertText(journal.AttachmentLink(relPath))
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.attachInput, cmd = m.attachInput.Update(msg)
+	m.statusBarModel.SetPrompt(m.attachInput.View())
+	return m, cmd
+}
+
+// closeAttach hides the attach field.
+func (m *model) closeAttach() {
+	m.attaching = false
+	m.attachInput.Blur()
+	m.statusBarModel.SetPrompt("")
+}
+
+// expandHome replaces a leading ~ with the home directory, since the path
+// is typed without a shell to expand it.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
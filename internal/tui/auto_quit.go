@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autoQuitSeconds is how long the countdown to quitting runs once the goal
+// is reached with Journal.AutoQuitOnComplete.
+const autoQuitSeconds = 5
+
+// autoQuitTickMsg counts the auto-quit countdown down by a second. id ties
+// it to the countdown that scheduled it, so ticks of a cancelled one are
+// ignored.
+type autoQuitTickMsg struct{ id int }
+
+// startAutoQuit begins the countdown to saving and quitting.
+func (m *model) startAutoQuit() tea.Cmd {
+	m.autoQuitID++
+	m.autoQuitLeft = autoQuitSeconds
+	m.statusBarModel.SetPrompt(autoQuitPrompt(m.autoQuitLeft))
+	return m.autoQuitTick()
+}
+
+// autoQuitTick schedules the next second of the countdown.
+func (m model) autoQuitTick() tea.Cmd {
+	id := m.autoQuitID
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return autoQuitTickMsg{id: id}
+	})
+}
+
+// autoQuitPrompt is the status bar line while the countdown runs.
+func autoQuitPrompt(left int) string {
+	return fmt.Sprintf("Goal reached, well done! Saving and quitting in %ds, press any key to keep writing", left)
+}
+
+// handleAutoQuitTick counts down, saving and quitting when it runs out.
+func (m *model) handleAutoQuitTick(msg autoQuitTickMsg) tea.Cmd {
+	if msg.id != m.autoQuitID || m.autoQuitLeft <= 0 {
+		return nil
+	}
+	m.autoQuitLeft--
+	if m.autoQuitLeft == 0 {
+		m.statusBarModel.SetPrompt("")
+		return m.saveAndQuit()
+	}
+	m.statusBarModel.SetPrompt(autoQuitPrompt(m.autoQuitLeft))
+	return m.autoQuitTick()
+}
+
+// cancelAutoQuit stops the countdown and goes back to writing.
+func (m *model) cancelAutoQuit() {
+	m.autoQuitLeft = 0
+	m.statusBarModel.SetPrompt("")
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// newAutoQuitModel opens an entry with a three word goal in the given goal
+// mode, quitting on completion if autoQuit is set.
+func newAutoQuitModel(t *testing.T, mode string, autoQuit bool) model {
+	t.Helper()
+	return newTestModel(t, func(cfg *config.Config) {
+		cfg.Journal.WordCountGoal = 3
+		cfg.Journal.GoalMode = mode
+		cfg.Journal.AutoQuitOnComplete = autoQuit
+	}, nil)
+}
+
+// tickAutoQuit delivers a countdown tick for the current countdown.
+func tickAutoQuit(m model) model {
+	updated, _ := m.Update(autoQuitTickMsg{id: m.autoQuitID})
+	return updated.(model)
+}
+
+func TestAutoQuitTrigger(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		autoQuit  bool
+		wantCount bool
+	}{
+		{name: "off", mode: goalModeSoft},
+		{name: "soft goal", mode: goalModeSoft, autoQuit: true, wantCount: true},
+		{name: "hard goal", mode: goalModeHard, autoQuit: true, wantCount: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typeText(newAutoQuitModel(t, tt.mode, tt.autoQuit), "one two")
+			if m.autoQuitLeft != 0 {
+				t.Fatal("countdown started below the goal")
+			}
+
+			m = typeText(m, " t")
+			if got := m.autoQuitLeft > 0; got != tt.wantCount {
+				t.Errorf("counting down = %v, want %v", got, tt.wantCount)
+			}
+			if tt.wantCount && (m.confirmFinish || m.statusBarModel.prompt != autoQuitPrompt(autoQuitSeconds)) {
+				t.Errorf("confirmFinish = %v, prompt = %q", m.confirmFinish, m.statusBarModel.prompt)
+			}
+			if m.quitting {
+				t.Error("quit before the countdown ran out")
+			}
+		})
+	}
+}
+
+func TestAutoQuitCountdown(t *testing.T) {
+	m := typeText(newAutoQuitModel(t, goalModeSoft, true), "one two t")
+	for range autoQuitSeconds - 1 {
+		m = tickAutoQuit(m)
+	}
+	if m.quitting || m.statusBarModel.prompt != autoQuitPrompt(1) {
+		t.Fatalf("quitting = %v, prompt = %q with a second left", m.quitting, m.statusBarModel.prompt)
+	}
+
+	m = tickAutoQuit(m)
+	if !m.quitting {
+		t.Fatal("didn't quit when the countdown ran out")
+	}
+	saved, err := m.manager.ReadEntry(m.entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if saved.Content != "one two t" {
+		t.Errorf("saved content = %q", saved.Content)
+	}
+}
+
+func TestAutoQuitCancel(t *testing.T) {
+	m := typeText(newAutoQuitModel(t, goalModeSoft, true), "one two t")
+	m = tickAutoQuit(m)
+	stale := autoQuitTickMsg{id: m.autoQuitID}
+
+	m = typeText(m, "hree")
+	if m.autoQuitLeft != 0 || m.statusBarModel.prompt != "" {
+		t.Fatalf("countdown not cancelled: %d left, prompt %q", m.autoQuitLeft, m.statusBarModel.prompt)
+	}
+	if got := m.writingModel.Value(); got != "one two three" {
+		t.Errorf("value = %q, the cancelling key was lost", got)
+	}
+
+	// Ticks already scheduled, and going on past the goal, don't restart it.
+	for range autoQuitSeconds {
+		updated, _ := m.Update(stale)
+		m = updated.(model)
+	}
+	m = typeText(m, " four")
+	if m.quitting || m.autoQuitLeft != 0 {
+		t.Errorf("quitting = %v, %d left after cancelling", m.quitting, m.autoQuitLeft)
+	}
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autosaveTickMsg fires every autosave interval.
+type autosaveTickMsg time.Time
+
+// AutosaveErrorMsg reports that an autosave failed, so the status bar can
+// raise the alert.
+type AutosaveErrorMsg struct {
+	Err error
+}
+
+// autosaveTick schedules the next autosave tick, or nothing if autosave is
+// disabled or the entry is read-only.
+func (m model) autosaveTick() tea.Cmd {
+	interval := time.Duration(m.cfg.Journal.AutosaveInterval) * time.Second
+	if interval <= 0 || m.writingModel.ReadOnly() {
+		return nil
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return autosaveTickMsg(t)
+	})
+}
+
+// saveCurrent writes the writing pane content to the current entry.
+func (m *model) saveCurrent() error {
+	m.entry.Content = m.writingModel.Value()
+	if err := m.manager.SaveEntry(m.entry); err != nil {
+		return err
+	}
+	m.lastSaved = m.entry.Content
+	return nil
+}
+
+// saveToStorage saves the entry if it changed, or just its word count
+// history otherwise.
+func (m *model) saveToStorage() error {
+	if m.dirty() {
+		return m.saveCurrent()
+	}
+	return m.manager.SaveHistory(m.entry)
+}
+
+// dirty reports whether the writing pane has changes since the last save.
+func (m model) dirty() bool {
+	return m.writingModel.Value() != m.lastSaved
+}
+
+// draftDirty reports whether the writing pane has changes since the last
+// draft was written. The entry file lags behind the draft until finalSave,
+// so dirty alone would rewrite an unchanged draft on every autosave.
+func (m model) draftDirty() bool {
+	return m.writingModel.Value() != m.lastDrafted
+}
+
+// saveDraft writes the writing pane content to the entry's draft.
+func (m *model) saveDraft() error {
+	m.entry.Content = m.writingModel.Value()
+	if err := m.manager.SaveDraft(m.entry); err != nil {
+		return err
+	}
+	m.lastDrafted = m.entry.Content
+	return nil
+}
+
+// saveOnBlur saves the entry when focus leaves the writing pane, so the
+// conversation works on the current text. Unchanged buffers are skipped so
+// bouncing between panes doesn't rewrite the file. Like autosave, it writes
+// to the draft when a draft directory is configured.
+func (m *model) saveOnBlur() {
+	if !m.cfg.Journal.SaveOnBlur {
+		return
+	}
+	if m.manager.UsesDraftDir() {
+		if m.draftDirty() {
+			m.noteSave(m.saveDraft())
+		}
+		return
+	}
+	if m.dirty() {
+		m.noteSave(m.saveCurrent())
+	}
+}
+
+// handleAutosave records a word count sample and saves the entry if it
+// changed, keeping a snapshot of it when Journal.Snapshots is on. With a
+// draft directory configured, the changes go to the draft and the entry
+// file is only written by finalSave. Every tick is logged at debug level.
+// A failed save comes back as an AutosaveErrorMsg.
+func (m *model) handleAutosave(at time.Time) tea.Cmd {
+	m.entry.Content = m.writingModel.Value()
+	m.manager.RecordSample(m.entry, at)
+
+	drafting := m.manager.UsesDraftDir()
+	changed := m.dirty()
+	if drafting {
+		changed = m.draftDirty()
+	}
+	written := 0
+	if changed {
+		written = len(m.entry.Content)
+	}
+	if drafting && !changed {
+		m.manager.LogAutosave(m.entry, at, written, nil)
+		return nil
+	}
+
+	var err error
+	if drafting {
+		err = m.saveDraft()
+	} else {
+		err = m.saveToStorage()
+	}
+	m.manager.LogAutosave(m.entry, at, written, err)
+	if err != nil {
+		m.saveErr = err
+		return func() tea.Msg { return AutosaveErrorMsg{Err: err} }
+	}
+
+	m.noteSave(nil)
+	if changed && !drafting && m.cfg.Journal.Snapshots {
+		if err := m.manager.Snapshot(m.entry); err != nil {
+			m.notify("Snapshot failed: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// finalSave saves the entry to storage when the session ends cleanly and
+// clears any draft left by autosave. Read-only sessions leave it untouched.
+func (m *model) finalSave(at time.Time) {
+	if m.writingModel.ReadOnly() {
+		return
+	}
+	m.entry.Content = m.writingModel.Value()
+	m.manager.RecordSample(m.entry, at)
+
+	m.noteSave(m.saveToStorage())
+	if m.saveErr == nil {
+		m.noteSave(m.manager.RemoveDraft(m.entry))
+	}
+}
+
+// noteSave records the outcome of a save. A failure raises an alert in the
+// status bar, and it stays there, counting the failures, until a save
+// succeeds: autosave retries every tick, and a problem that won't go away
+// shouldn't look like one that happened once.
+func (m *model) noteSave(err error) {
+	m.saveErr = err
+	if err == nil {
+		m.saveFails = 0
+		m.statusBarModel.SetAlert("")
+		return
+	}
+	m.saveFails++
+	alert := "Save failed: " + err.Error()
+	if m.saveFails > 1 {
+		alert = fmt.Sprintf("Save failed %d times in a row: %s", m.saveFails, err)
+	}
+	m.statusBarModel.SetAlert(alert)
+}
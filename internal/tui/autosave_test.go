@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"go.uber.org/zap"
+)
+
+// savedContent returns what the entry's file holds.
+func savedContent(t *testing.T, m model) string {
+	t.Helper()
+	entry, err := m.manager.ReadEntry(m.entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	return entry.Content
+}
+
+func TestSaveOnBlur(t *testing.T) {
+	m := newTestModel(t, nil, fakeClient{})
+	m = typeText(m, "fresh words")
+	m = press(m, "esc", "tab")
+	if m.focusedPane != conversationPane {
+		t.Fatal("tab didn't move to the conversation pane")
+	}
+	if got := savedContent(t, m); got != "fresh words" {
+		t.Fatalf("after tabbing away the file holds %q, want the new text", got)
+	}
+	if m.dirty() {
+		t.Error("still dirty after saving on blur")
+	}
+
+	// Bouncing between panes without editing doesn't save again
+	saved := m.entry.ModifiedAt
+	m = press(m, "tab", "tab")
+	if !m.entry.ModifiedAt.Equal(saved) {
+		t.Error("tabbing away from an unchanged buffer saved it again")
+	}
+}
+
+func TestSaveOnBlurDisabled(t *testing.T) {
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.Journal.SaveOnBlur = false
+	}, fakeClient{})
+	m = typeText(m, "fresh words")
+	m = press(m, "esc", "tab")
+	if got := savedContent(t, m); got != "" {
+		t.Errorf("with save_on_blur off the file holds %q", got)
+	}
+	if !m.dirty() {
+		t.Error("buffer no longer dirty though nothing was saved")
+	}
+}
+
+var errDiskFull = errors.New("disk full")
+
+// failingStore is a MemoryStore whose writes fail while fail is set.
+type failingStore struct {
+	*journal.MemoryStore
+	fail bool
+}
+
+func (s *failingStore) Write(path string, data []byte) error {
+	if s.fail {
+		return errDiskFull
+	}
+	return s.MemoryStore.Write(path, data)
+}
+
+// autosave delivers an autosave tick and then the messages its commands
+// produce, skipping the next tick.
+func autosave(m model) model {
+	updated, cmd := m.Update(autosaveTickMsg(time.Now()))
+	m = updated.(model)
+	for _, msg := range runCmd(cmd) {
+		if _, ok := msg.(AutosaveErrorMsg); ok {
+			updated, _ = m.Update(msg)
+			m = updated.(model)
+		}
+	}
+	return m
+}
+
+func TestAutosaveError(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Journal.StorageDir = "/journal"
+	cfg.Journal.AutosaveInterval = 0 // Ticks are sent by hand
+	store := &failingStore{MemoryStore: journal.NewMemoryStore()}
+	manager, err := journal.NewManager(cfg, zap.NewNop(), journal.WithStore(store))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	entry, err := manager.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	m := typeText(InitialModel(cfg, manager, entry, nil), "unsaved")
+
+	store.fail = true
+	_, cmd := m.Update(autosaveTickMsg(time.Now()))
+	var got []AutosaveErrorMsg
+	for _, msg := range runCmd(cmd) {
+		if msg, ok := msg.(AutosaveErrorMsg); ok {
+			got = append(got, msg)
+		}
+	}
+	if len(got) != 1 || !errors.Is(got[0].Err, errDiskFull) {
+		t.Fatalf("autosave reported %v, want one AutosaveErrorMsg", got)
+	}
+
+	m = autosave(m)
+	if !errors.Is(m.saveErr, errDiskFull) || m.statusBarModel.alert != "Save failed: "+m.saveErr.Error() {
+		t.Fatalf("saveErr = %v, alert = %q", m.saveErr, m.statusBarModel.alert)
+	}
+	m = autosave(m)
+	if want := "Save failed 2 times in a row: " + m.saveErr.Error(); m.statusBarModel.alert != want {
+		t.Errorf("alert = %q, want %q", m.statusBarModel.alert, want)
+	}
+
+	store.fail = false
+	m = autosave(m)
+	if m.saveErr != nil || m.statusBarModel.alert != "" {
+		t.Errorf("after a good save saveErr = %v, alert = %q", m.saveErr, m.statusBarModel.alert)
+	}
+	if got := savedContent(t, m); got != "unsaved" {
+		t.Errorf("saved content = %q", got)
+	}
+}
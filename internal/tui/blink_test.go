@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/charmbracelet/bubbles/cursor"
+)
+
+func TestCursorBlink(t *testing.T) {
+	for _, blink := range []bool{true, false} {
+		t.Run(fmt.Sprintf("blink=%v", blink), func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.UI.CursorBlink = blink
+			cfg.UI.CursorShapes = false // Leave only the blink to the commands
+			m := NewWritingModel(cfg)
+
+			if got := m.Init() != nil; got != blink {
+				t.Errorf("Init returned a command = %v, want %v", got, blink)
+			}
+			if got := m.Focus() != nil; got != blink {
+				t.Errorf("Focus returned a command = %v, want %v", got, blink)
+			}
+			wantMode := cursor.CursorBlink
+			if !blink {
+				wantMode = cursor.CursorStatic
+			}
+			if got := m.textarea.Cursor.Mode(); got != wantMode {
+				t.Errorf("cursor mode = %v, want %v", got, wantMode)
+			}
+
+			// Going back to insert mode starts the blink again, or doesn't
+			m, _ = m.Update(keyMsg("esc"))
+			_, cmd := m.Update(keyMsg("i"))
+			if got := cmd != nil; got != blink {
+				t.Errorf("i returned a command = %v, want %v", got, blink)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+)
+
+// markdownStyle returns the glamour style matching the UI theme, without
+// the document margin, since the pane has its own padding.
+func markdownStyle(theme string) ansi.StyleConfig {
+	style := styles.DarkStyleConfig
+	if theme == "light" {
+		style = styles.LightStyleConfig
+	}
+	var margin uint
+	style.Document.Margin = &margin
+	return style
+}
+
+// renderMarkdown renders an assistant reply as styled markdown wrapped to
+// width. ok is false if glamour can't render it, in which case the reply is
+// shown as plain text.
+func renderMarkdown(content, theme string, width int) (string, bool) {
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStyles(markdownStyle(theme)),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return "", false
+	}
+	out, err := r.Render(content)
+	if err != nil {
+		return "", false
+	}
+	return strings.Trim(out, "\n"), true
+}
+
+// renderMessages renders the assistant messages not rendered yet for the
+// current width. Glamour is too slow to run on every frame, so replies are
+// rendered once, when they arrive or the pane is resized, and View uses the
+// results; the answer still streaming in stays plain until it is complete.
+func (m *convoModel) renderMessages() {
+	if !m.markdown || m.width <= 0 {
+		return
+	}
+	if m.renderedWidth != m.width {
+		m.rendered = nil
+		m.renderedWidth = m.width
+	}
+	for i := len(m.rendered); i < len(m.messages); i++ {
+		var out string
+		if msg := m.messages[i]; msg.Role == journal.RoleAssistant {
+			out, _ = renderMarkdown(msg.Content, m.theme, m.width)
+		}
+		m.rendered = append(m.rendered, out) // "" shows the message as is
+	}
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const markdownReply = "Try these:\n\n- **Notice** what you felt\n- Write it down"
+
+// maxLineWidth returns the display width of the widest line of s.
+func maxLineWidth(s string) int {
+	widest := 0
+	for _, line := range strings.Split(s, "\n") {
+		widest = max(widest, lipgloss.Width(line))
+	}
+	return widest
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	for _, theme := range []string{"dark", "light"} {
+		t.Run(theme, func(t *testing.T) {
+			out, ok := renderMarkdown(markdownReply, theme, 30)
+			if !ok {
+				t.Fatal("renderMarkdown failed")
+			}
+			if strings.Contains(out, "**") || strings.Contains(out, "- Write") {
+				t.Errorf("markdown syntax left in the output:\n%s", out)
+			}
+			if strings.Count(out, "•") != 2 {
+				t.Errorf("want two bullets:\n%s", out)
+			}
+			if !strings.Contains(out, "\x1b[") {
+				t.Error("output isn't styled")
+			}
+			if w := maxLineWidth(out); w > 30 {
+				t.Errorf("output is %d wide, wider than 30", w)
+			}
+		})
+	}
+}
+
+func TestConvoMarkdown(t *testing.T) {
+	messages := []journal.Message{
+		{Role: journal.RoleUser, Content: "- my own list"},
+		{Role: journal.RoleAssistant, Content: markdownReply},
+	}
+
+	for _, markdown := range []bool{true, false} {
+		t.Run(fmt.Sprintf("markdown=%v", markdown), func(t *testing.T) {
+			m := newConvoModel(messages, markdown, "dark")
+			m.SetSize(40, 30)
+			view := m.View()
+
+			if got := strings.Count(view, "•"); got != map[bool]int{true: 2, false: 0}[markdown] {
+				t.Errorf("view has %d bullets:\n%s", got, view)
+			}
+			if !strings.Contains(view, "- my own list") {
+				t.Errorf("user message not shown as written:\n%s", view)
+			}
+			if got := strings.Contains(view, "**Notice**"); got == markdown {
+				t.Errorf("reply shown as written = %v:\n%s", got, view)
+			}
+			if w := maxLineWidth(view); w > 40 {
+				t.Errorf("view is %d wide, wider than the pane", w)
+			}
+		})
+	}
+}
+
+func TestConvoMarkdownResize(t *testing.T) {
+	m := newConvoModel(nil, true, "dark")
+	m.SetSize(60, 30)
+	m.AddMessage(journal.Message{Role: journal.RoleAssistant, Content: strings.Repeat("word ", 30)})
+	wide := m.View()
+
+	m.SetSize(20, 30)
+	narrow := m.View()
+	if w := maxLineWidth(narrow); w > 20 {
+		t.Errorf("after narrowing the view is %d wide", w)
+	}
+	if strings.Count(narrow, "\n") <= strings.Count(wide, "\n") {
+		t.Error("reply wasn't rewrapped for the narrower pane")
+	}
+}
+
+func TestConvoMarkdownScroll(t *testing.T) {
+	var messages []journal.Message
+	for i := range 6 {
+		messages = append(messages, journal.Message{Role: journal.RoleAssistant, Content: fmt.Sprintf("- point %d", i)})
+	}
+	m := newConvoModel(messages, true, "dark")
+	m.SetSize(40, 5)
+
+	newest := ansi.Strip(m.View())
+	if strings.Count(newest, "\n") != 4 || !strings.Contains(newest, "point 5") {
+		t.Fatalf("view isn't the last 5 lines:\n%s", newest)
+	}
+	m.scroll = 100 // Scrolled back past the oldest message
+	oldest := ansi.Strip(m.View())
+	if strings.Count(oldest, "\n") != 4 || !strings.Contains(oldest, "point 0") {
+		t.Errorf("scrolled back view isn't the first 5 lines:\n%s", oldest)
+	}
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// convoModel holds the state for the AI conversation pane.
+type convoModel struct {
+	width  int
+	height int
+
+	messages []journal.Message // The entry's conversation, oldest first
+	waiting  bool              // An answer from the AI is in flight
+	partial  string            // The answer streamed in so far
+	err      error             // Error from the most recent request, if any
+	notice   string            // Shown in place of an empty reply
+	scroll   int               // Lines scrolled back from the newest
+
+	markdown      bool     // Render assistant replies as markdown
+	theme         string   // UI theme the markdown is styled for
+	rendered      []string // Rendered replies by message index; "" for plain
+	renderedWidth int      // Width rendered was wrapped to
+}
+
+// newConvoModel creates the conversation pane, showing any earlier messages.
+// With markdown set, assistant replies are rendered as markdown styled for
+// theme.
+func newConvoModel(messages []journal.Message, markdown bool, theme string) convoModel {
+	return convoModel{messages: messages, markdown: markdown, theme: theme}
+}
+
+// SetSize updates the dimensions of the conversation pane.
+func (m *convoModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	m.renderMessages()
+}
+
+// StartWaiting marks an answer as in flight, clearing the outcome of the
+// previous request.
+func (m *convoModel) StartWaiting() {
+	m.waiting = true
+	m.err = nil
+	m.notice = ""
+}
+
+// AddMessage appends a message to the conversation.
+func (m *convoModel) AddMessage(msg journal.Message) {
+	m.messages = append(m.messages, msg)
+	m.scroll = 0 // Jump back to the newest message
+	m.renderMessages()
+}
+
+// Messages returns the conversation so far.
+func (m convoModel) Messages() []journal.Message {
+	return m.messages
+}
+
+// View renders the most recent messages that fit in the pane.
+func (m convoModel) View() string {
+	if len(m.messages) == 0 && !m.waiting && m.err == nil && m.notice == "" {
+		return lipgloss.NewStyle().Faint(true).Render("No conversation yet. [Ctrl+O] to pick a prompt.")
+	}
+
+	width := m.width
+	if width <= 0 {
+		width = 1
+	}
+	text := lipgloss.NewStyle().Width(width)
+	label := lipgloss.NewStyle().Bold(true)
+
+	var blocks []string
+	for i, msg := range m.messages {
+		body := text.Render(msg.Content)
+		if i < len(m.rendered) && m.rendered[i] != "" {
+			body = m.rendered[i]
+		}
+		blocks = append(blocks, label.Render(roleLabel(msg.Role)+":"), body, "")
+	}
+	switch {
+	case m.waiting && m.partial != "":
+		blocks = append(blocks, label.Render(roleLabel(journal.RoleAssistant)+":"), text.Render(m.partial))
+	case m.waiting:
+		blocks = append(blocks, lipgloss.NewStyle().Faint(true).Render("thinking..."))
+	case m.err != nil:
+		blocks = append(blocks, text.Render("request failed: "+m.err.Error()))
+	case m.notice != "":
+		blocks = append(blocks, text.Faint(true).Render(m.notice))
+	}
+
+	// Keep the newest lines in view, unless scrolled back
+	lines := strings.Split(strings.Join(blocks, "\n"), "\n")
+	if m.height > 0 && len(lines) > m.height {
+		end := len(lines) - min(m.scroll, len(lines)-m.height)
+		lines = lines[end-m.height : end]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roleLabel returns how a message role is shown in the pane.
+func roleLabel(role string) string {
+	if role == journal.RoleAssistant {
+		return "AI"
+	}
+	return "You"
+}
@@ -0,0 +1,65 @@
+package tui
+
+// cursorPos returns the cursor's logical row and column (in runes).
+func (m writingModel) cursorPos() (row, col int) {
+	li := m.textarea.LineInfo()
+	return m.textarea.Line(), li.StartColumn + li.ColumnOffset
+}
+
+// moveCursorTo places the cursor at the given logical row and column.
+func (m *writingModel) moveCursorTo(row, col int) {
+	for m.textarea.Line() > row {
+		m.textarea.CursorUp()
+	}
+	for m.textarea.Line() < row && m.textarea.Line() < m.textarea.LineCount()-1 {
+		m.textarea.CursorDown()
+	}
+	m.textarea.SetCursor(col)
+}
+
+// applyScrollOff keeps scrollOff lines of context visible above and below
+// the cursor. The textarea only scrolls to keep the cursor itself in view,
+// so we briefly move the cursor past the context lines, let the textarea
+// reposition its viewport, and then put the cursor back.
+func (m *writingModel) applyScrollOff() {
+	n := m.scrollOff
+	if maxOff := (m.textarea.Height() - 1) / 2; n > maxOff {
+		n = maxOff
+	}
+	if n <= 0 {
+		return
+	}
+
+	// The textarea ignores updates while blurred (normal mode)
+	focused := m.textarea.Focused()
+	if !focused {
+		m.textarea.Focus()
+	}
+
+	row, col := m.cursorPos()
+	for _, step := range []func(){m.textarea.CursorDown, m.textarea.CursorUp} {
+		for i := 0; i < n; i++ {
+			step()
+		}
+		m.textarea, _ = m.textarea.Update(nil) // Repositions the viewport
+		m.moveCursorTo(row, col)
+	}
+
+	if !focused {
+		m.textarea.Blur()
+	}
+}
+
+// refreshView scrolls the textarea so the cursor is visible after it was
+// moved directly. The textarea only repositions its viewport in Update,
+// which it ignores while blurred (normal mode).
+func (m *writingModel) refreshView() {
+	focused := m.textarea.Focused()
+	if !focused {
+		m.textarea.Focus()
+	}
+	m.textarea, _ = m.textarea.Update(nil)
+	if !focused {
+		m.textarea.Blur()
+	}
+}
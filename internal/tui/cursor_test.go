@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// numberedLines returns n lines "L00", "L01", ...
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("L%02d", i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var numberedLinePattern = regexp.MustCompile(`L(\d\d)`)
+
+// visibleLines returns the numbers of the numbered lines the pane shows.
+func visibleLines(m writingModel) map[int]bool {
+	visible := map[int]bool{}
+	for _, match := range numberedLinePattern.FindAllStringSubmatch(m.View(), -1) {
+		n, _ := strconv.Atoi(match[1])
+		visible[n] = true
+	}
+	return visible
+}
+
+// newScrollModel returns a pane of the given height holding lines numbered
+// lines, with the cursor at the top
+func newScrollModel(t *testing.T, scrollOff, lines, height int) writingModel {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.UI.ScrollOff = scrollOff
+	m := NewWritingModel(cfg)
+	m.SetSize(60, height)
+	m.SetValue(numberedLines(lines))
+	m.moveCursorTo(0, 0)
+	m.applyScrollOff()
+	return m
+}
+
+func TestScrollOff(t *testing.T) {
+	const lines = 40
+
+	tests := []struct {
+		name      string
+		scrollOff int
+		want      int // Context lines expected around the cursor
+	}{
+		{name: "three lines", scrollOff: 3, want: 3},
+		{name: "one line", scrollOff: 1, want: 1},
+		{name: "clamped to half the view", scrollOff: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newScrollModel(t, tt.scrollOff, lines, 12)
+			height := m.textarea.Height()
+			want := tt.want
+			if want == 0 {
+				want = (height - 1) / 2
+			}
+
+			moveTo := func(row int) {
+				t.Helper()
+				m.moveCursorTo(row, 0)
+				m.applyScrollOff()
+				visible := visibleLines(m)
+				for line := max(0, row-want); line <= min(lines-1, row+want); line++ {
+					if !visible[line] {
+						t.Fatalf("cursor on line %d: line %d out of view (shown %v)", row, line, visible)
+					}
+				}
+			}
+
+			for row := 0; row < lines; row++ {
+				moveTo(row)
+			}
+			if visible := visibleLines(m); !visible[lines-1] || visible[lines-1-height] {
+				t.Errorf("at the end of the buffer shown %v, want the last %d lines", visible, height)
+			}
+			for row := lines - 1; row >= 0; row-- {
+				moveTo(row)
+			}
+			if visible := visibleLines(m); !visible[0] || visible[height] {
+				t.Errorf("at the start of the buffer shown %v, want the first %d lines", visible, height)
+			}
+		})
+	}
+}
+
+func TestScrollOffDisabled(t *testing.T) {
+	m := newScrollModel(t, 0, 40, 12)
+	height := m.textarea.Height()
+	m.moveCursorTo(height-1, 0)
+	m.applyScrollOff()
+	// The cursor is on the last row in view, so nothing needs to scroll
+	if visible := visibleLines(m); !visible[0] || visible[height] {
+		t.Errorf("scrolloff 0 scrolled the view: shown %v", visible)
+	}
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cursorShape is a terminal cursor style set with the DECSCUSR sequence.
+type cursorShape int
+
+const (
+	cursorDefault cursorShape = 0 // The terminal's own default
+	cursorBlock   cursorShape = 2 // Steady block
+	cursorBar     cursorShape = 6 // Steady bar
+)
+
+// sequence returns the escape sequence that selects the shape.
+func (s cursorShape) sequence() string {
+	return fmt.Sprintf("\x1b[%d q", s)
+}
+
+// cursorShapeFor maps a writing mode to its cursor: a block in normal mode
+// and a bar in insert mode, like vim.
+func cursorShapeFor(mode writingMode) cursorShape {
+	if mode == modeInsert {
+		return cursorBar
+	}
+	return cursorBlock
+}
+
+// cursorShapesSupported reports whether the terminal is likely to
+// understand cursor shape sequences. The Linux console and dumb terminals
+// don't, so they keep the textarea's blink/static cursor.
+func cursorShapesSupported() bool {
+	switch os.Getenv("TERM") {
+	case "", "dumb", "linux":
+		return false
+	}
+	return true
+}
+
+// setCursorShape writes the shape's escape sequence to the terminal.
+func setCursorShape(shape cursorShape) tea.Cmd {
+	return func() tea.Msg {
+		fmt.Fprint(os.Stdout, shape.sequence())
+		return nil
+	}
+}
+
+// cursorShapeCmd updates the terminal cursor to match the current mode,
+// or does nothing when cursor shapes are disabled.
+func (m writingModel) cursorShapeCmd() tea.Cmd {
+	if !m.cursorShapes {
+		return nil
+	}
+	return setCursorShape(cursorShapeFor(m.mode))
+}
+
+// resetCursorShape restores the terminal's default cursor on exit.
+func (m writingModel) resetCursorShape() tea.Cmd {
+	if !m.cursorShapes {
+		return nil
+	}
+	return setCursorShape(cursorDefault)
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestCursorShapeFor(t *testing.T) {
+	tests := []struct {
+		mode writingMode
+		want cursorShape
+		seq  string
+	}{
+		{mode: modeNormal, want: cursorBlock, seq: "\x1b[2 q"},
+		{mode: modeInsert, want: cursorBar, seq: "\x1b[6 q"},
+	}
+	for _, tt := range tests {
+		got := cursorShapeFor(tt.mode)
+		if got != tt.want {
+			t.Errorf("cursorShapeFor(%v) = %v, want %v", tt.mode, got, tt.want)
+		}
+		if seq := got.sequence(); seq != tt.seq {
+			t.Errorf("mode %v: sequence %q, want %q", tt.mode, seq, tt.seq)
+		}
+	}
+	if seq := cursorDefault.sequence(); seq != "\x1b[0 q" {
+		t.Errorf("default cursor sequence %q, want %q", seq, "\x1b[0 q")
+	}
+}
+
+func TestCursorShapesSupported(t *testing.T) {
+	for term, want := range map[string]bool{
+		"":               false,
+		"dumb":           false,
+		"linux":          false,
+		"xterm-256color": true,
+		"screen":         true,
+	} {
+		t.Setenv("TERM", term)
+		if got := cursorShapesSupported(); got != want {
+			t.Errorf("TERM=%q: supported = %v, want %v", term, got, want)
+		}
+	}
+}
+
+func TestCursorShapeCmd(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	off := NewWritingModel(config.DefaultConfig())
+	if off.cursorShapeCmd() != nil || off.resetCursorShape() != nil {
+		t.Error("cursor shape commands sent with cursor_shapes off")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.UI.CursorShapes = true
+	on := NewWritingModel(cfg)
+	if on.cursorShapeCmd() == nil || on.resetCursorShape() == nil {
+		t.Error("no cursor shape commands with cursor_shapes on")
+	}
+
+	t.Setenv("TERM", "dumb")
+	if NewWritingModel(cfg).cursorShapeCmd() != nil {
+		t.Error("cursor shape command sent to a dumb terminal")
+	}
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDateInsert(t *testing.T) {
+	at := time.Date(2024, 3, 5, 9, 7, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		format string
+		normal bool
+		want   string
+	}{
+		{name: "default format", format: config.DefaultConfig().UI.DateInsertFormat, want: "Met 2024-03-05 09:07 at the cafe"},
+		{name: "custom format", format: "Monday, 3:04PM", want: "Met Tuesday, 9:07AM at the cafe"},
+		{name: "empty format", format: "", want: "Met  at the cafe"},
+		{name: "normal mode", format: "2006", normal: true, want: "Met  at the cafe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.UI.DateInsertFormat = tt.format
+			}, nil)
+			m.writingModel.now = func() time.Time { return at }
+
+			m = typeText(m, "Met ")
+			if tt.normal {
+				m = press(m, "esc")
+			}
+			updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+			m = updated.(model)
+			if tt.normal {
+				m = press(m, "i") // Back to typing
+			}
+			m = typeText(m, " at the cafe")
+
+			if got := m.writingModel.Value(); got != tt.want {
+				t.Errorf("value = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import "strings"
+
+// lineStart returns the offset of the first rune of the line containing off.
+func lineStart(text []rune, off int) int {
+	for off > 0 && text[off-1] != '\n' {
+		off--
+	}
+	return off
+}
+
+// lineEnd returns the offset of the line break ending the line containing
+// off, or the end of the buffer on the last line.
+func lineEnd(text []rune, off int) int {
+	for off < len(text) && text[off] != '\n' {
+		off++
+	}
+	return off
+}
+
+// isBlankLine reports whether text[start:end] holds only whitespace.
+func isBlankLine(text []rune, start, end int) bool {
+	return strings.TrimSpace(string(text[start:end])) == ""
+}
+
+// toLineEnd returns the range deleted by "d$": from the cursor to the end
+// of its line, leaving the line break.
+func toLineEnd(text []rune, off int) (start, end int) {
+	return off, lineEnd(text, off)
+}
+
+// toLineStart returns the range deleted by "d0": from the start of the line
+// up to, but not including, the cursor.
+func toLineStart(text []rune, off int) (start, end int) {
+	return lineStart(text, off), off
+}
+
+// wholeLines returns the range covering every line from the one containing
+// from to the one containing to, including one line break so no empty line
+// is left behind. This is the range of the linewise deletes "dd", "dG" and
+// "dgg".
+func wholeLines(text []rune, from, to int) (start, end int) {
+	if from > to {
+		from, to = to, from
+	}
+	start, end = lineStart(text, from), lineEnd(text, to)
+	switch {
+	case end < len(text):
+		end++ // Take the trailing line break
+	case start > 0:
+		start-- // Last line: take the line break before it instead
+	}
+	return start, end
+}
+
+// deleteRange removes the runes in [start, end), stores them in the
+// register, and leaves the cursor at start.
+func (m *writingModel) deleteRange(start, end int) {
+	text := []rune(m.textarea.Value())
+	if start < 0 {
+		start = 0
+	}
+	if end > len(text) {
+		end = len(text)
+	}
+	if start >= end {
+		return
+	}
+
+	m.register = string(text[start:end])
+	m.textarea.SetValue(string(text[:start]) + string(text[end:]))
+	m.updateWordCount()
+
+	// A linewise delete that took the line break before the last line
+	// lands on the start of the now last line, like vim
+	rest := []rune(m.textarea.Value())
+	if start > len(rest) {
+		start = len(rest)
+	}
+	if start > 0 && start == len(rest) {
+		start = lineStart(rest, start)
+	}
+	m.moveCursorToOffset(start)
+	m.refreshView()
+}
+
+// applyDelete deletes the range that op selects from the cursor.
+func (m *writingModel) applyDelete(op func(text []rune, off int) (start, end int)) {
+	text := []rune(m.textarea.Value())
+	m.deleteRange(op(text, m.cursorOffset()))
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// deleteBuffer is the text the delete tests start from.
+const deleteBuffer = "one two\nthree four\nfive six\nseven"
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		keys         string
+		off          int // Cursor offset before the delete
+		want         string
+		wantRegister string
+		wantOff      int // Cursor offset after it
+	}{
+		// "three four" starts at 8, "five six" at 19, "seven" at 28
+		{keys: "dd", off: 10, want: "one two\nfive six\nseven", wantRegister: "three four\n", wantOff: 8},
+		{keys: "dd", off: 30, want: "one two\nthree four\nfive six", wantRegister: "\nseven", wantOff: 19},
+		{keys: "dd", off: 0, want: "three four\nfive six\nseven", wantRegister: "one two\n", wantOff: 0},
+		{keys: "d$", off: 14, want: "one two\nthree \nfive six\nseven", wantRegister: "four", wantOff: 14},
+		{keys: "d$", off: 28, want: "one two\nthree four\nfive six\n", wantRegister: "seven", wantOff: 28},
+		{keys: "d0", off: 14, want: "one two\nfour\nfive six\nseven", wantRegister: "three ", wantOff: 8},
+		{keys: "d0", off: 8, want: deleteBuffer, wantOff: 8},
+		{keys: "dG", off: 10, want: "one two", wantRegister: "\nthree four\nfive six\nseven", wantOff: 0},
+		{keys: "dG", off: 0, want: "", wantRegister: deleteBuffer, wantOff: 0},
+		{keys: "dgg", off: 22, want: "seven", wantRegister: "one two\nthree four\nfive six\n", wantOff: 0},
+		{keys: "dgg", off: 30, want: "", wantRegister: deleteBuffer, wantOff: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s from %d", tt.keys, tt.off), func(t *testing.T) {
+			m := NewWritingModel(config.DefaultConfig())
+			m.SetSize(60, 20)
+			m.mode = modeNormal
+			m.SetValue(deleteBuffer)
+			m.moveCursorToOffset(tt.off)
+			for _, key := range tt.keys {
+				m, _ = m.Update(keyMsg(string(key)))
+			}
+
+			if got := m.Value(); got != tt.want {
+				t.Errorf("text %q, want %q", got, tt.want)
+			}
+			if m.register != tt.wantRegister {
+				t.Errorf("register %q, want %q", m.register, tt.wantRegister)
+			}
+			if got := m.cursorOffset(); got != tt.wantOff {
+				t.Errorf("cursor at %d, want %d", got, tt.wantOff)
+			}
+			if m.pendingKey != "" {
+				t.Errorf("%q left pending", m.pendingKey)
+			}
+		})
+	}
+}
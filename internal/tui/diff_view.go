@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// diffContextLines is how many unchanged lines are shown above the first
+// addition.
+const diffContextLines = 2
+
+// renderDiffView shows the current text with lines added since the last
+// save marked, starting just above the first addition. Removed lines are
+// left out; this is a glance at what's new, not a full diff.
+func renderDiffView(saved, current string, width, height int) string {
+	added := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	text := lipgloss.NewStyle().Width(max(width-2, 1))
+
+	var lines []string
+	first := -1
+	for _, line := range journal.DiffLines(saved, current) {
+		switch line.Op {
+		case journal.DiffAdded:
+			if first < 0 {
+				first = len(lines)
+			}
+			for _, wrapped := range strings.Split(text.Render(line.Text), "\n") {
+				lines = append(lines, added.Render("+ "+wrapped))
+			}
+		case journal.DiffEqual:
+			for _, wrapped := range strings.Split(text.Render(line.Text), "\n") {
+				lines = append(lines, "  "+wrapped)
+			}
+		}
+	}
+
+	// Fill the pane like the textarea does
+	pane := lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height)
+	header := lipgloss.NewStyle().Padding(0, 1).Render("[CHANGES]")
+	if first < 0 {
+		return pane.Render(header + "\n" + lipgloss.NewStyle().Faint(true).Render("No changes since the last save."))
+	}
+
+	start := max(first-diffContextLines, 0)
+	return pane.Render(header + "\n" + strings.Join(lines[start:], "\n"))
+}
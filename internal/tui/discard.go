@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// escDoubleTapWindow is how quickly the second Esc must follow the first.
+const escDoubleTapWindow = 500 * time.Millisecond
+
+// discardPrompt is shown in the status bar while awaiting confirmation.
+const discardPrompt = "Discard unsaved changes and quit? (y/n)"
+
+// registerEsc records an Esc press in normal mode and reports whether it
+// completed a double tap.
+func (m *model) registerEsc(at time.Time) bool {
+	if !m.lastEsc.IsZero() && at.Sub(m.lastEsc) <= escDoubleTapWindow {
+		m.lastEsc = time.Time{}
+		return true
+	}
+	m.lastEsc = at
+	return false
+}
+
+// handleDiscardConfirm resolves the discard prompt: "y" quits without
+// saving, anything else cancels.
+func (m model) handleDiscardConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmDiscard = false
+	m.statusBarModel.SetPrompt("")
+
+	if msg.String() == "y" {
+		return m, m.quitWithoutSaving()
+	}
+	return m, nil
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegisterEsc(t *testing.T) {
+	t0 := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		gaps  []time.Duration // Time since the previous Esc, the first from t0
+		wants []bool
+	}{
+		{name: "single Esc", gaps: []time.Duration{0}, wants: []bool{false}},
+		{name: "quick double tap", gaps: []time.Duration{0, 200 * time.Millisecond}, wants: []bool{false, true}},
+		{name: "at the window edge", gaps: []time.Duration{0, escDoubleTapWindow}, wants: []bool{false, true}},
+		{name: "too slow", gaps: []time.Duration{0, escDoubleTapWindow + time.Millisecond}, wants: []bool{false, false}},
+		{name: "slow then quick", gaps: []time.Duration{0, time.Second, 100 * time.Millisecond}, wants: []bool{false, false, true}},
+		{name: "a tap only completes one double tap", gaps: []time.Duration{0, 100 * time.Millisecond, 100 * time.Millisecond}, wants: []bool{false, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m model
+			at := t0
+			for i, gap := range tt.gaps {
+				at = at.Add(gap)
+				if got := m.registerEsc(at); got != tt.wants[i] {
+					t.Errorf("Esc %d: double tap = %v, want %v", i+1, got, tt.wants[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		wantQuit bool
+	}{
+		{name: "y discards and quits", answer: "y", wantQuit: true},
+		{name: "n keeps writing", answer: "n"},
+		{name: "anything else keeps writing", answer: "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, nil, nil)
+			// The first Esc leaves insert mode; the next two are the double tap
+			m = press(m, "a", "esc", "esc")
+			if m.confirmDiscard {
+				t.Fatal("one Esc in normal mode asked to discard")
+			}
+			m = press(m, "esc")
+			if !m.confirmDiscard || m.statusBarModel.prompt != discardPrompt {
+				t.Fatalf("confirmDiscard = %v, prompt = %q; want the discard prompt", m.confirmDiscard, m.statusBarModel.prompt)
+			}
+
+			m = press(m, tt.answer)
+			if m.confirmDiscard || m.statusBarModel.prompt != "" {
+				t.Errorf("prompt still open")
+			}
+			if m.quitting != tt.wantQuit || m.discarded != tt.wantQuit {
+				t.Errorf("quitting = %v, discarded = %v, want %v", m.quitting, m.discarded, tt.wantQuit)
+			}
+			saved, err := m.manager.ReadEntry(m.entry.FilePath)
+			if err != nil {
+				t.Fatalf("ReadEntry: %v", err)
+			}
+			if saved.Content != "" {
+				t.Errorf("saved %q, want nothing saved", saved.Content)
+			}
+			if got := m.writingModel.Value(); got != "a" {
+				t.Errorf("value = %q, want the text untouched", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// draftPrompt is shown in the status bar when an earlier session left a draft.
+const draftPrompt = "Unsaved draft found from an earlier session. Recover it? (y/n)"
+
+// offerDraft asks whether to recover the draft an earlier session left for
+// the entry, if it differs from the saved text. Read-only sessions never
+// write, so they have nothing to recover into.
+func (m *model) offerDraft() {
+	if m.writingModel.ReadOnly() {
+		return
+	}
+	draft, ok, err := m.manager.LoadDraft(m.entry)
+	if err != nil {
+		m.notify("Couldn't read draft: " + err.Error())
+		return
+	}
+	if !ok {
+		return
+	}
+	m.pendingDraft = &draft
+	m.statusBarModel.SetPrompt(draftPrompt)
+}
+
+// handleDraftConfirm resolves the draft prompt: "y" loads the draft into the
+// writing pane as unsaved changes, anything else keeps the saved text and
+// removes the draft.
+func (m model) handleDraftConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	draft := *m.pendingDraft
+	m.pendingDraft = nil
+	m.statusBarModel.SetPrompt("")
+
+	if msg.String() == "y" {
+		m.writingModel.SetValue(draft)
+		m.lastDrafted = draft // Already in the draft file
+		return m, nil
+	}
+	m.noteSave(m.manager.RemoveDraft(m.entry))
+	return m, nil
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// withDraftDir keeps autosaves in a draft directory.
+func withDraftDir(cfg *config.Config) {
+	cfg.Journal.DraftDir = "/drafts"
+}
+
+func TestBlurSavesToDraft(t *testing.T) {
+	m := newTestModel(t, withDraftDir, fakeClient{})
+	m = press(typeText(m, "unsaved words"), "esc", "tab")
+	if m.focusedPane != conversationPane {
+		t.Fatalf("focus on pane %v, want the conversation pane", m.focusedPane)
+	}
+	if m.saveErr != nil {
+		t.Fatalf("save failed: %v", m.saveErr)
+	}
+
+	saved, err := m.manager.ReadEntry(m.entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if saved.Content != "" {
+		t.Errorf("entry file content = %q, want it untouched until the final save", saved.Content)
+	}
+	draft, ok, err := m.manager.LoadDraft(saved)
+	if err != nil || !ok || draft != "unsaved words" {
+		t.Errorf("LoadDraft = %q, %v, %v; want the typed text", draft, ok, err)
+	}
+}
+
+func TestDraftRecovery(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		wantValue string
+		wantDraft bool
+	}{
+		{name: "y recovers the draft", key: "y", wantValue: "left behind", wantDraft: true},
+		{name: "n removes the draft", key: "n", wantValue: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Journal.StorageDir = "/journal"
+			cfg.LLM.Enabled = false
+			withDraftDir(cfg)
+			manager, err := journal.NewManager(cfg, zap.NewNop(), journal.WithStore(journal.NewMemoryStore()))
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			entry, err := manager.CreateEntry()
+			if err != nil {
+				t.Fatalf("CreateEntry: %v", err)
+			}
+			draft := *entry
+			draft.Content = "left behind"
+			if err := manager.SaveDraft(&draft); err != nil {
+				t.Fatalf("SaveDraft: %v", err)
+			}
+
+			m := InitialModel(cfg, manager, entry, nil)
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+			m = updated.(model)
+			if m.pendingDraft == nil {
+				t.Fatal("no draft recovery offered")
+			}
+
+			m = press(m, tt.key)
+			if m.pendingDraft != nil {
+				t.Errorf("draft prompt still pending")
+			}
+			if got := m.writingModel.Value(); got != tt.wantValue {
+				t.Errorf("value = %q, want %q", got, tt.wantValue)
+			}
+			if m.dirty() != tt.wantDraft {
+				t.Errorf("dirty = %v, want %v", m.dirty(), tt.wantDraft)
+			}
+			if _, ok, _ := manager.LoadDraft(entry); ok != tt.wantDraft {
+				t.Errorf("draft kept = %v, want %v", ok, tt.wantDraft)
+			}
+		})
+	}
+}
+
+func TestNoDraftOffer(t *testing.T) {
+	m := newTestModel(t, withDraftDir, nil)
+	if m.pendingDraft != nil {
+		t.Errorf("draft recovery offered without a draft")
+	}
+}
+
+// savedDraft returns the draft kept for m's entry, compared against the
+// entry as saved rather than as being written.
+func savedDraft(t *testing.T, m model) (string, bool) {
+	t.Helper()
+	saved, err := m.manager.ReadEntry(m.entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	draft, ok, err := m.manager.LoadDraft(saved)
+	if err != nil {
+		t.Fatalf("LoadDraft: %v", err)
+	}
+	return draft, ok
+}
+
+func TestUnchangedDraftNotRewritten(t *testing.T) {
+	tests := []struct {
+		name string
+		save func(m model) model // Saves the draft the way the path under test does
+	}{
+		{name: "autosave", save: func(m model) model {
+			updated, _ := m.Update(autosaveTickMsg(time.Now()))
+			return updated.(model)
+		}},
+		{name: "blur", save: func(m model) model {
+			return press(m, "tab", "tab") // Over to the conversation and back
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, withDraftDir, fakeClient{})
+			m = tt.save(press(typeText(m, "draft words"), "esc"))
+			if _, ok := savedDraft(t, m); !ok {
+				t.Fatal("changes weren't drafted")
+			}
+
+			// With the draft gone behind its back, a rewrite would bring it back
+			if err := m.manager.RemoveDraft(m.entry); err != nil {
+				t.Fatalf("RemoveDraft: %v", err)
+			}
+			m = tt.save(m)
+			if _, ok := savedDraft(t, m); ok {
+				t.Error("unchanged draft written again")
+			}
+			if !m.dirty() {
+				t.Error("drafted changes counted as saved to the entry")
+			}
+
+			m = tt.save(press(m, "i", "!", "esc"))
+			if draft, ok := savedDraft(t, m); !ok || draft != "draft words!" {
+				t.Errorf("LoadDraft = %q, %v after more changes", draft, ok)
+			}
+		})
+	}
+}
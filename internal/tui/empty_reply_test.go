@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestEmptyAnswer(t *testing.T) {
+	notice := config.DefaultConfig().LLM.EmptyReplyNotice
+	tests := []struct {
+		name       string
+		reply      string
+		notice     string
+		wantNotice string
+		wantReply  bool
+	}{
+		{name: "empty", reply: "", notice: notice, wantNotice: notice},
+		{name: "whitespace", reply: " \n\t", notice: notice, wantNotice: notice},
+		{name: "text", reply: "You sound tired.", notice: notice, wantReply: true},
+		{name: "notice off", reply: "", notice: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.LLM.PromptLibrary = map[string]string{"mood": "How do I feel?"}
+				cfg.LLM.EmptyReplyNotice = tt.notice
+			}, fakeClient{reply: tt.reply})
+			m = askLibraryPrompt(t, m, 0)
+
+			messages := m.convoModel.Messages()
+			if got := len(messages) == 2; got != tt.wantReply {
+				t.Fatalf("conversation has %d messages; want the reply added = %v", len(messages), tt.wantReply)
+			}
+			if m.convoModel.notice != tt.wantNotice {
+				t.Errorf("notice = %q, want %q", m.convoModel.notice, tt.wantNotice)
+			}
+			if tt.wantNotice != "" && !strings.Contains(m.convoModel.View(), "model returned no text") {
+				t.Errorf("pane doesn't show the notice:\n%s", m.convoModel.View())
+			}
+
+			saved, err := m.manager.LoadConversation(m.entry)
+			if err != nil {
+				t.Fatalf("LoadConversation: %v", err)
+			}
+			for _, msg := range saved {
+				if strings.TrimSpace(msg.Content) == "" {
+					t.Errorf("empty %s turn saved", msg.Role)
+				}
+			}
+		})
+	}
+}
+
+func TestEmptyAnswerNoticeCleared(t *testing.T) {
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.LLM.PromptLibrary = map[string]string{"mood": "How do I feel?"}
+	}, fakeClient{})
+	m = askLibraryPrompt(t, m, 0)
+	if m.convoModel.notice == "" {
+		t.Fatal("no notice for the empty reply")
+	}
+
+	// Asking again clears it while the next answer is awaited
+	m.llmClient = fakeClient{reply: "Rested, finally."}
+	m = askLibraryPrompt(t, m, 0)
+	if m.convoModel.notice != "" {
+		t.Errorf("notice = %q after a real reply", m.convoModel.notice)
+	}
+	if strings.Contains(m.convoModel.View(), "model returned no text") {
+		t.Error("old notice still shown")
+	}
+}
+
+func TestEmptySuggestion(t *testing.T) {
+	notice := config.DefaultConfig().LLM.EmptyReplyNotice
+	tests := []struct {
+		name    string
+		reply   string
+		wantErr string
+	}{
+		{name: "empty", reply: "", wantErr: notice},
+		{name: "whitespace", reply: "  \n", wantErr: notice},
+		{name: "text", reply: " and then"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := suggest(t, newTestModel(t, nil, fakeClient{reply: tt.reply}), "I went")
+			err := m.writingModel.suggestionErr
+			if tt.wantErr == "" {
+				if err != nil || m.writingModel.suggestion != tt.reply {
+					t.Errorf("suggestion = %q, %v; want %q", m.writingModel.suggestion, err, tt.reply)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("suggestion error = %v, want %q", err, tt.wantErr)
+			}
+			if m.writingModel.suggestion != "" {
+				t.Errorf("blank suggestion %q kept as ghost text", m.writingModel.suggestion)
+			}
+		})
+	}
+}
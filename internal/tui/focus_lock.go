@@ -0,0 +1,20 @@
+package tui
+
+import "fmt"
+
+// focusLocked reports whether focus lock keeps the writer in the writing
+// pane: pane switches and quitting wait until the entry reaches its goal.
+func (m model) focusLocked() bool {
+	if !m.cfg.UI.FocusLock || m.writingModel.ReadOnly() {
+		return false
+	}
+	current, target := m.progress()
+	return current < target
+}
+
+// remindFocusLock explains in the status bar why a key was ignored.
+func (m *model) remindFocusLock() {
+	current, target := m.progress()
+	m.statusBarModel.SetPrompt(fmt.Sprintf("Focus lock: %d more %s to go. [Ctrl+Q] forces quit.", target-current, m.manager.Goal().Unit()))
+	m.transientPrompt = true
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// newFocusLockModel opens an entry with a three word goal and focus lock
+// on, with the AI pane available to tab to.
+func newFocusLockModel(t *testing.T) model {
+	t.Helper()
+	return newTestModel(t, func(cfg *config.Config) {
+		cfg.Journal.WordCountGoal = 3
+		cfg.UI.FocusLock = true
+	}, fakeClient{})
+}
+
+func TestFocusLock(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		blocked func(m model) bool
+	}{
+		{name: "tab", keys: []string{"tab"}, blocked: func(m model) bool { return m.focusedPane == writingPane }},
+		{name: "q", keys: []string{"esc", "q"}, blocked: func(m model) bool { return !m.quitting }},
+		{name: "ctrl+c", keys: []string{"ctrl+c"}, blocked: func(m model) bool { return !m.quitting }},
+		{name: "esc esc", keys: []string{"esc", "esc", "esc"}, blocked: func(m model) bool { return !m.confirmDiscard }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" while locked", func(t *testing.T) {
+			m := press(typeText(newFocusLockModel(t), "one two"), tt.keys...)
+			if !tt.blocked(m) {
+				t.Fatal("key worked below the goal")
+			}
+			if got := m.statusBarModel.prompt; !strings.HasPrefix(got, "Focus lock: 1 more") {
+				t.Errorf("prompt = %q, want the focus lock reminder", got)
+			}
+		})
+
+		t.Run(tt.name+" at the goal", func(t *testing.T) {
+			m := press(typeText(newFocusLockModel(t), "one two t"), tt.keys...)
+			if tt.blocked(m) {
+				t.Error("key still ignored once the goal was reached")
+			}
+		})
+	}
+}
+
+func TestFocusLockForceQuit(t *testing.T) {
+	m := press(typeText(newFocusLockModel(t), "one"), "ctrl+q")
+	if !m.quitting {
+		t.Fatal("ctrl+q didn't get past focus lock")
+	}
+	saved, err := m.manager.ReadEntry(m.entry.FilePath)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if saved.Content != "one" {
+		t.Errorf("saved content = %q", saved.Content)
+	}
+}
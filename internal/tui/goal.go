@@ -0,0 +1,57 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Journal.GoalMode values.
+const (
+	goalModeSoft = "soft" // Reaching the goal just marks the entry complete
+	goalModeHard = "hard" // Reaching the goal offers to finish the session
+)
+
+// finishPrompt is shown in the status bar when a hard goal is reached.
+const finishPrompt = "Goal reached! Save and finish the session? (y/n)"
+
+// progress measures the entry as it stands in the writing pane, which may
+// be ahead of the last save.
+func (m model) progress() (current, target int) {
+	entry := *m.entry
+	entry.Content = m.writingModel.Value()
+	entry.WordCount = m.writingModel.WordCount()
+	return m.manager.Progress(&entry)
+}
+
+// checkGoalReached offers to finish the session when an edit, or time
+// passing, takes progress from below the goal to the goal in hard goal
+// mode. With Journal.AutoQuitOnComplete, in either mode, it starts the
+// countdown to quitting instead. Either happens once per session, so
+// declining lets writing carry on.
+func (m *model) checkGoalReached(before int) tea.Cmd {
+	autoQuit := m.cfg.Journal.AutoQuitOnComplete
+	if (m.cfg.Journal.GoalMode != goalModeHard && !autoQuit) || m.goalOffered || m.writingModel.ReadOnly() {
+		return nil
+	}
+	current, target := m.progress()
+	if target <= 0 || before >= target || current < target {
+		return nil
+	}
+
+	m.goalOffered = true
+	if autoQuit {
+		return m.startAutoQuit()
+	}
+	m.confirmFinish = true
+	m.statusBarModel.SetPrompt(finishPrompt)
+	return nil
+}
+
+// handleFinishConfirm resolves the finish prompt: "y" saves and quits,
+// anything else goes back to writing.
+func (m model) handleFinishConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmFinish = false
+	m.statusBarModel.SetPrompt("")
+
+	if msg.String() != "y" {
+		return m, nil
+	}
+	return m, m.saveAndQuit()
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// newGoalModel opens an entry with a three word goal in the given goal
+// mode.
+func newGoalModel(t *testing.T, mode string) model {
+	t.Helper()
+	return newTestModel(t, func(cfg *config.Config) {
+		cfg.Journal.WordCountGoal = 3
+		cfg.Journal.GoalMode = mode
+	}, nil)
+}
+
+func TestGoalModeOnCrossing(t *testing.T) {
+	tests := []struct {
+		mode      string
+		wantOffer bool
+	}{
+		{mode: goalModeSoft},
+		{mode: goalModeHard, wantOffer: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			m := typeText(newGoalModel(t, tt.mode), "one two")
+			if m.confirmFinish {
+				t.Fatal("finish offered below the goal")
+			}
+
+			m = typeText(m, " t") // The third word counts as soon as it starts
+			if m.confirmFinish != tt.wantOffer {
+				t.Errorf("confirmFinish = %v, want %v", m.confirmFinish, tt.wantOffer)
+			}
+			if got := m.statusBarModel.prompt; (got == finishPrompt) != tt.wantOffer {
+				t.Errorf("prompt = %q", got)
+			}
+			if m.quitting {
+				t.Error("quit without an answer")
+			}
+		})
+	}
+}
+
+func TestFinishPrompt(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		wantQuit  bool
+		wantSaved string
+	}{
+		{name: "y saves and quits", key: "y", wantQuit: true, wantSaved: "one two t"},
+		{name: "n keeps writing", key: "n", wantSaved: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typeText(newGoalModel(t, goalModeHard), "one two t")
+			if !m.confirmFinish {
+				t.Fatal("finish not offered at the goal")
+			}
+
+			m = press(m, tt.key)
+			if m.confirmFinish || m.statusBarModel.prompt != "" {
+				t.Errorf("prompt still open: %q", m.statusBarModel.prompt)
+			}
+			if m.quitting != tt.wantQuit {
+				t.Errorf("quitting = %v, want %v", m.quitting, tt.wantQuit)
+			}
+			saved, err := m.manager.ReadEntry(m.entry.FilePath)
+			if err != nil {
+				t.Fatalf("ReadEntry: %v", err)
+			}
+			if saved.Content != tt.wantSaved {
+				t.Errorf("saved content = %q, want %q", saved.Content, tt.wantSaved)
+			}
+		})
+	}
+}
+
+func TestFinishOfferedOnce(t *testing.T) {
+	m := typeText(newGoalModel(t, goalModeHard), "one two t")
+	m = press(m, "n")
+
+	// Declining lets writing carry on past the goal without asking again
+	m = typeText(m, "hree four")
+	if m.confirmFinish {
+		t.Error("finish offered a second time")
+	}
+	if got := m.writingModel.Value(); got != "one two three four" {
+		t.Errorf("value = %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// idleTickMsg fires when the UI may have been idle long enough to dim.
+type idleTickMsg time.Time
+
+// dimAfter returns how long the UI waits without input before dimming, or
+// zero if dimming is disabled.
+func (m model) dimAfter() time.Duration {
+	return time.Duration(m.cfg.UI.DimAfter) * time.Second
+}
+
+// idleTick schedules the next idle check after d.
+func (m model) idleTick(d time.Duration) tea.Cmd {
+	if m.dimAfter() <= 0 {
+		return nil
+	}
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return idleTickMsg(t)
+	})
+}
+
+// registerInput restores full brightness and restarts the idle countdown.
+func (m *model) registerInput(at time.Time) {
+	m.lastInput = at
+	m.dimmed = false
+}
+
+// handleIdleTick dims the UI once there has been no input for the dim
+// period, and otherwise checks again when the period would next run out.
+func (m *model) handleIdleTick(at time.Time) tea.Cmd {
+	d := m.dimAfter()
+	idle := at.Sub(m.lastInput)
+	if idle >= d {
+		m.dimmed = true
+		return m.idleTick(d)
+	}
+	return m.idleTick(d - idle)
+}
+
+// paneStyles returns the styles for unfocused and focused panes, toned down
+// while the UI is dimmed.
+func (m model) paneStyles() (pane, focused lipgloss.Style) {
+	if !m.dimmed {
+		return m.paneStyle, m.focusedStyle
+	}
+	dim := themeFor(m.cfg.UI.Theme).dimBorder
+	return m.paneStyle.BorderForeground(dim).Faint(true), m.focusedStyle.BorderForeground(dim)
+}
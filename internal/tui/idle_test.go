@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+// idleAfter returns a model that dims after a minute, last touched at start.
+func idleAfter(t *testing.T, start time.Time) model {
+	t.Helper()
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.UI.DimAfter = 60
+	}, nil)
+	m.lastInput = start
+	return m
+}
+
+// tick delivers an idle tick at at.
+func tick(m model, at time.Time) model {
+	updated, _ := m.Update(idleTickMsg(at))
+	return updated.(model)
+}
+
+func TestIdleDimming(t *testing.T) {
+	start := time.Now()
+	m := idleAfter(t, start)
+
+	m = tick(m, start.Add(30*time.Second))
+	if m.dimmed {
+		t.Fatal("dimmed after 30s of a 60s period")
+	}
+	if cmd := m.handleIdleTick(start.Add(30 * time.Second)); cmd == nil {
+		t.Error("no tick scheduled for the rest of the period")
+	}
+
+	m = tick(m, start.Add(60*time.Second))
+	if !m.dimmed {
+		t.Fatal("not dimmed after the full period")
+	}
+
+	// A key press wakes the UI and starts the countdown again
+	m = press(m, "l")
+	if m.dimmed {
+		t.Fatal("still dimmed after a key press")
+	}
+	if !m.lastInput.After(start) {
+		t.Errorf("lastInput = %v, want it reset by the key press", m.lastInput)
+	}
+	m = tick(m, m.lastInput.Add(59*time.Second))
+	if m.dimmed {
+		t.Error("dimmed before a full period since the key press")
+	}
+	m = tick(m, m.lastInput.Add(61*time.Second))
+	if !m.dimmed {
+		t.Error("not dimmed again after another idle period")
+	}
+}
+
+func TestIdleDimmingDisabled(t *testing.T) {
+	m := newTestModel(t, nil, nil)
+	if cmd := m.idleTick(time.Second); cmd != nil {
+		t.Error("idle tick scheduled with dim_after 0")
+	}
+}
+
+func TestDimmedPaneStyles(t *testing.T) {
+	withColor(t)
+	m := idleAfter(t, time.Now())
+
+	pane, focused := m.paneStyles()
+	if pane.GetBorderTopForeground() != m.paneStyle.GetBorderTopForeground() || pane.GetFaint() {
+		t.Error("pane styles changed while not dimmed")
+	}
+	if focused.GetBorderTopForeground() != m.focusedStyle.GetBorderTopForeground() {
+		t.Error("focused style changed while not dimmed")
+	}
+
+	m.dimmed = true
+	dim := themeFor(m.cfg.UI.Theme).dimBorder
+	pane, focused = m.paneStyles()
+	if pane.GetBorderTopForeground() != dim || !pane.GetFaint() {
+		t.Errorf("dimmed pane border = %v, faint = %v; want %v, faint", pane.GetBorderTopForeground(), pane.GetFaint(), dim)
+	}
+	if focused.GetBorderTopForeground() != dim {
+		t.Errorf("dimmed focused border = %v, want %v", focused.GetBorderTopForeground(), dim)
+	}
+}
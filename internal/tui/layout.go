@@ -0,0 +1,63 @@
+package tui
+
+// Conversation pane positions accepted by UI.ConvoPosition.
+const (
+	convoLeft   = "left"
+	convoRight  = "right"
+	convoBottom = "bottom"
+)
+
+// minPaneSize is the smallest width (or height, when stacked) of a pane.
+const minPaneSize = 10
+
+// sidebarMaxWidth is the widest the entry sidebar gets.
+const sidebarMaxWidth = 32
+
+// rect is the outer size of a pane, including its border.
+type rect struct {
+	width  int
+	height int
+}
+
+// computeLayout splits the available area between the writing and
+// conversation panes. Left/right split horizontally 65/35; bottom stacks
+// the conversation under the writing pane with the same ratio.
+func computeLayout(position string, width, height int) (writing, convo rect) {
+	if position == convoBottom {
+		writingHeight, convoHeight := splitSize(height)
+		return rect{width, writingHeight}, rect{width, convoHeight}
+	}
+
+	writingWidth, convoWidth := splitSize(width)
+	return rect{writingWidth, height}, rect{convoWidth, height}
+}
+
+// splitSize divides total 65/35, keeping both parts at least minPaneSize.
+func splitSize(total int) (primary, secondary int) {
+	primary = int(float64(total) * 0.65)
+	// Ensure minimum size or handle edge cases if necessary
+	if primary < minPaneSize {
+		primary = minPaneSize
+	}
+	secondary = total - primary
+	if secondary < minPaneSize {
+		secondary = minPaneSize
+		// Adjust the primary size if the secondary hits the minimum
+		primary = total - secondary
+	}
+	return primary, secondary
+}
+
+// splitSidebar takes the entry sidebar's outer width off the left of the
+// window when it is shown, up to a third of the width, and returns what is
+// left for the panes. A window too narrow for it leaves the sidebar out.
+func splitSidebar(shown bool, width int) (sidebar, rest int) {
+	if !shown {
+		return 0, width
+	}
+	sidebar = min(sidebarMaxWidth, width/3)
+	if sidebar < minPaneSize {
+		return 0, width
+	}
+	return sidebar, width - sidebar
+}
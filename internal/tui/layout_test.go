@@ -0,0 +1,53 @@
+package tui
+
+import "testing"
+
+func TestComputeLayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		position    string
+		width       int
+		height      int
+		wantWriting rect
+		wantConvo   rect
+	}{
+		{name: "right splits width", position: convoRight, width: 100, height: 40, wantWriting: rect{65, 40}, wantConvo: rect{35, 40}},
+		{name: "left splits width the same", position: convoLeft, width: 100, height: 40, wantWriting: rect{65, 40}, wantConvo: rect{35, 40}},
+		{name: "bottom splits height", position: convoBottom, width: 100, height: 40, wantWriting: rect{100, 26}, wantConvo: rect{100, 14}},
+		{name: "rounds writing down", position: convoRight, width: 81, height: 24, wantWriting: rect{52, 24}, wantConvo: rect{29, 24}},
+		{name: "narrow keeps convo at minimum", position: convoRight, width: 25, height: 24, wantWriting: rect{15, 24}, wantConvo: rect{10, 24}},
+		{name: "too narrow for both keeps convo", position: convoRight, width: 12, height: 24, wantWriting: rect{2, 24}, wantConvo: rect{10, 24}},
+		{name: "short bottom keeps minimums", position: convoBottom, width: 80, height: 20, wantWriting: rect{80, 10}, wantConvo: rect{80, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writing, convo := computeLayout(tt.position, tt.width, tt.height)
+			if writing != tt.wantWriting || convo != tt.wantConvo {
+				t.Errorf("computeLayout(%q, %d, %d) = %v, %v, want %v, %v",
+					tt.position, tt.width, tt.height, writing, convo, tt.wantWriting, tt.wantConvo)
+			}
+		})
+	}
+}
+
+func TestSplitSidebar(t *testing.T) {
+	tests := []struct {
+		shown       bool
+		width       int
+		wantSidebar int
+		wantRest    int
+	}{
+		{shown: false, width: 120, wantSidebar: 0, wantRest: 120},
+		{shown: true, width: 120, wantSidebar: sidebarMaxWidth, wantRest: 120 - sidebarMaxWidth},
+		{shown: true, width: 60, wantSidebar: 20, wantRest: 40},
+		{shown: true, width: 29, wantSidebar: 0, wantRest: 29},
+	}
+
+	for _, tt := range tests {
+		sidebar, rest := splitSidebar(tt.shown, tt.width)
+		if sidebar != tt.wantSidebar || rest != tt.wantRest {
+			t.Errorf("splitSidebar(%v, %d) = %d, %d, want %d, %d", tt.shown, tt.width, sidebar, rest, tt.wantSidebar, tt.wantRest)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+)
+
+// lineCountWidth is the width of the running word count gutter, enough for
+// five digits and the separator.
+const lineCountWidth = 8
+
+// lineCountGutter holds the running word count shown beside each line. The
+// textarea calls its prompt function while rendering, so the labels are
+// shared through a pointer and rebuilt only when the text or width changes.
+type lineCountGutter struct {
+	labels []string // Gutter text for each display (wrapped) row
+	value  string   // Text the labels were built for
+	width  int      // Textarea width the labels were built for
+}
+
+// cumulativeWordCounts returns the running word count at the end of each
+// line.
+func cumulativeWordCounts(lines []string, count func(string) int) []int {
+	totals := make([]int, len(lines))
+	sum := 0
+	for i, line := range lines {
+		sum += count(line)
+		totals[i] = sum
+	}
+	return totals
+}
+
+// gutterLabels lays the totals out over display rows: each line's total is
+// shown on its first row, and wrapped continuation rows are left blank.
+func gutterLabels(totals, rowsPerLine []int) []string {
+	var labels []string
+	for i, total := range totals {
+		labels = append(labels, fmt.Sprintf("%*d ┃ ", lineCountWidth-3, total))
+		for r := 1; r < rowsPerLine[i]; r++ {
+			labels = append(labels, strings.Repeat(" ", lineCountWidth-2)+"┃ ")
+		}
+	}
+	return labels
+}
+
+// displayRows returns how many rows each line of the textarea wraps onto.
+// It walks a copy of the textarea, which only moves the copy's cursor.
+func displayRows(ta textarea.Model) []int {
+	rows := make([]int, ta.LineCount())
+	for ta.Line() > 0 {
+		ta.CursorUp()
+	}
+
+	steps := 0
+	for i := range rows {
+		rows[i] = max(ta.LineInfo().Height, 1)
+		if i == len(rows)-1 {
+			break
+		}
+		for ta.Line() == i && steps <= writingMaxLines*rows[i] {
+			ta.CursorDown()
+			steps++
+		}
+	}
+	return rows
+}
+
+// label returns the gutter text for a display row.
+func (g *lineCountGutter) label(row int) string {
+	if row < len(g.labels) {
+		return g.labels[row]
+	}
+	return strings.Repeat(" ", lineCountWidth-2) + "┃ "
+}
+
+// enableLineCounts adds the running word count gutter; installGutter then
+// puts it in place of the textarea prompt.
+func (m *writingModel) enableLineCounts() {
+	m.lineCounts = &lineCountGutter{}
+}
+
+// updateLineCounts rebuilds the gutter after the text or width changed.
+func (m *writingModel) updateLineCounts() {
+	if m.lineCounts == nil {
+		return
+	}
+	value, width := m.textarea.Value(), m.textarea.Width()
+	if value == m.lineCounts.value && width == m.lineCounts.width && m.lineCounts.labels != nil {
+		return
+	}
+	m.lineCounts.value, m.lineCounts.width = value, width
+
+	totals := cumulativeWordCounts(strings.Split(value, "\n"), m.countWords)
+	m.lineCounts.labels = gutterLabels(totals, displayRows(m.textarea))
+}
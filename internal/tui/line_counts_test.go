@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+)
+
+func TestCumulativeWordCounts(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int
+	}{
+		{name: "empty", text: "", want: []int{0}},
+		{name: "one line", text: "one two three", want: []int{3}},
+		{name: "running totals", text: "one two\nthree\nfour five six", want: []int{2, 3, 6}},
+		{name: "blank lines carry the total", text: "one\n\n\ntwo", want: []int{1, 1, 1, 2}},
+		{name: "trailing newline", text: "one two\n", want: []int{2, 2}},
+		{name: "punctuation alone", text: "word\n—\nword", want: []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		got := cumulativeWordCounts(strings.Split(tt.text, "\n"), journal.CountWords)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGutterLabels(t *testing.T) {
+	got := gutterLabels([]int{2, 3, 12345}, []int{1, 2, 1})
+	want := []string{
+		"    2 ┃ ",
+		"    3 ┃ ",
+		"      ┃ ", // Wrapped continuation of the second line
+		"12345 ┃ ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// gutterRows returns the first n rows of the textarea as rendered, trimmed.
+func gutterRows(m writingModel, n int) []string {
+	lines := strings.Split(m.View(), "\n")[1:] // Below the mode indicator
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = strings.TrimSpace(lines[i])
+	}
+	return rows
+}
+
+func TestLineCountGutter(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.UI.LineWordCounts = true
+	cfg.UI.LineNumbers = "none" // Keep the text right beside the counts
+	m := NewWritingModel(cfg)
+	m.SetSize(40, 10)
+
+	counts := 0
+	count := m.countWords
+	m.countWords = func(s string) int {
+		counts++
+		return count(s)
+	}
+
+	m.SetValue("one two\nthree\n\nfour five six")
+	want := []string{"2 ┃ one two", "3 ┃ three", "3 ┃", "6 ┃ four five six", "┃"}
+	if got := gutterRows(m, len(want)); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows %q, want %q", got, want)
+	}
+
+	// Unchanged text and width aren't counted again
+	counts = 0
+	m.updateLineCounts()
+	m.View()
+	if counts != 0 {
+		t.Errorf("recounted %d lines without a change", counts)
+	}
+
+	m.SetValue("just one line")
+	want = []string{"3 ┃ just one line", "┃"}
+	if got := gutterRows(m, len(want)); !reflect.DeepEqual(got, want) {
+		t.Errorf("after an edit rows %q, want %q", got, want)
+	}
+}
+
+func TestLineCountGutterOff(t *testing.T) {
+	m := NewWritingModel(config.DefaultConfig())
+	m.SetSize(40, 10)
+	m.SetValue("one two")
+	if m.lineCounts != nil || strings.Contains(m.View(), "2 ┃") {
+		t.Error("running word counts shown with ui.line_word_counts off")
+	}
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UI.LineNumbers values.
+const (
+	lineNumbersNone     = "none"
+	lineNumbersAbsolute = "absolute"
+	lineNumbersRelative = "relative"
+)
+
+// relNumberWidth is the width of the relative line number column.
+const relNumberWidth = 5
+
+// gutterSeparator ends the gutter, like the textarea's own prompt.
+const gutterSeparator = "┃ "
+
+// lineNumberGutter holds what the relative line numbers are drawn from.
+// Like lineCountGutter it is shared through a pointer with the textarea's
+// prompt function.
+type lineNumberGutter struct {
+	rowLines []int  // Line shown on each display row; -1 for wrapped continuation rows
+	cursor   int    // Line the cursor is on, as of the last render
+	value    string // Text the rows were built for
+	width    int    // Textarea width the rows were built for
+}
+
+// relativeNumber returns the number vim's relativenumber shows for line:
+// its distance from the cursor line, and its own number on the cursor line.
+func relativeNumber(line, cursor int) int {
+	if line == cursor {
+		return line + 1
+	}
+	if line > cursor {
+		return line - cursor
+	}
+	return cursor - line
+}
+
+// rowLines maps display rows back to the lines they show, marking wrapped
+// continuation rows with -1.
+func rowLines(rowsPerLine []int) []int {
+	var rows []int
+	for line, n := range rowsPerLine {
+		rows = append(rows, line)
+		for r := 1; r < n; r++ {
+			rows = append(rows, -1)
+		}
+	}
+	return rows
+}
+
+// label returns the relative number column for a display row.
+func (g *lineNumberGutter) label(row int) string {
+	if row >= len(g.rowLines) || g.rowLines[row] < 0 {
+		return strings.Repeat(" ", relNumberWidth)
+	}
+	return fmt.Sprintf("%*d ", relNumberWidth-1, relativeNumber(g.rowLines[row], g.cursor))
+}
+
+// setLineNumbers picks between the textarea's own absolute numbers, no
+// numbers, and the relative number gutter.
+func (m *writingModel) setLineNumbers(style string) {
+	m.textarea.ShowLineNumbers = style == lineNumbersAbsolute || style == ""
+	if style == lineNumbersRelative {
+		m.lineNumbers = &lineNumberGutter{}
+	}
+}
+
+// installGutter replaces the textarea prompt with the relative line numbers
+// and running word counts, whichever are on.
+func (m *writingModel) installGutter() {
+	numbers, counts := m.lineNumbers, m.lineCounts
+	if numbers == nil && counts == nil {
+		return
+	}
+
+	width := lineCountWidth
+	if counts == nil {
+		width = len([]rune(gutterSeparator))
+	}
+	if numbers != nil {
+		width += relNumberWidth
+	}
+	m.textarea.SetPromptFunc(width, func(row int) string {
+		var b strings.Builder
+		if numbers != nil {
+			b.WriteString(numbers.label(row))
+		}
+		if counts != nil {
+			b.WriteString(counts.label(row))
+		} else {
+			b.WriteString(gutterSeparator)
+		}
+		return b.String()
+	})
+}
+
+// updateLineNumbers rebuilds the row map after the text or width changed.
+func (m *writingModel) updateLineNumbers() {
+	if m.lineNumbers == nil {
+		return
+	}
+	value, width := m.textarea.Value(), m.textarea.Width()
+	if value == m.lineNumbers.value && width == m.lineNumbers.width && m.lineNumbers.rowLines != nil {
+		return
+	}
+	m.lineNumbers.value, m.lineNumbers.width = value, width
+	m.lineNumbers.rowLines = rowLines(displayRows(m.textarea))
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRelativeNumber(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   int
+		cursor int
+		want   int
+	}{
+		{name: "cursor line shows its own number", line: 4, cursor: 4, want: 5},
+		{name: "cursor on first line", line: 0, cursor: 0, want: 1},
+		{name: "line above", line: 3, cursor: 4, want: 1},
+		{name: "far above", line: 0, cursor: 9, want: 9},
+		{name: "line below", line: 5, cursor: 4, want: 1},
+		{name: "far below", line: 20, cursor: 4, want: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeNumber(tt.line, tt.cursor); got != tt.want {
+				t.Errorf("relativeNumber(%d, %d) = %d, want %d", tt.line, tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineNumberLabels(t *testing.T) {
+	// Line 1 wraps onto a second row; the cursor is on line 2
+	g := &lineNumberGutter{rowLines: rowLines([]int{1, 2, 1}), cursor: 2}
+	if want := []int{0, 1, -1, 2}; !slices.Equal(g.rowLines, want) {
+		t.Fatalf("rowLines = %v, want %v", g.rowLines, want)
+	}
+
+	want := []string{"   2 ", "   1 ", "     ", "   3 ", "     "}
+	for row, label := range want {
+		if got := g.label(row); got != label {
+			t.Errorf("label(%d) = %q, want %q", row, got, label)
+		}
+	}
+}
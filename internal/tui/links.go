@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// followLinkMsg asks to open the entry a [[link]] points at. An empty
+// target means there was no link under the cursor.
+type followLinkMsg struct {
+	target string
+}
+
+// followLink returns a command that asks to follow the link to target.
+func followLink(target string) tea.Cmd {
+	return func() tea.Msg {
+		return followLinkMsg{target: target}
+	}
+}
+
+// linkAtCursor returns the target of the wiki-link the cursor is on, or ""
+// if it isn't on one.
+func (m writingModel) linkAtCursor() string {
+	row, col := m.cursorPos()
+	lines := strings.Split(m.textarea.Value(), "\n")
+	if row >= len(lines) {
+		return ""
+	}
+	line := lines[row]
+	runes := []rune(line)
+	off := len(string(runes[:min(col, len(runes))])) // Byte offset of the cursor
+
+	spans, targets := journal.LinkSpans(line)
+	for i, span := range spans {
+		if off >= span[0] && off < span[1] {
+			return targets[i]
+		}
+	}
+	return ""
+}
+
+// handleFollowLink saves the current entry and opens the linked one in its
+// place.
+func (m *model) handleFollowLink(msg followLinkMsg) {
+	if msg.target == "" {
+		m.notify("No [[link]] under the cursor.")
+		return
+	}
+
+	path, err := m.manager.ResolveLink(msg.target)
+	if err != nil {
+		m.notify("No entry matches [[" + msg.target + "]].")
+		return
+	}
+	if path == m.entry.FilePath {
+		return
+	}
+
+	entry, err := m.manager.ReadEntry(path)
+	if err != nil {
+		m.notify("Couldn't open [[" + msg.target + "]]: " + err.Error())
+		return
+	}
+
+	m.openInstead(entry)
+}
+
+// switchEntry makes entry the one being written.
+func (m *model) switchEntry(entry *journal.JournalEntry) {
+	m.leaveEntry()
+	m.watchEntryDir(m.entry.FilePath, entry.FilePath)
+	m.entry = entry
+	m.lastSaved = entry.Content
+	m.lastDrafted = entry.Content
+	m.goalOffered = false
+	m.writingModel.SetHeader(entry.Header)
+	m.writingModel.SetValue(entry.Content)
+	m.startWords = m.writingModel.WordCount()
+	if m.cfg.LLM.Enabled {
+		messages, _ := m.manager.LoadConversation(entry)
+		m.convoModel = newConvoModel(messages, m.cfg.UI.RenderAIMarkdown, m.cfg.UI.Theme)
+		m.updateSizes()
+	}
+	m.offerDraft()
+}
+
+// notify shows a notice in the status bar until the next key press.
+func (m *model) notify(text string) {
+	m.statusBarModel.SetPrompt(text)
+	m.transientPrompt = true
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lowPowerRecount is how often words are recounted while typing in
+// low-power mode.
+const lowPowerRecount = time.Second
+
+// recountMsg catches up a word count held back while typing in low-power
+// mode.
+type recountMsg struct{}
+
+// countTyping recounts words after a keystroke. In low-power mode it
+// recounts at most once per lowPowerRecount, and schedules one more count
+// for the end of a burst of typing so the total is never left stale.
+func (m *writingModel) countTyping() tea.Cmd {
+	if !m.lowPower {
+		m.updateWordCount()
+		return nil
+	}
+
+	wait := lowPowerRecount - time.Since(m.lastCount)
+	if wait <= 0 {
+		m.recount()
+		return nil
+	}
+	if m.recountPending {
+		return nil
+	}
+	m.recountPending = true
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return recountMsg{}
+	})
+}
+
+// recount brings the word count up to date with the buffer.
+func (m *writingModel) recount() {
+	m.recountPending = false
+	m.lastCount = time.Now()
+	m.updateWordCount()
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestLowPowerTicks(t *testing.T) {
+	for _, lowPower := range []bool{false, true} {
+		t.Run(fmt.Sprintf("low_power=%v", lowPower), func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.UI.LowPower = lowPower
+				cfg.UI.PacingBPM = 60
+				cfg.UI.CursorShapes = false
+			}, nil)
+
+			if got := m.pacingTick() != nil; got == lowPower {
+				t.Errorf("pacing tick scheduled = %v", got)
+			}
+			if got := m.writingModel.Init() != nil; got == lowPower {
+				t.Errorf("cursor blink scheduled = %v", got)
+			}
+			if got := m.writingModel.StartSuggestion() != nil; got == lowPower {
+				t.Errorf("spinner tick scheduled = %v", got)
+			}
+		})
+	}
+}
+
+func TestLowPowerRecount(t *testing.T) {
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.UI.LowPower = true
+	}, nil)
+
+	// The first word is counted at once, then counting waits
+	m = press(m, "a")
+	if m.writingModel.recountPending {
+		t.Error("first keystroke deferred its count")
+	}
+	if got := m.writingModel.WordCount(); got != 1 {
+		t.Fatalf("word count = %d, want 1", got)
+	}
+
+	m = typeText(m, " b c")
+	if !m.writingModel.recountPending {
+		t.Fatal("no recount scheduled for the burst of typing")
+	}
+	if got := m.writingModel.WordCount(); got != 1 {
+		t.Errorf("word count = %d during the burst, want it held at 1", got)
+	}
+
+	updated, _ := m.Update(recountMsg{})
+	m = updated.(model)
+	if got := m.writingModel.WordCount(); got != 3 {
+		t.Errorf("word count = %d after the recount, want 3", got)
+	}
+	if m.writingModel.recountPending {
+		t.Error("recount still pending")
+	}
+}
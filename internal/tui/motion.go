@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// charClass groups runes the way vim does for word motions: a word is a run
+// of letters, digits, and underscores, or a run of other non-blank
+// characters (punctuation).
+type charClass int
+
+const (
+	classSpace charClass = iota
+	classPunct
+	classWord
+)
+
+// classOf returns the word-motion class of r.
+func classOf(r rune) charClass {
+	switch {
+	case unicode.IsSpace(r):
+		return classSpace
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return classWord
+	default:
+		return classPunct
+	}
+}
+
+// endOfWord returns the offset of the end of the word after off, like vim's
+// "e": it always moves forward at least one rune, skips blanks (including
+// line breaks), and stops on the last rune of the next word. It stays put
+// at the end of the buffer.
+func endOfWord(text []rune, off int) int {
+	if off >= len(text)-1 {
+		return off
+	}
+
+	i := off + 1
+	for i < len(text) && classOf(text[i]) == classSpace {
+		i++
+	}
+	if i >= len(text) {
+		return len(text) - 1
+	}
+
+	class := classOf(text[i])
+	for i+1 < len(text) && classOf(text[i+1]) == class {
+		i++
+	}
+	return i
+}
+
+// prevEndOfWord returns the offset of the end of the word before off, like
+// vim's "ge". It stops at the start of the buffer.
+func prevEndOfWord(text []rune, off int) int {
+	if off > len(text)-1 {
+		off = len(text) - 1
+	}
+	if off <= 0 {
+		return 0
+	}
+
+	// Leave the word the cursor is in, then any blanks before it
+	i := off
+	if class := classOf(text[i]); class != classSpace {
+		for i >= 0 && classOf(text[i]) == class {
+			i--
+		}
+	}
+	for i >= 0 && classOf(text[i]) == classSpace {
+		i--
+	}
+	if i < 0 {
+		return 0
+	}
+	return i
+}
+
+// bufferStart returns the offset of the start of the buffer, like vim's "gg".
+func bufferStart(text []rune, off int) int {
+	return 0
+}
+
+// lastLineStart returns the offset of the start of the last line, like
+// vim's "G".
+func lastLineStart(text []rune, off int) int {
+	return lineStart(text, len(text))
+}
+
+// nextParagraph returns the offset of the blank line after the paragraph
+// at or after off, like vim's "}". Blank lines the cursor is already in are
+// skipped first. Without a blank line below, it goes to the end of the
+// buffer.
+func nextParagraph(text []rune, off int) int {
+	pos := lineEnd(text, off)
+	inText := !isBlankLine(text, lineStart(text, off), pos)
+	for pos < len(text) {
+		next := pos + 1
+		end := lineEnd(text, next)
+		blank := isBlankLine(text, next, end)
+		if blank && inText {
+			return next
+		}
+		inText = inText || !blank
+		pos = end
+	}
+	return len(text)
+}
+
+// prevParagraph returns the offset of the blank line before the paragraph
+// at or before off, like vim's "{". Without a blank line above, it goes to
+// the start of the buffer.
+func prevParagraph(text []rune, off int) int {
+	pos := lineStart(text, off)
+	inText := !isBlankLine(text, pos, lineEnd(text, off))
+	for pos > 0 {
+		prev := lineStart(text, pos-1)
+		blank := isBlankLine(text, prev, pos-1)
+		if blank && inText {
+			return prev
+		}
+		inText = inText || !blank
+		pos = prev
+	}
+	return 0
+}
+
+// cursorOffset returns the cursor position as a rune offset into the buffer.
+func (m writingModel) cursorOffset() int {
+	row, col := m.cursorPos()
+	off := 0
+	for i, line := range strings.Split(m.textarea.Value(), "\n") {
+		if i == row {
+			return off + col
+		}
+		off += len([]rune(line)) + 1 // Include the line break
+	}
+	return off
+}
+
+// moveCursorToOffset places the cursor at a rune offset into the buffer.
+func (m *writingModel) moveCursorToOffset(off int) {
+	row := 0
+	for _, line := range strings.Split(m.textarea.Value(), "\n") {
+		n := len([]rune(line))
+		if off <= n {
+			break
+		}
+		off -= n + 1
+		row++
+	}
+	m.moveCursorTo(row, off)
+}
+
+// applyMotion moves the cursor to wherever motion takes it from the
+// current position.
+func (m *writingModel) applyMotion(motion func(text []rune, off int) int) {
+	text := []rune(m.textarea.Value())
+	m.moveCursorToOffset(motion(text, m.cursorOffset()))
+	m.refreshView()
+}
@@ -0,0 +1,96 @@
+package tui
+
+import "testing"
+
+// motionCase is a motion from off in text, expected to land on want
+type motionCase struct {
+	name string
+	text string
+	off  int
+	want int
+}
+
+// testMotion runs each case through motion
+func testMotion(t *testing.T, motion func(text []rune, off int) int, tests []motionCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := motion([]rune(tt.text), tt.off); got != tt.want {
+				t.Errorf("from %d in %q got %d, want %d", tt.off, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndOfWord(t *testing.T) {
+	testMotion(t, endOfWord, []motionCase{
+		{name: "to end of current word", text: "foo bar", off: 0, want: 2},
+		{name: "from word end to next", text: "foo bar", off: 2, want: 6},
+		{name: "from blank", text: "foo bar", off: 3, want: 6},
+		{name: "punctuation is its own word", text: "foo.bar", off: 2, want: 3},
+		{name: "punctuation run", text: "foo...bar", off: 2, want: 5},
+		{name: "word after punctuation", text: "foo.bar", off: 3, want: 6},
+		{name: "across a line break", text: "foo\nbar", off: 2, want: 6},
+		{name: "across blank lines", text: "foo\n\n  bar", off: 1, want: 2},
+		{name: "from blank lines", text: "foo\n\n  bar", off: 3, want: 9},
+		{name: "unicode word", text: "héllo wörld", off: 5, want: 10},
+		{name: "stays at last rune", text: "foo bar", off: 6, want: 6},
+		{name: "trailing blanks end the buffer", text: "foo  \n\n  ", off: 2, want: 8},
+		{name: "single rune", text: "a", off: 0, want: 0},
+		{name: "empty buffer", text: "", off: 0, want: 0},
+	})
+}
+
+func TestPrevEndOfWord(t *testing.T) {
+	testMotion(t, prevEndOfWord, []motionCase{
+		{name: "from word end", text: "foo bar", off: 6, want: 2},
+		{name: "from word start", text: "foo bar", off: 4, want: 2},
+		{name: "from blank", text: "foo bar", off: 3, want: 2},
+		{name: "punctuation before word", text: "foo.bar", off: 4, want: 3},
+		{name: "word before punctuation", text: "foo.bar", off: 3, want: 2},
+		{name: "across blank lines", text: "foo\n\nbar", off: 5, want: 2},
+		{name: "from a blank line", text: "foo\n\nbar", off: 4, want: 2},
+		{name: "past the end clamps", text: "foo bar", off: 7, want: 2},
+		{name: "first word goes to start", text: "foo bar", off: 2, want: 0},
+		{name: "leading blanks go to start", text: "  foo", off: 3, want: 0},
+		{name: "at start", text: "foo", off: 0, want: 0},
+		{name: "empty buffer", text: "", off: 0, want: 0},
+	})
+}
+
+// paragraphs has two paragraphs split by consecutive blank lines, then a
+// third after a single one:
+//
+//	0 a  2 b  4 ""  5 ""  6 c  8 ""  9 d
+const paragraphs = "a\nb\n\n\nc\n\nd"
+
+func TestNextParagraph(t *testing.T) {
+	testMotion(t, nextParagraph, []motionCase{
+		{name: "to blank after paragraph", text: paragraphs, off: 0, want: 4},
+		{name: "from inside a line", text: paragraphs, off: 3, want: 4},
+		{name: "skips consecutive blanks", text: paragraphs, off: 4, want: 8},
+		{name: "from last of the blanks", text: paragraphs, off: 5, want: 8},
+		{name: "last paragraph goes to end", text: paragraphs, off: 9, want: 10},
+		{name: "from blank before last paragraph", text: paragraphs, off: 8, want: 10},
+		{name: "whitespace-only line is blank", text: "a\n  \nb", off: 0, want: 2},
+		{name: "trailing blank line", text: "a\n\n", off: 0, want: 2},
+		{name: "from trailing blank line", text: "a\n\n", off: 2, want: 3},
+		{name: "single line", text: "abc", off: 1, want: 3},
+		{name: "empty buffer", text: "", off: 0, want: 0},
+	})
+}
+
+func TestPrevParagraph(t *testing.T) {
+	testMotion(t, prevParagraph, []motionCase{
+		{name: "to blank before paragraph", text: paragraphs, off: 9, want: 8},
+		{name: "from a blank line", text: paragraphs, off: 8, want: 5},
+		{name: "to nearest of consecutive blanks", text: paragraphs, off: 6, want: 5},
+		{name: "skips consecutive blanks", text: paragraphs, off: 5, want: 0},
+		{name: "first paragraph goes to start", text: paragraphs, off: 2, want: 0},
+		{name: "at start", text: paragraphs, off: 0, want: 0},
+		{name: "whitespace-only line is blank", text: "a\n  \nb", off: 5, want: 2},
+		{name: "leading blank line", text: "\n\na", off: 2, want: 1},
+		{name: "end of buffer", text: "a\n\nb", off: 4, want: 2},
+		{name: "empty buffer", text: "", off: 0, want: 0},
+	})
+}
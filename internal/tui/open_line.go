@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// listMarkerPattern matches a markdown list marker after the indent: a
+// bullet or a number, optionally followed by a task checkbox.
+var listMarkerPattern = regexp.MustCompile(`^([-*+]|(\d+)([.)]))( \[[ xX]\])? `)
+
+// lineIndent returns the prefix for a line opened next to line: its
+// leading whitespace and, with continueLists, its list marker. A numbered
+// item opened below counts on, and a task opened next to a done one starts
+// unchecked.
+func lineIndent(line string, continueLists, below bool) string {
+	rest := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(rest)]
+	if !continueLists {
+		return indent
+	}
+
+	match := listMarkerPattern.FindStringSubmatch(rest)
+	if match == nil {
+		return indent
+	}
+	marker := match[1]
+	if match[2] != "" {
+		n, _ := strconv.Atoi(match[2])
+		if below {
+			n++
+		}
+		marker = strconv.Itoa(n) + match[3]
+	}
+	if match[4] != "" {
+		marker += " [ ]"
+	}
+	return indent + marker + " "
+}
+
+// openLine starts a new line below (o) or above (O) the cursor line,
+// carrying over its indent, and switches to insert mode there.
+func (m *writingModel) openLine(below bool) {
+	text := []rune(m.textarea.Value())
+	off := m.cursorOffset()
+	start, end := lineStart(text, off), lineEnd(text, off)
+	prefix := []rune(lineIndent(string(text[start:end]), m.continueLists, below))
+
+	var value []rune
+	var cursor int
+	if below {
+		value = append(append(append(text[:end:end], '\n'), prefix...), text[end:]...)
+		cursor = end + 1 + len(prefix)
+	} else {
+		value = append(append(append(text[:start:start], prefix...), '\n'), text[start:]...)
+		cursor = start + len(prefix)
+	}
+
+	m.textarea.SetValue(string(value))
+	m.updateWordCount()
+	m.moveCursorToOffset(cursor)
+	m.mode = modeInsert
+	m.textarea.Focus()
+	m.refreshView()
+}
@@ -0,0 +1,39 @@
+package tui
+
+import "testing"
+
+func TestLineIndent(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		continueLists bool
+		below         bool
+		want          string
+	}{
+		{name: "no indent", line: "words", continueLists: true, below: true, want: ""},
+		{name: "spaces", line: "    words", continueLists: true, below: true, want: "    "},
+		{name: "tabs", line: "\t\twords", continueLists: true, below: true, want: "\t\t"},
+		{name: "mixed tabs and spaces", line: "\t  words", continueLists: true, below: true, want: "\t  "},
+		{name: "blank line keeps its whitespace", line: "  \t", continueLists: true, below: true, want: "  \t"},
+		{name: "bullet", line: "- item", continueLists: true, below: true, want: "- "},
+		{name: "indented bullet", line: "  * item", continueLists: true, below: true, want: "  * "},
+		{name: "plus bullet under a tab", line: "\t+ item", continueLists: true, below: true, want: "\t+ "},
+		{name: "numbered below counts on", line: "9. item", continueLists: true, below: true, want: "10. "},
+		{name: "numbered above keeps number", line: "9. item", continueLists: true, below: false, want: "9. "},
+		{name: "paren numbering", line: "  3) item", continueLists: true, below: true, want: "  4) "},
+		{name: "done task starts unchecked", line: "- [x] done", continueLists: true, below: true, want: "- [ ] "},
+		{name: "numbered task", line: "1. [ ] todo", continueLists: true, below: true, want: "2. [ ] "},
+		{name: "marker needs a space", line: "-item", continueLists: true, below: true, want: ""},
+		{name: "not a list", line: "1.5 litres", continueLists: true, below: true, want: ""},
+		{name: "lists off keeps indent only", line: "  - item", continueLists: false, below: true, want: "  "},
+		{name: "lists off numbered", line: "1. item", continueLists: false, below: true, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineIndent(tt.line, tt.continueLists, tt.below); got != tt.want {
+				t.Errorf("lineIndent(%q, %v, %v) = %q, want %q", tt.line, tt.continueLists, tt.below, got, tt.want)
+			}
+		})
+	}
+}
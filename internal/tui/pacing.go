@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pacingFrames is how many times the pacing dot is redrawn per beat, enough
+// for it to fade in and out rather than blink.
+const pacingFrames = 8
+
+// Darkest and brightest grays of the 256-color palette the dot moves
+// between.
+const (
+	pacingDim    = 236
+	pacingBright = 255
+)
+
+// pacingTickMsg redraws the pacing dot.
+type pacingTickMsg time.Time
+
+// pacingPeriod returns the length of one beat at bpm beats per minute.
+func pacingPeriod(bpm int) time.Duration {
+	return time.Minute / time.Duration(bpm)
+}
+
+// pacingTick schedules the next frame of the pacing dot, or nothing if
+// UI.PacingBPM is 0 or low-power mode is on.
+func (m model) pacingTick() tea.Cmd {
+	bpm := m.cfg.UI.PacingBPM
+	if bpm <= 0 || m.cfg.UI.LowPower {
+		return nil
+	}
+	return tea.Tick(pacingPeriod(bpm)/pacingFrames, func(t time.Time) tea.Msg {
+		return pacingTickMsg(t)
+	})
+}
+
+// pacingPhase returns how far through its beat the pulse is at t, from 0 up
+// to but not including 1, counting beats from start.
+func pacingPhase(start, t time.Time, bpm int) float64 {
+	period := pacingPeriod(bpm)
+	elapsed := t.Sub(start) % period
+	if elapsed < 0 {
+		elapsed += period
+	}
+	return float64(elapsed) / float64(period)
+}
+
+// pacingLevel returns the dot's brightness at phase, from 0 to 1: it rises
+// and falls once a beat, like a breath.
+func pacingLevel(phase float64) float64 {
+	return (1 - math.Cos(2*math.Pi*phase)) / 2
+}
+
+// pacingDot renders the pacing dot at the given strength, which on a light
+// theme darkens it rather than brightening it.
+func pacingDot(level float64, light bool) string {
+	if light {
+		level = 1 - level
+	}
+	gray := pacingDim + int(math.Round(level*(pacingBright-pacingDim)))
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(strconv.Itoa(gray))).Render("●")
+}
+
+// pacing returns the pacing dot as of the latest tick, or "" when pacing
+// is off.
+func (m model) pacing() string {
+	if m.cfg.UI.PacingBPM <= 0 || m.cfg.UI.LowPower || m.pacingAt.IsZero() {
+		return ""
+	}
+	level := pacingLevel(pacingPhase(m.sessionStart, m.pacingAt, m.cfg.UI.PacingBPM))
+	return pacingDot(level, m.cfg.UI.Theme == "light")
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPacingPhase(t *testing.T) {
+	start := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		bpm     int
+		want    float64
+	}{
+		{name: "start of first beat", elapsed: 0, bpm: 60, want: 0},
+		{name: "quarter beat", elapsed: 250 * time.Millisecond, bpm: 60, want: 0.25},
+		{name: "just before the beat ends", elapsed: 999 * time.Millisecond, bpm: 60, want: 0.999},
+		{name: "next beat starts at 0", elapsed: time.Second, bpm: 60, want: 0},
+		{name: "later beat", elapsed: 2500 * time.Millisecond, bpm: 60, want: 0.5},
+		{name: "faster tempo", elapsed: 250 * time.Millisecond, bpm: 120, want: 0.5},
+		{name: "faster tempo beat boundary", elapsed: 500 * time.Millisecond, bpm: 120, want: 0},
+		{name: "before start wraps", elapsed: -250 * time.Millisecond, bpm: 60, want: 0.75},
+		{name: "a whole beat before start", elapsed: -time.Second, bpm: 60, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pacingPhase(start, start.Add(tt.elapsed), tt.bpm)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("pacingPhase(+%v, %d bpm) = %v, want %v", tt.elapsed, tt.bpm, got, tt.want)
+			}
+			if got < 0 || got >= 1 {
+				t.Errorf("phase %v outside [0, 1)", got)
+			}
+		})
+	}
+}
+
+func TestPacingLevel(t *testing.T) {
+	for phase, want := range map[float64]float64{0: 0, 0.25: 0.5, 0.5: 1, 0.75: 0.5} {
+		if got := pacingLevel(phase); math.Abs(got-want) > 1e-9 {
+			t.Errorf("pacingLevel(%v) = %v, want %v", phase, got, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// countedWritingModel returns an insert-mode pane whose word counter records
+// how often it runs.
+func countedWritingModel(t *testing.T) (writingModel, *int) {
+	t.Helper()
+	m := NewWritingModel(config.DefaultConfig())
+	m.SetSize(60, 20)
+	m.mode = modeInsert
+	m.textarea.Focus()
+
+	counts := new(int)
+	count := m.countWords
+	m.countWords = func(s string) int {
+		*counts++
+		return count(s)
+	}
+	return m, counts
+}
+
+func TestPasteCountsOnce(t *testing.T) {
+	const text = "first pasted line\nsecond line here\n\nthird paragraph"
+
+	m, counts := countedWritingModel(t)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true})
+	if *counts != 1 {
+		t.Errorf("paste counted words %d times, want once", *counts)
+	}
+	if got := m.Value(); got != text {
+		t.Errorf("Value() = %q, want the pasted text", got)
+	}
+	if got := m.WordCount(); got != 8 {
+		t.Errorf("WordCount() = %d, want 8", got)
+	}
+
+	// Typing the same text counts after every key
+	typed, counts := countedWritingModel(t)
+	for _, r := range text {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		if r == '\n' {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		typed, _ = typed.Update(msg)
+	}
+	if *counts != len(text) {
+		t.Errorf("typing counted words %d times, want %d", *counts, len(text))
+	}
+	if got := typed.WordCount(); got != m.WordCount() {
+		t.Errorf("typed WordCount() = %d, pasted %d", got, m.WordCount())
+	}
+}
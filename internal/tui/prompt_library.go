@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// askTimeout bounds how long we wait for an answer to a library prompt.
+const askTimeout = time.Minute
+
+// entryPlaceholder marks where the entry text goes in a library prompt.
+const entryPlaceholder = "{{entry}}"
+
+// renderLibraryPrompt fills the entry text into a library prompt. Prompts
+// without the placeholder get the entry appended after them.
+func renderLibraryPrompt(prompt, entry string) string {
+	if strings.Contains(prompt, entryPlaceholder) {
+		return strings.ReplaceAll(prompt, entryPlaceholder, entry)
+	}
+	return prompt + "\n\nJournal entry:\n" + entry
+}
+
+// answerMsg carries the AI's complete reply to a library prompt.
+type answerMsg struct {
+	text string
+	err  error
+}
+
+// answerChunkMsg carries the next piece of a streamed reply.
+type answerChunkMsg struct {
+	stream *answerStream
+	text   string
+}
+
+// answerStream is a reply being streamed from the LLM client.
+type answerStream struct {
+	chunks <-chan llm.Chunk
+	cancel context.CancelFunc
+	text   strings.Builder // Everything received so far
+}
+
+// requestAnswer sends a rendered prompt to the LLM client and streams the
+// reply back as answerChunkMsgs, ending with an answerMsg.
+func requestAnswer(client llm.Client, prompt string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), askTimeout)
+		chunks, err := client.Stream(ctx, prompt)
+		if err != nil {
+			cancel()
+			return answerMsg{err: err}
+		}
+		return (&answerStream{chunks: chunks, cancel: cancel}).next()
+	}
+}
+
+// next waits for the next chunk of the reply.
+func (s *answerStream) next() tea.Msg {
+	chunk, ok := <-s.chunks
+	switch {
+	case !ok:
+		s.cancel()
+		return answerMsg{text: strings.TrimSpace(s.text.String())}
+	case chunk.Err != nil:
+		s.cancel()
+		return answerMsg{err: chunk.Err}
+	}
+	s.text.WriteString(chunk.Text)
+	return answerChunkMsg{stream: s, text: chunk.Text}
+}
+
+// promptPicker lists the prompt library for selection.
+type promptPicker struct {
+	names  []string // Library prompt names, sorted
+	cursor int
+	open   bool
+}
+
+// newPromptPicker creates a picker over the library's prompt names.
+func newPromptPicker(library map[string]string) promptPicker {
+	names := make([]string, 0, len(library))
+	for name := range library {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return promptPicker{names: names}
+}
+
+// Open shows the picker with the first prompt selected.
+func (p *promptPicker) Open() {
+	p.open = true
+	p.cursor = 0
+}
+
+// Close hides the picker.
+func (p *promptPicker) Close() {
+	p.open = false
+}
+
+// Move shifts the selection by delta, stopping at either end.
+func (p *promptPicker) Move(delta int) {
+	p.cursor = max(0, min(len(p.names)-1, p.cursor+delta))
+}
+
+// Selected returns the name of the highlighted prompt.
+func (p promptPicker) Selected() (string, bool) {
+	if len(p.names) == 0 {
+		return "", false
+	}
+	return p.names[p.cursor], true
+}
+
+// View renders the prompt list.
+func (p promptPicker) View(width int) string {
+	selected := lipgloss.NewStyle().Reverse(true)
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("Ask the AI"), ""}
+	for i, name := range p.names {
+		line := "  " + name
+		if i == p.cursor {
+			line = selected.Render("> " + name)
+		}
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(line))
+	}
+	if len(p.names) == 0 {
+		lines = append(lines, "No prompts in llm.prompt_library.")
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Faint(true).Render("[Enter] ask  [Esc] cancel"))
+	return strings.Join(lines, "\n")
+}
+
+// openPicker shows the prompt library, or explains why AI isn't available.
+func (m *model) openPicker() {
+	if m.llmClient == nil {
+		m.statusBarModel.SetPrompt("AI is unavailable: no LLM provider could be set up.")
+		m.transientPrompt = true
+		return
+	}
+	m.picker.Open()
+}
+
+// handlePickerKey moves through the prompt library while it is open. Enter
+// sends the selected prompt with the entry as context; Esc cancels.
+func (m model) handlePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k", "ctrl+p":
+		m.picker.Move(-1)
+	case "down", "j", "ctrl+n":
+		m.picker.Move(1)
+	case "esc":
+		m.picker.Close()
+	case "enter":
+		m.picker.Close()
+		name, ok := m.picker.Selected()
+		if !ok {
+			return m, nil
+		}
+		// Save first so the persisted conversation matches the saved entry
+		if m.cfg.LLM.SaveBeforeAsk && m.dirty() {
+			m.noteSave(m.saveCurrent())
+		}
+
+		prompt := m.cfg.LLM.PromptLibrary[name]
+		m.convoModel.AddMessage(journal.Message{
+			Role:    journal.RoleUser,
+			Content: prompt,
+			Time:    time.Now(),
+		})
+		m.convoModel.StartWaiting()
+		m.noteThrottle()
+		return m, requestAnswer(m.llmClient, renderLibraryPrompt(prompt, m.aiContext(m.writingModel.Value())))
+	}
+	return m, nil
+}
+
+// handleAnswerChunk shows the reply so far and waits for more.
+func (m *model) handleAnswerChunk(msg answerChunkMsg) tea.Cmd {
+	m.convoModel.partial += msg.text
+	return msg.stream.next
+}
+
+// handleAnswer adds the AI's reply to the conversation and saves it
+// alongside the entry.
+func (m *model) handleAnswer(msg answerMsg) {
+	m.convoModel.waiting = false
+	m.convoModel.partial = ""
+	if msg.err != nil {
+		m.convoModel.err = msg.err
+		return
+	}
+	// Small models sometimes say nothing; explain rather than show a blank
+	// reply, and keep it out of the saved conversation
+	if strings.TrimSpace(msg.text) == "" {
+		m.convoModel.notice = m.cfg.LLM.EmptyReplyNotice
+		return
+	}
+
+	m.convoModel.AddMessage(journal.Message{
+		Role:    journal.RoleAssistant,
+		Content: msg.text,
+		Time:    time.Now(),
+	})
+	m.noteSave(m.manager.SaveConversation(m.entry, m.convoModel.Messages()))
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptClient is a fakeClient that calls onStream with each prompt it is
+// sent, before replying.
+type promptClient struct {
+	fakeClient
+	onStream func(prompt string)
+}
+
+func (c promptClient) Stream(ctx context.Context, prompt string) (<-chan llm.Chunk, error) {
+	c.onStream(prompt)
+	return c.fakeClient.Stream(ctx, prompt)
+}
+
+// askLibraryPrompt opens the picker, asks the prompt the cursor lands on
+// after moving down steps times, and delivers the reply.
+func askLibraryPrompt(t *testing.T, m model, steps int) model {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = updated.(model)
+	if !m.picker.open {
+		t.Fatal("ctrl+o didn't open the picker")
+	}
+	for range steps {
+		m = press(m, "j")
+	}
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(model)
+	if m.picker.open {
+		t.Error("picker still open after choosing a prompt")
+	}
+	// Deliver the streamed reply chunk by chunk
+	for cmd != nil {
+		updated, cmd = m.Update(cmd())
+		m = updated.(model)
+	}
+	return m
+}
+
+func TestRenderLibraryPrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		prompt string
+		want   string
+	}{
+		{name: "placeholder", prompt: "Summarize:\n{{entry}}\nBriefly.", want: "Summarize:\nToday was long.\nBriefly."},
+		{name: "placeholder twice", prompt: "{{entry}} / {{entry}}", want: "Today was long. / Today was long."},
+		{name: "entry appended", prompt: "Reflect on my mood.", want: "Reflect on my mood.\n\nJournal entry:\nToday was long."},
+	}
+	for _, tt := range tests {
+		if got := renderLibraryPrompt(tt.prompt, "Today was long."); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPromptPicker(t *testing.T) {
+	p := newPromptPicker(map[string]string{"b": "", "c": "", "a": ""})
+	p.Open()
+	if got, _ := p.Selected(); got != "a" {
+		t.Errorf("opened on %q, want the first name in order", got)
+	}
+	p.Move(-1)
+	if got, _ := p.Selected(); got != "a" {
+		t.Errorf("moved above the top to %q", got)
+	}
+	p.Move(5)
+	if got, _ := p.Selected(); got != "c" {
+		t.Errorf("moved past the end to %q, want c", got)
+	}
+
+	empty := newPromptPicker(nil)
+	if _, ok := empty.Selected(); ok {
+		t.Error("empty library has a selection")
+	}
+}
+
+func TestAskLibraryPrompt(t *testing.T) {
+	var sent string
+	client := promptClient{
+		fakeClient: fakeClient{reply: "You sound tired."},
+		onStream:   func(prompt string) { sent = prompt },
+	}
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.LLM.PromptLibrary = map[string]string{
+			"mood":    "How do I feel?",
+			"summary": "Summarize {{entry}} please.",
+		}
+	}, client)
+	m.writingModel.SetValue("Long day at work.")
+
+	m = askLibraryPrompt(t, m, 1)
+
+	if want := "Summarize Long day at work. please."; sent != want {
+		t.Errorf("sent %q, want %q", sent, want)
+	}
+	messages := m.convoModel.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("conversation has %d messages, want the prompt and the reply", len(messages))
+	}
+	if messages[0].Role != journal.RoleUser || messages[0].Content != "Summarize {{entry}} please." {
+		t.Errorf("first message = %+v, want the library prompt", messages[0])
+	}
+	if messages[1].Role != journal.RoleAssistant || !strings.Contains(messages[1].Content, "tired") {
+		t.Errorf("second message = %+v, want the reply", messages[1])
+	}
+}
+
+func TestSaveBeforeAsk(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		// What the entry's file held when the request went out
+		var savedWhenAsked string
+		var read func() (*journal.JournalEntry, error)
+		client := promptClient{
+			fakeClient: fakeClient{reply: "Noted."},
+			onStream: func(string) {
+				entry, err := read()
+				if err != nil {
+					t.Fatalf("ReadEntry: %v", err)
+				}
+				savedWhenAsked = entry.Content
+			},
+		}
+		m := newTestModel(t, func(cfg *config.Config) {
+			cfg.LLM.SaveBeforeAsk = enabled
+		}, client)
+		read = func() (*journal.JournalEntry, error) { return m.manager.ReadEntry(m.entry.FilePath) }
+		m = typeText(m, "Unsaved thoughts")
+		m = press(m, "esc")
+
+		m = askLibraryPrompt(t, m, 0)
+		if saved := strings.Contains(savedWhenAsked, "Unsaved thoughts"); saved != enabled {
+			t.Errorf("save_before_ask %v: entry saved before asking = %v (file held %q)", enabled, saved, savedWhenAsked)
+		}
+		if m.dirty() == enabled {
+			t.Errorf("save_before_ask %v: dirty after asking = %v", enabled, m.dirty())
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quitPrompt is shown in the status bar when UI.ConfirmQuit is on and
+// there are unsaved changes.
+const quitPrompt = "Save before quitting? (y/n/c)"
+
+// requestQuit quits with a final save, or first asks what to do with
+// unsaved changes when UI.ConfirmQuit is on.
+func (m model) requestQuit() (tea.Model, tea.Cmd) {
+	if m.cfg.UI.ConfirmQuit && m.dirty() && !m.writingModel.ReadOnly() {
+		m.confirmQuit = true
+		m.statusBarModel.SetPrompt(quitPrompt)
+		return m, nil
+	}
+	return m, m.saveAndQuit()
+}
+
+// handleQuitConfirm resolves the quit prompt: "y" saves and quits, "n"
+// quits without saving, and "c" (or anything else) goes back to writing.
+func (m model) handleQuitConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmQuit = false
+	m.statusBarModel.SetPrompt("")
+
+	switch msg.String() {
+	case "y":
+		return m, m.saveAndQuit()
+	case "n":
+		return m, m.quitWithoutSaving()
+	}
+	return m, nil
+}
+
+// saveAndQuit ends the session with a final save and sample.
+func (m *model) saveAndQuit() tea.Cmd {
+	m.quitting = true
+	m.finalSave(time.Now())
+	m.closeWatcher()
+	return tea.Sequence(m.writingModel.resetCursorShape(), tea.Quit)
+}
+
+// quitWithoutSaving ends the session, leaving the entry as last saved.
+func (m *model) quitWithoutSaving() tea.Cmd {
+	m.quitting = true
+	m.discarded = true
+	m.manager.RemoveDraft(m.entry) // Nothing to recover from a discarded session
+	m.closeWatcher()
+	return tea.Sequence(m.writingModel.resetCursorShape(), tea.Quit)
+}
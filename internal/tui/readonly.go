@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetReadOnly switches the writing pane between editing and reading. A
+// read-only pane shows the text in a scrollable viewport and ignores every
+// key that would change it.
+func (m *writingModel) SetReadOnly(readonly bool) {
+	m.readonly = readonly
+	if readonly {
+		m.mode = modeNormal
+		m.textarea.Blur()
+		m.updateViewport()
+	}
+}
+
+// ReadOnly reports whether the writing pane is read-only.
+func (m writingModel) ReadOnly() bool {
+	return m.readonly
+}
+
+// updateViewport sizes the read-only viewport to the pane and wraps the
+// text to its width, since the viewport doesn't wrap lines itself.
+func (m *writingModel) updateViewport() {
+	if !m.readonly {
+		return
+	}
+	height := m.height - lipgloss.Height(m.renderModeIndicator())
+	if m.width <= 0 || height <= 0 {
+		return
+	}
+	if m.viewport.Width != m.width || m.viewport.Height != height {
+		m.viewport = viewport.New(m.width, height)
+	}
+	m.viewport.SetContent(lipgloss.NewStyle().Width(m.width).Render(m.textarea.Value()))
+}
+
+// updateReadOnly handles keys in read-only mode: only scrolling is allowed.
+func (m writingModel) updateReadOnly(msg tea.KeyMsg) (writingModel, tea.Cmd) {
+	switch msg.String() {
+	case "g", "home":
+		m.viewport.GotoTop()
+		return m, nil
+	case "G", "end":
+		m.viewport.GotoBottom()
+		return m, nil
+	}
+
+	// The viewport's own bindings (j/k, arrows, page and half-page moves)
+	// only scroll
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newReadOnlyModel returns a read-only model showing lines numbered lines.
+func newReadOnlyModel(t *testing.T, lines int) model {
+	t.Helper()
+	m := newTestModel(t, nil, fakeClient{reply: "more"})
+	m.writingModel.SetValue(numberedLines(lines))
+	WithReadOnly(true)(&m)
+	return m
+}
+
+func TestReadOnlyIgnoresEdits(t *testing.T) {
+	m := newReadOnlyModel(t, 60)
+	value := m.writingModel.Value()
+
+	edits := []tea.KeyMsg{
+		keyMsg("i"), keyMsg("x"), keyMsg("a"), keyMsg("o"), keyMsg("O"),
+		keyMsg("d"), keyMsg("d"), keyMsg("p"),
+		keyMsg("c"), keyMsg("i"), keyMsg("w"),
+		keyMsg("enter"), keyMsg(" "),
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyDelete},
+		{Type: tea.KeyCtrlD},
+		{Type: tea.KeyRunes, Runes: []rune("pasted"), Paste: true},
+	}
+	for _, key := range edits {
+		updated, _ := m.Update(key)
+		m = updated.(model)
+		if got := m.writingModel.Value(); got != value {
+			t.Fatalf("%q changed the text to %q", key.String(), got)
+		}
+		if m.writingModel.mode != modeNormal {
+			t.Fatalf("%q left normal mode", key.String())
+		}
+	}
+
+	// No suggestion is asked for
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlAt})
+	m = updated.(model)
+	if cmd != nil || m.writingModel.suggesting {
+		t.Error("ctrl+space asked for a suggestion in read-only mode")
+	}
+	if !m.writingModel.ReadOnly() {
+		t.Error("no longer read-only")
+	}
+}
+
+func TestReadOnlyScrolls(t *testing.T) {
+	m := newReadOnlyModel(t, 60)
+	vp := func() int { return m.writingModel.viewport.YOffset }
+
+	m = press(m, "j")
+	if vp() != 1 {
+		t.Errorf("j scrolled to %d, want 1", vp())
+	}
+	m = press(m, "G")
+	if !m.writingModel.viewport.AtBottom() {
+		t.Error("G didn't scroll to the bottom")
+	}
+	if view := m.writingModel.View(); !visibleLines(m.writingModel)[59] {
+		t.Errorf("the last line isn't shown at the bottom:\n%s", view)
+	}
+	m = press(m, "g")
+	if vp() != 0 {
+		t.Errorf("g scrolled to %d, want the top", vp())
+	}
+}
+
+func TestReadOnlyNeverSaves(t *testing.T) {
+	m := newReadOnlyModel(t, 3)
+	if m.autosaveTick() != nil {
+		t.Error("autosave scheduled for a read-only entry")
+	}
+	m.finalSave(time.Now())
+	if got := savedContent(t, m); got != "" {
+		t.Errorf("read-only session saved %q", got)
+	}
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCtrlLRedraws(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*model)
+	}{
+		{name: "writing pane"},
+		{name: "normal mode", setup: func(m *model) { *m = press(*m, "esc") }},
+		{name: "conversation pane", setup: func(m *model) { m.focusedPane = conversationPane }},
+		{name: "picker open", setup: func(m *model) { m.picker.Open() }},
+		{name: "discard prompt", setup: func(m *model) { m.confirmDiscard = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, nil, fakeClient{})
+			if tt.setup != nil {
+				tt.setup(&m)
+			}
+			value := m.writingModel.Value()
+			// Shrink the panes so the redraw has something to recompute
+			m.writingModel.SetSize(10, 5)
+
+			updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+			m = updated.(model)
+			if cmd == nil || cmd() != tea.ClearScreen() {
+				t.Fatal("ctrl+l didn't clear the screen")
+			}
+			if m.writingModel.textarea.Width() == 10 {
+				t.Error("ctrl+l didn't recompute the pane sizes")
+			}
+			if m.writingModel.Value() != value {
+				t.Errorf("ctrl+l changed the text to %q", m.writingModel.Value())
+			}
+		})
+	}
+}
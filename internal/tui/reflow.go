@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reflow rewraps each paragraph of text to at most width columns. Blank
+// lines, headings and fenced code blocks are kept as they are, list items
+// wrap under their own marker, and a paragraph whose lines already fit is
+// left alone so deliberate short lines survive.
+func reflow(text string, width int) string {
+	var (
+		out   []string
+		para  []string
+		fence string // Marker of the open code fence, if any
+	)
+	flush := func() {
+		out = append(out, fillParagraph(para, width)...)
+		para = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if fence != "" {
+			out = append(out, line)
+			if journal.IsFenceClose(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		switch {
+		case journal.FenceMarker(line) != "":
+			flush()
+			fence = journal.FenceMarker(line)
+			out = append(out, line)
+		case trimmed == "", strings.HasPrefix(trimmed, "#"):
+			flush()
+			out = append(out, line)
+		case listMarkerPattern.MatchString(trimmed):
+			flush() // Each list item is a paragraph of its own
+			para = append(para, line)
+		default:
+			para = append(para, line)
+		}
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+// fillParagraph wraps the words of a paragraph's lines greedily to width.
+// Continuation lines take the first line's indent, plus room for its list
+// marker. Words longer than width get a line to themselves.
+func fillParagraph(lines []string, width int) []string {
+	fits := true
+	for _, line := range lines {
+		if lipgloss.Width(line) > width {
+			fits = false
+			break
+		}
+	}
+	if fits {
+		return lines
+	}
+
+	first := strings.TrimLeft(lines[0], " \t")
+	indent := lines[0][:len(lines[0])-len(first)]
+	prefix, hang := indent, indent
+	if marker := listMarkerPattern.FindString(first); marker != "" {
+		prefix += marker
+		hang += strings.Repeat(" ", len(marker))
+		first = first[len(marker):]
+	}
+
+	words := strings.Fields(first)
+	for _, line := range lines[1:] {
+		words = append(words, strings.Fields(line)...)
+	}
+
+	var out []string
+	current, empty := prefix, true
+	for _, word := range words {
+		if !empty && lipgloss.Width(current)+1+lipgloss.Width(word) > width {
+			out = append(out, current)
+			current, empty = hang, true
+		}
+		if !empty {
+			current += " "
+		}
+		current += word
+		empty = false
+	}
+	return append(out, current)
+}
+
+// paragraphAround returns the range of the lines of the paragraph holding
+// off, without the final line break. It is empty on a blank line.
+func paragraphAround(text []rune, off int) (start, end int) {
+	start, end = lineStart(text, off), lineEnd(text, off)
+	if isBlankLine(text, start, end) {
+		return off, off
+	}
+	for start > 0 {
+		prev := lineStart(text, start-1)
+		if isBlankLine(text, prev, start-1) {
+			break
+		}
+		start = prev
+	}
+	for end < len(text) {
+		next := lineEnd(text, end+1)
+		if isBlankLine(text, end+1, next) {
+			break
+		}
+		end = next
+	}
+	return start, end
+}
+
+// reflowWidth returns the width gq wraps to: ui.reflow_width, or the
+// writing pane's width when that is 0.
+func (m writingModel) reflowWidth() int {
+	if m.reflowTo > 0 {
+		return m.reflowTo
+	}
+	return m.textarea.Width()
+}
+
+// applyReflow rewraps the whole lines from the one containing from to the
+// one containing to, leaving the cursor at the start of the first.
+func (m *writingModel) applyReflow(from, to int) {
+	text := []rune(m.textarea.Value())
+	if from > to {
+		from, to = to, from
+	}
+	start, end := lineStart(text, from), lineEnd(text, to)
+	wrapped := reflow(string(text[start:end]), m.reflowWidth())
+	if wrapped == string(text[start:end]) {
+		return
+	}
+
+	m.textarea.SetValue(string(text[:start]) + wrapped + string(text[end:]))
+	m.updateWordCount()
+	m.moveCursorToOffset(start)
+	m.refreshView()
+}
+
+// reflowMotion rewraps from the cursor to where op selects, for gq with a
+// motion.
+func (m *writingModel) reflowMotion(op func(text []rune, off int) (start, end int)) {
+	text := []rune(m.textarea.Value())
+	m.applyReflow(op(text, m.cursorOffset()))
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+)
+
+func TestReflow(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{name: "wraps to width", text: "one two three four five", width: 10, want: "one two\nthree four\nfive"},
+		{name: "leaves fitting lines alone", text: "short\nlines", width: 20, want: "short\nlines"},
+		{name: "joins lines of a paragraph", text: "aaa bbb ccc\nddd", width: 8, want: "aaa bbb\nccc ddd"},
+		{name: "keeps paragraphs apart", text: "one two three\n\nfour five six", width: 8, want: "one two\nthree\n\nfour\nfive six"},
+		{name: "keeps headings", text: "# A very long heading here\nwords words words", width: 10, want: "# A very long heading here\nwords\nwords\nwords"},
+		{name: "hangs list items under the marker", text: "- alpha beta gamma", width: 12, want: "- alpha beta\n  gamma"},
+		{name: "wraps list items separately", text: "- a b c d e f\n- g", width: 6, want: "- a b\n  c d\n  e f\n- g"},
+		{name: "numbered list item", text: "10. alpha beta gamma", width: 14, want: "10. alpha beta\n    gamma"},
+		{name: "keeps the indent", text: "    indented words here", width: 14, want: "    indented\n    words here"},
+		{name: "keeps code fences", text: "```\nlong long long long\n```", width: 5, want: "```\nlong long long long\n```"},
+		{name: "long words get their own line", text: "supercalifragilistic is long", width: 5, want: "supercalifragilistic\nis\nlong"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reflow(tt.text, tt.width); got != tt.want {
+				t.Errorf("reflow(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflowCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		cursor     int
+		op         func(text []rune, off int) (int, int)
+		want       string
+		wantCursor int
+	}{
+		{
+			name:       "paragraph moves to its start",
+			text:       "first para\n\nsecond paragraph that is long",
+			cursor:     20,
+			op:         paragraphAround,
+			want:       "first para\n\nsecond\nparagraph\nthat is long",
+			wantCursor: 12,
+		},
+		{
+			name:       "line moves to its start",
+			text:       "keep\nthis line is too long",
+			cursor:     12,
+			op:         func(text []rune, off int) (int, int) { return off, off },
+			want:       "keep\nthis line is\ntoo long",
+			wantCursor: 5,
+		},
+		{
+			name:       "nothing to wrap leaves the cursor",
+			text:       "short\n\nlines",
+			cursor:     3,
+			op:         paragraphAround,
+			want:       "short\n\nlines",
+			wantCursor: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.UI.ReflowWidth = 12
+			m := NewWritingModel(cfg)
+			m.SetValue(tt.text)
+			m.moveCursorToOffset(tt.cursor)
+
+			m.reflowMotion(tt.op)
+			if got := m.Value(); got != tt.want {
+				t.Errorf("value = %q, want %q", got, tt.want)
+			}
+			if got := m.cursorOffset(); got != tt.wantCursor {
+				t.Errorf("cursor at %d, want %d", got, tt.wantCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scrollDelta returns how many lines a mouse event scrolls by: negative for
+// the wheel turned up, positive for down, and 0 for anything else.
+func scrollDelta(msg tea.MouseMsg, linesPerNotch int) int {
+	if msg.Action != tea.MouseActionPress {
+		return 0
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return -linesPerNotch
+	case tea.MouseButtonWheelDown:
+		return linesPerNotch
+	}
+	return 0
+}
+
+// paneAtPoint returns the pane drawn at column x, row y of the main area,
+// given the panes' outer sizes and where the conversation pane sits.
+func paneAtPoint(position string, writing, convo rect, x, y int) focusState {
+	switch position {
+	case convoLeft:
+		if x < convo.width {
+			return conversationPane
+		}
+		return writingPane
+	case convoBottom:
+		if y < writing.height {
+			return writingPane
+		}
+		return conversationPane
+	default:
+		if x < writing.width {
+			return writingPane
+		}
+		return conversationPane
+	}
+}
+
+// paneAt returns the pane under the pointer, or false when it is over the
+// status bar or outside the window.
+func (m model) paneAt(x, y int) (focusState, bool) {
+	mainHeight := m.height - lipgloss.Height(m.statusBarModel.View())
+	if x < 0 || y < 0 || x >= m.width || y >= mainHeight {
+		return writingPane, false
+	}
+	sidebarWidth, width := splitSidebar(m.sidebar.shown, m.width)
+	if x < sidebarWidth {
+		return sidebarPane, true
+	}
+	x -= sidebarWidth
+	if !m.cfg.LLM.Enabled {
+		return writingPane, true
+	}
+	writing, convo := computeLayout(m.cfg.UI.ConvoPosition, width, mainHeight)
+	return paneAtPoint(m.cfg.UI.ConvoPosition, writing, convo, x, y), true
+}
+
+// handleMouse scrolls the pane under the pointer when the wheel turns.
+func (m *model) handleMouse(msg tea.MouseMsg) {
+	delta := scrollDelta(msg, m.cfg.UI.ScrollLines)
+	if delta == 0 || m.showDiff {
+		return
+	}
+	pane, ok := m.paneAt(msg.X, msg.Y)
+	if !ok {
+		return
+	}
+	switch pane {
+	case writingPane:
+		m.writingModel.Scroll(delta)
+	case conversationPane:
+		m.convoModel.Scroll(delta)
+	}
+}
+
+// Scroll moves the writing pane by lines, negative for up. Editable text
+// scrolls by moving the cursor, since the textarea keeps it in view.
+func (m *writingModel) Scroll(lines int) {
+	if m.readonly {
+		if lines < 0 {
+			m.viewport.ScrollUp(-lines)
+		} else {
+			m.viewport.ScrollDown(lines)
+		}
+		return
+	}
+
+	row, col := m.cursorPos()
+	m.moveCursorTo(max(0, row+lines), col)
+	m.refreshView()
+	m.applyScrollOff()
+}
+
+// Scroll moves the conversation by lines, negative for back toward older
+// messages.
+func (m *convoModel) Scroll(lines int) {
+	m.scroll = max(0, m.scroll-lines)
+}
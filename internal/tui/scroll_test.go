@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// wheel returns a wheel notch at column x, row y.
+func wheel(button tea.MouseButton, x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: button}
+}
+
+func TestScrollDelta(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.MouseMsg
+		want int
+	}{
+		{name: "wheel up", msg: wheel(tea.MouseButtonWheelUp, 0, 0), want: -3},
+		{name: "wheel down", msg: wheel(tea.MouseButtonWheelDown, 0, 0), want: 3},
+		{name: "wheel release", msg: tea.MouseMsg{Action: tea.MouseActionRelease, Button: tea.MouseButtonWheelDown}},
+		{name: "left click", msg: wheel(tea.MouseButtonLeft, 0, 0)},
+		{name: "motion", msg: tea.MouseMsg{Action: tea.MouseActionMotion, Button: tea.MouseButtonNone}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scrollDelta(tt.msg, 3); got != tt.want {
+				t.Errorf("scrollDelta = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaneAtPoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		x, y     int
+		want     focusState
+	}{
+		{name: "right, over writing", position: convoRight, x: 64, y: 5, want: writingPane},
+		{name: "right, over conversation", position: convoRight, x: 65, y: 5, want: conversationPane},
+		{name: "left, over conversation", position: convoLeft, x: 34, y: 5, want: conversationPane},
+		{name: "left, over writing", position: convoLeft, x: 35, y: 5, want: writingPane},
+		{name: "bottom, over writing", position: convoBottom, x: 90, y: 25, want: writingPane},
+		{name: "bottom, over conversation", position: convoBottom, x: 90, y: 26, want: conversationPane},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writing, convo := computeLayout(tt.position, 100, 40)
+			if got := paneAtPoint(tt.position, writing, convo, tt.x, tt.y); got != tt.want {
+				t.Errorf("paneAtPoint(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaneAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		llm    bool
+		x, y   int
+		want   focusState
+		wantOK bool
+	}{
+		{name: "writing pane", llm: true, x: 10, y: 10, want: writingPane, wantOK: true},
+		{name: "conversation pane", llm: true, x: 110, y: 10, want: conversationPane, wantOK: true},
+		{name: "writing fills the window without the llm", x: 110, y: 10, want: writingPane, wantOK: true},
+		{name: "status bar", llm: true, x: 10, y: 39},
+		{name: "outside the window", llm: true, x: 120, y: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var client llm.Client
+			if tt.llm {
+				client = &fakeClient{}
+			}
+			m := newTestModel(t, nil, client)
+			pane, ok := m.paneAt(tt.x, tt.y)
+			if ok != tt.wantOK || (ok && pane != tt.want) {
+				t.Errorf("paneAt(%d, %d) = %v, %v; want %v, %v", tt.x, tt.y, pane, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMouseScrollsPaneUnderPointer(t *testing.T) {
+	tests := []struct {
+		name        string
+		scrollLines int
+		x           int
+		wantRow     int
+		wantScroll  int
+	}{
+		{name: "over writing", scrollLines: 3, x: 10, wantRow: 3, wantScroll: 4},
+		{name: "over conversation", scrollLines: 3, x: 110, wantScroll: 1},
+		{name: "mouse scrolling off", x: 10, wantScroll: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.UI.ScrollLines = tt.scrollLines
+			}, &fakeClient{})
+			m.writingModel.SetValue(numberedLines(60))
+			m.writingModel.moveCursorTo(0, 0)
+			m.convoModel.scroll = 4 // Scrolled back from the newest message
+
+			updated, _ := m.Update(wheel(tea.MouseButtonWheelDown, tt.x, 10))
+			m = updated.(model)
+			if row, _ := m.writingModel.cursorPos(); row != tt.wantRow {
+				t.Errorf("cursor row = %d, want %d", row, tt.wantRow)
+			}
+			if m.convoModel.scroll != tt.wantScroll {
+				t.Errorf("conversation scroll = %d, want %d", m.convoModel.scroll, tt.wantScroll)
+			}
+		})
+	}
+}
+
+func TestConvoScroll(t *testing.T) {
+	var m convoModel
+	m.Scroll(-5)
+	if m.scroll != 5 {
+		t.Errorf("scroll back = %d, want 5", m.scroll)
+	}
+	m.Scroll(8)
+	if m.scroll != 0 {
+		t.Errorf("scroll past the newest = %d, want 0", m.scroll)
+	}
+
+	// A new message brings the newest back into view
+	m.Scroll(-5)
+	m.AddMessage(journal.Message{Role: "assistant", Content: "Keep going."})
+	if m.scroll != 0 {
+		t.Errorf("scroll after a new message = %d, want 0", m.scroll)
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Session describes a finished writing session, for the summary printed
+// after the TUI closes.
+type Session struct {
+	Words    int           // Words added during the session, across entries opened with gf
+	Duration time.Duration // Time from opening the TUI to quitting
+
+	Progress int    // Progress of the last entry toward its goal, in Unit
+	Goal     int    // The last entry's goal, in Unit; 0 when it has none
+	Unit     string // What Progress and Goal count, e.g. "words"
+
+	ReadOnly  bool // The entry was only read
+	Discarded bool // The session ended without saving
+}
+
+// GoalMet reports whether the last entry reached its goal.
+func (s Session) GoalMet() bool {
+	return s.Goal > 0 && s.Progress >= s.Goal
+}
+
+// startSession notes where the session starts from: the time, and the
+// entry's word count so only words written now are counted.
+func (m *model) startSession(at time.Time) {
+	m.sessionStart = at
+	m.startWords = m.writingModel.WordCount()
+}
+
+// leaveEntry adds the words written in the current entry to the session
+// before gf switches away from it.
+func (m *model) leaveEntry() {
+	m.wordsElsewhere += m.writingModel.WordCount() - m.startWords
+}
+
+// session summarizes the session as it stands at the given time.
+func (m model) session(at time.Time) Session {
+	current, target := m.progress()
+	return Session{
+		Words:     m.wordsElsewhere + m.writingModel.WordCount() - m.startWords,
+		Duration:  at.Sub(m.sessionStart),
+		Progress:  current,
+		Goal:      target,
+		Unit:      m.manager.Goal().Unit(),
+		ReadOnly:  m.writingModel.ReadOnly(),
+		Discarded: m.discarded,
+	}
+}
+
+// SessionOf returns the session summary of a model returned by the Bubble
+// Tea program, or false if it isn't one of ours.
+func SessionOf(final tea.Model) (Session, bool) {
+	m, ok := final.(model)
+	if !ok {
+		return Session{}, false
+	}
+	return m.session(time.Now()), true
+}
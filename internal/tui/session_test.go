@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSession(t *testing.T) {
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.Journal.WordCountGoal = 5
+	}, nil)
+	m.writingModel.SetValue("Already here")
+	m.startSession(time.Date(2024, 3, 5, 9, 0, 0, 0, time.Local))
+
+	m = typeText(m, " and three more")
+	got := m.session(time.Date(2024, 3, 5, 9, 4, 30, 0, time.Local))
+	want := Session{Words: 3, Duration: 4*time.Minute + 30*time.Second, Progress: 5, Goal: 5, Unit: "words"}
+	if got != want {
+		t.Errorf("session = %+v, want %+v", got, want)
+	}
+	if !got.GoalMet() {
+		t.Error("GoalMet = false at the goal")
+	}
+
+	// Words written before gf switches entries still count
+	other, err := m.manager.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+	other.Content = "Some earlier words"
+	m.switchEntry(other)
+	m = typeText(m, " plus two")
+	if got := m.session(time.Now()); got.Words != 5 || got.Progress != 5 {
+		t.Errorf("after switching entries: words %d, progress %d; want 5, 5", got.Words, got.Progress)
+	}
+}
+
+func TestSessionGoalMet(t *testing.T) {
+	tests := []struct {
+		session Session
+		want    bool
+	}{
+		{session: Session{Progress: 750, Goal: 750}, want: true},
+		{session: Session{Progress: 749, Goal: 750}},
+		{session: Session{Progress: 10}},
+	}
+	for _, tt := range tests {
+		if got := tt.session.GoalMet(); got != tt.want {
+			t.Errorf("%+v GoalMet = %v, want %v", tt.session, got, tt.want)
+		}
+	}
+}
+
+func TestSessionOf(t *testing.T) {
+	m := press(newTestModel(t, nil, nil), "esc", "q")
+	session, ok := SessionOf(m)
+	if !ok {
+		t.Fatal("SessionOf didn't recognize the model")
+	}
+	if session.Words != 0 || session.Discarded {
+		t.Errorf("session = %+v", session)
+	}
+
+	if _, ok := SessionOf(otherModel{}); ok {
+		t.Error("SessionOf accepted another model")
+	}
+}
+
+// otherModel is a Bubble Tea model that isn't ours.
+type otherModel struct{}
+
+func (otherModel) Init() tea.Cmd                       { return nil }
+func (otherModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return otherModel{}, nil }
+func (otherModel) View() string                        { return "" }
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// entryItem is one entry in the sidebar list.
+type entryItem struct {
+	entry *journal.JournalEntry
+}
+
+func (i entryItem) Title() string { return strings.TrimSuffix(i.entry.FileName, ".md") }
+
+func (i entryItem) Description() string {
+	return fmt.Sprintf("%s · %d words", i.entry.CreatedAt.Format("Jan 2 15:04"), i.entry.WordCount)
+}
+
+// FilterValue matches the filter against the entry name and its text,
+// reading the text the first time the list is filtered.
+func (i entryItem) FilterValue() string {
+	i.entry.LoadContent() // Unreadable entries match by name alone
+	return i.entry.FileName + " " + i.entry.Content
+}
+
+// sidebarModel lists the journal's entries, newest first, on the left of
+// the window. Typing / filters the list.
+type sidebarModel struct {
+	list  list.Model
+	shown bool
+}
+
+func newSidebarModel() sidebarModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "Entries"
+	l.SetShowHelp(false) // The status bar shows the keys
+	l.DisableQuitKeybindings()
+	return sidebarModel{list: l}
+}
+
+// SetSize sets the inner size of the sidebar.
+func (m *sidebarModel) SetSize(w, h int) { m.list.SetSize(w, h) }
+
+// SetEntries replaces the listed entries, putting the newest first.
+func (m *sidebarModel) SetEntries(entries []*journal.JournalEntry) tea.Cmd {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	items := make([]list.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = entryItem{entry}
+	}
+	return m.list.SetItems(items)
+}
+
+// Selected returns the highlighted entry.
+func (m sidebarModel) Selected() (*journal.JournalEntry, bool) {
+	item, ok := m.list.SelectedItem().(entryItem)
+	return item.entry, ok
+}
+
+// Filtering reports whether a filter is being typed, when keys belong to
+// the filter field.
+func (m sidebarModel) Filtering() bool { return m.list.SettingFilter() }
+
+func (m sidebarModel) Update(msg tea.Msg) (sidebarModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m sidebarModel) View() string { return m.list.View() }
+
+// toggleSidebar shows the sidebar and moves the keyboard to it, or hides
+// it when it already has the keyboard.
+func (m *model) toggleSidebar() tea.Cmd {
+	if m.focusedPane == sidebarPane {
+		m.sidebar.shown = false
+		m.updateSizes()
+		return m.focusWriting()
+	}
+	if m.focusedPane == writingPane && m.focusLocked() {
+		m.remindFocusLock()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if !m.sidebar.shown {
+		entries, err := m.manager.ListEntries()
+		if err != nil {
+			m.notify("Couldn't list entries: " + err.Error())
+			return nil
+		}
+		cmd = m.sidebar.SetEntries(entries)
+		m.sidebar.shown = true
+		m.updateSizes()
+	}
+	if m.focusedPane == writingPane {
+		m.saveOnBlur()
+		m.writingModel.Blur()
+	}
+	m.focusedPane = sidebarPane
+	return cmd
+}
+
+// focusWriting gives the keyboard back to the writing pane.
+func (m *model) focusWriting() tea.Cmd {
+	m.focusedPane = writingPane
+	return m.writingModel.Focus()
+}
+
+// handleSidebarKey moves through and filters the entry list while the
+// sidebar has the keyboard. Enter opens the highlighted entry; Esc and Tab
+// go back to writing, leaving the sidebar shown.
+func (m model) handleSidebarKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.sidebar.Filtering() {
+		var cmd tea.Cmd
+		m.sidebar, cmd = m.sidebar.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m.requestQuit()
+	case "ctrl+b":
+		return m, m.toggleSidebar()
+	case "tab":
+		return m, m.focusWriting()
+	case "esc":
+		if m.sidebar.list.IsFiltered() {
+			m.sidebar.list.ResetFilter()
+			return m, nil
+		}
+		return m, m.focusWriting()
+	case "enter":
+		selected, ok := m.sidebar.Selected()
+		if !ok {
+			return m, nil
+		}
+		if selected.FilePath != m.entry.FilePath {
+			entry, err := m.manager.ReadEntry(selected.FilePath)
+			if err != nil {
+				m.notify("Couldn't open " + selected.FileName + ": " + err.Error())
+				return m, nil
+			}
+			if !m.openInstead(entry) {
+				return m, nil
+			}
+		}
+		return m, m.focusWriting()
+	}
+
+	var cmd tea.Cmd
+	m.sidebar, cmd = m.sidebar.Update(msg)
+	return m, cmd
+}
+
+// openInstead leaves the current entry the way quitting would and opens
+// entry in its place. It stays put if the current entry couldn't be saved.
+func (m *model) openInstead(entry *journal.JournalEntry) bool {
+	m.finalSave(time.Now())
+	if m.saveErr != nil {
+		m.notify("Save failed, staying on this entry: " + m.saveErr.Error())
+		return false
+	}
+	m.switchEntry(entry)
+	return true
+}
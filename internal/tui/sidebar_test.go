@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sidebarTitles returns the titles of the entries the sidebar lists.
+func sidebarTitles(m sidebarModel) []string {
+	var titles []string
+	for _, item := range m.list.VisibleItems() {
+		titles = append(titles, item.(entryItem).Title())
+	}
+	return titles
+}
+
+func TestSidebarFilter(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2024, 3, day, 9, 0, 0, 0, time.Local) }
+	entries := []*journal.JournalEntry{
+		{FileName: "monday.md", CreatedAt: at(4), Content: "Planted tomatoes"},
+		{FileName: "wednesday.md", CreatedAt: at(6), Content: "Long meeting"},
+		{FileName: "tuesday.md", CreatedAt: at(5), Content: "Tomato sale at the market"},
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "", want: []string{"wednesday", "tuesday", "monday"}}, // Newest first
+		{filter: "tomato", want: []string{"monday", "tuesday"}},
+		{filter: "wednes", want: []string{"wednesday"}},
+		{filter: "zebra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			m := newSidebarModel()
+			m.SetSize(30, 20)
+			m.SetEntries(slices.Clone(entries))
+			if tt.filter != "" {
+				m.list.SetFilterText(tt.filter)
+			}
+			got := sidebarTitles(m)
+			slices.Sort(got)
+			want := slices.Clone(tt.want)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("listed %v, want %v", got, want)
+			}
+			if tt.filter == "" && !slices.Equal(sidebarTitles(m), tt.want) {
+				t.Errorf("order %v, want %v", sidebarTitles(m), tt.want)
+			}
+		})
+	}
+}
+
+func TestSidebarLayout(t *testing.T) {
+	m := newTestModel(t, nil, fakeClient{})
+	writingWidth, convoWidth := m.writingModel.width, m.convoModel.width
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = updated.(model)
+	if !m.sidebar.shown || m.focusedPane != sidebarPane {
+		t.Fatalf("shown = %v, focused = %v; want the sidebar shown and focused", m.sidebar.shown, m.focusedPane)
+	}
+	// The panes share what the sidebar leaves, keeping their proportions
+	frame := m.paneStyle.GetHorizontalFrameSize()
+	writing, convo := computeLayout(m.cfg.UI.ConvoPosition, 120-sidebarMaxWidth, 0)
+	if m.writingModel.width != writing.width-frame || m.convoModel.width != convo.width-frame {
+		t.Errorf("pane widths = %d, %d; want %d, %d", m.writingModel.width, m.convoModel.width, writing.width-frame, convo.width-frame)
+	}
+	if m.writingModel.width >= writingWidth || m.convoModel.width >= convoWidth {
+		t.Error("panes didn't shrink for the sidebar")
+	}
+	for _, line := range strings.Split(m.View(), "\n") {
+		if w := lipgloss.Width(line); w > 120 {
+			t.Fatalf("view line is %d wide, wider than the window", w)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = updated.(model)
+	if m.sidebar.shown || m.focusedPane != writingPane {
+		t.Errorf("shown = %v, focused = %v; want the sidebar hidden", m.sidebar.shown, m.focusedPane)
+	}
+	if m.writingModel.width != writingWidth {
+		t.Errorf("writing width = %d, want %d back", m.writingModel.width, writingWidth)
+	}
+}
+
+func TestSidebarOpensEntry(t *testing.T) {
+	m := newTestModel(t, nil, nil)
+	older, _, err := m.manager.CreateEntryAt(time.Now().Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	older.Content = "Yesterday's words"
+	if err := m.manager.SaveEntry(older); err != nil {
+		t.Fatalf("SaveEntry: %v", err)
+	}
+	current := m.entry.FilePath
+	m = typeText(m, "Today's words")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = updated.(model)
+	m = press(m, "j") // The newest, the current entry, is listed first
+	updated, _ = m.Update(keyMsg("enter"))
+	m = updated.(model)
+
+	if m.entry.FilePath != older.FilePath || m.focusedPane != writingPane {
+		t.Fatalf("entry = %s, focused = %v; want %s open for writing", m.entry.FileName, m.focusedPane, older.FileName)
+	}
+	if got := m.writingModel.Value(); got != "Yesterday's words" {
+		t.Errorf("value = %q", got)
+	}
+	saved, err := m.manager.ReadEntry(current)
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if saved.Content != "Today's words" {
+		t.Errorf("left entry saved as %q", saved.Content)
+	}
+}
@@ -0,0 +1,30 @@
+package tui
+
+import "fmt"
+
+// syncStatus passes the focused pane, and what the status bar shows for
+// it, to the status bar. It runs before each render, so the status always
+// follows the focus and the latest edit.
+func (m *model) syncStatus() {
+	bar := &m.statusBarModel
+	bar.SetFocus(m.focusedPane)
+	bar.hints = m.cfg.UI.StatusHints
+	bar.twoPane = m.cfg.LLM.Enabled
+
+	mode := "NORMAL"
+	switch {
+	case m.writingModel.ReadOnly():
+		mode = "READ-ONLY"
+	case m.writingModel.mode == modeInsert:
+		mode = "INSERT"
+	}
+	current, target := m.progress()
+	bar.SetWriting(mode, fmt.Sprintf("%d/%d %s", current, target, m.manager.Goal().Unit()))
+	bar.pacing = m.pacing()
+
+	ai := "unavailable"
+	if m.llmClient != nil {
+		ai = fmt.Sprintf("%s (%s)", m.cfg.LLM.Provider, m.cfg.LLM.ModelName)
+	}
+	bar.SetAI(ai)
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+)
+
+func TestStatusByPane(t *testing.T) {
+	tests := []struct {
+		name   string
+		client llm.Client
+		hints  bool
+		keys   []string
+		want   string
+	}{
+		{
+			name:   "writing",
+			client: fakeClient{}, hints: true,
+			want: "-- INSERT -- | 2/750 words | [Tab] chat | [Ctrl+B] entries | [q] quit",
+		},
+		{
+			name:   "writing in normal mode",
+			client: fakeClient{}, hints: true, keys: []string{"esc"},
+			want: "-- NORMAL -- | 2/750 words | [Tab] chat | [Ctrl+B] entries | [q] quit",
+		},
+		{
+			name:   "conversation",
+			client: fakeClient{}, hints: true, keys: []string{"tab"},
+			want: "AI: ollama (llama3) | [Ctrl+O] prompts | [Ctrl+T] summarize | [Tab] writing | [q] quit",
+		},
+		{
+			name:  "writing without the llm",
+			hints: true,
+			want:  "-- INSERT -- | 2/750 words | [Ctrl+B] entries | [q] quit",
+		},
+		{
+			name:   "hints off",
+			client: fakeClient{}, keys: []string{"tab"},
+			want: "AI: ollama (llama3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.UI.StatusHints = tt.hints
+				cfg.LLM.Provider = "ollama"
+				cfg.LLM.ModelName = "llama3"
+			}, tt.client)
+			m = press(typeText(m, "Two words"), tt.keys...)
+
+			m.syncStatus()
+			if got := strings.Join(m.statusBarModel.status(), " | "); got != tt.want {
+				t.Errorf("status =\n%q\nwant\n%q", got, tt.want)
+			}
+			if view := m.View(); !strings.Contains(view, tt.want) {
+				t.Errorf("view doesn't show the status %q", tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusReadOnly(t *testing.T) {
+	m := newReadOnlyModel(t, 3)
+	m.syncStatus()
+	if got := m.statusBarModel.status()[0]; got != "-- READ-ONLY --" {
+		t.Errorf("mode = %q, want read-only", got)
+	}
+}
+
+func TestStatusPromptAndAlert(t *testing.T) {
+	m := newTestModel(t, nil, nil)
+	m.syncStatus()
+	bar := m.statusBarModel
+
+	bar.SetAlert("Autosave failed")
+	if got := bar.View(); !strings.Contains(got, "Autosave failed") || strings.Contains(got, "INSERT") {
+		t.Errorf("alert view = %q, want the alert in place of the status", got)
+	}
+	bar.SetPrompt("Save before quitting? (y/n/c)")
+	if got := bar.View(); !strings.Contains(got, "Save before quitting?") || strings.Contains(got, "Autosave failed") {
+		t.Errorf("prompt view = %q, want the prompt over the alert", got)
+	}
+}
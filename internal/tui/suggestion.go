@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// suggestionTimeout bounds how long we wait for a continuation.
+const suggestionTimeout = 30 * time.Second
+
+// continuationPrompt asks the model for a short next sentence.
+const continuationPrompt = `You are helping someone write their morning pages.
+Continue the text below with one short sentence in the same voice.
+Reply with only the continuation, no quotes or commentary.
+
+`
+
+// suggestionMsg carries the result of a continuation request.
+type suggestionMsg struct {
+	text string
+	err  error
+}
+
+// requestSuggestion asks the LLM client to continue the given text.
+func requestSuggestion(client llm.Client, text string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), suggestionTimeout)
+		defer cancel()
+
+		reply, err := client.Generate(ctx, continuationPrompt+text)
+		if err != nil {
+			return suggestionMsg{err: err}
+		}
+		return suggestionMsg{text: joinContinuation(text, reply)}
+	}
+}
+
+// joinContinuation trims the model reply and makes sure it doesn't run
+// into the preceding word when inserted at the cursor.
+func joinContinuation(before, reply string) string {
+	reply = strings.TrimSpace(reply)
+	if reply == "" || before == "" {
+		return reply
+	}
+	last := before[len(before)-1]
+	if last != ' ' && last != '\n' && last != '\t' {
+		reply = " " + reply
+	}
+	return reply
+}
+
+// ghostMark is drawn around the cursor in the textarea view so the
+// suggestion can be placed at it; it never reaches the screen.
+const ghostMark = "\uE000"
+
+// textareaView renders the textarea with the pending suggestion, if any,
+// as dimmed ghost text at the cursor, where Tab would insert it. The ghost
+// text runs to the edge of the pane and is cut off there, so the pane
+// doesn't change height until the suggestion is accepted.
+func (m writingModel) textareaView() string {
+	if m.suggestion == "" || m.readonly {
+		return m.textarea.View()
+	}
+
+	// A blinking cursor isn't always drawn, so hold it still while the
+	// suggestion shows and mark where it is
+	ta := m.textarea
+	ta.Cursor.Blink = false
+	ta.Cursor.Style = ta.Cursor.Style.Transform(func(s string) string {
+		return ghostMark + s + ghostMark
+	})
+
+	lines := strings.Split(ta.View(), "\n")
+	for i, line := range lines {
+		start := strings.Index(line, ghostMark)
+		if start < 0 {
+			continue
+		}
+		end := start + len(ghostMark) + strings.Index(line[start+len(ghostMark):], ghostMark)
+		under := line[start+len(ghostMark) : end]
+
+		// The text is inserted before the character under the cursor, so
+		// the ghost goes before the cursor along with its styling
+		at := start
+		for at > 0 && isStyleSequenceEnd(line[:at]) {
+			at = strings.LastIndex(line[:at], "\x1b[")
+		}
+		ghost := strings.Join(strings.Fields(m.suggestion), " ")
+		if strings.HasPrefix(m.suggestion, " ") {
+			ghost = " " + ghost
+		}
+
+		// Cut the ghost short rather than push the cursor off the pane
+		width := ansi.StringWidth(strings.ReplaceAll(line, ghostMark, ""))
+		room := width - ansi.StringWidth(line[:at]) - ansi.StringWidth(under)
+		ghost = lipgloss.NewStyle().Faint(true).Render(ansi.Truncate(ghost, max(room, 0), "…"))
+
+		line = line[:at] + ghost + line[at:start] + under + line[end+len(ghostMark):]
+		lines[i] = ansi.Truncate(line, width, "")
+		return strings.Join(lines, "\n")
+	}
+
+	// The cursor is scrolled out of view
+	return m.textarea.View()
+}
+
+// isStyleSequenceEnd reports whether s ends with a complete ANSI style
+// sequence, such as the one starting the cursor's reverse video.
+func isStyleSequenceEnd(s string) bool {
+	i := strings.LastIndex(s, "\x1b[")
+	if i < 0 || !strings.HasSuffix(s, "m") {
+		return false
+	}
+	return strings.Trim(s[i+2:len(s)-1], "0123456789;") == ""
+}
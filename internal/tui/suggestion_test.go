@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeClient is an llm.Client that answers every prompt with reply.
+type fakeClient struct {
+	reply string
+}
+
+func (c fakeClient) Generate(ctx context.Context, prompt string) (string, error) {
+	return c.reply, nil
+}
+
+func (c fakeClient) Stream(ctx context.Context, prompt string) (<-chan llm.Chunk, error) {
+	ch := make(chan llm.Chunk, 1)
+	ch <- llm.Chunk{Text: c.reply}
+	close(ch)
+	return ch, nil
+}
+
+func (c fakeClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	return nil, nil
+}
+
+// suggest types text, asks for a suggestion and delivers the reply to m.
+func suggest(t *testing.T, m model, text string) model {
+	t.Helper()
+	m = typeText(m, text)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlAt})
+	m = updated.(model)
+	for _, msg := range runCmd(cmd) {
+		if msg, ok := msg.(suggestionMsg); ok {
+			updated, _ = m.Update(msg)
+			return updated.(model)
+		}
+	}
+	t.Fatal("no suggestion was requested")
+	return m
+}
+
+// runCmd runs cmd, and the commands of any batch it returns, and collects
+// the messages they produce.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, cmd := range batch {
+		msgs = append(msgs, runCmd(cmd)...)
+	}
+	return msgs
+}
+
+func TestSuggestion(t *testing.T) {
+	client := fakeClient{reply: "and the sun rose.\n"}
+
+	tests := []struct {
+		name      string
+		key       tea.KeyMsg
+		wantValue string
+		wantMode  writingMode
+	}{
+		{name: "tab accepts", key: tea.KeyMsg{Type: tea.KeyTab}, wantValue: "I woke and the sun rose.", wantMode: modeInsert},
+		{name: "esc dismisses", key: tea.KeyMsg{Type: tea.KeyEsc}, wantValue: "I woke", wantMode: modeNormal},
+		{name: "typing dismisses", key: keyMsg("!"), wantValue: "I woke!", wantMode: modeInsert},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := suggest(t, newTestModel(t, nil, client), "I woke")
+			if !m.writingModel.HasSuggestion() {
+				t.Fatal("no suggestion showing")
+			}
+			if got := m.writingModel.Value(); got != "I woke" {
+				t.Fatalf("value = %q before the suggestion was accepted", got)
+			}
+
+			updated, _ := m.Update(tt.key)
+			m = updated.(model)
+			if m.writingModel.HasSuggestion() {
+				t.Errorf("suggestion still showing")
+			}
+			if got := m.writingModel.Value(); got != tt.wantValue {
+				t.Errorf("value = %q, want %q", got, tt.wantValue)
+			}
+			if m.writingModel.mode != tt.wantMode {
+				t.Errorf("mode = %v, want %v", m.writingModel.mode, tt.wantMode)
+			}
+			if m.focusedPane != writingPane {
+				t.Errorf("focus moved to pane %v", m.focusedPane)
+			}
+		})
+	}
+}
+
+func TestSuggestionGhostText(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		keys  []string
+		want  string // On the cursor's line
+	}{
+		{name: "at the end of the line", reply: "and the sun rose.", want: "I woke and the sun rose."},
+		{name: "inside the line", reply: "slowly", keys: []string{"left", "left", "left", "left"}, want: "I slowlywoke"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, nil, fakeClient{reply: tt.reply})
+			m = press(typeText(m, "I woke"), tt.keys...)
+			m = suggest(t, m, "")
+
+			view := m.writingModel.View()
+			var found bool
+			for _, line := range strings.Split(view, "\n") {
+				if strings.Contains(line, "woke") {
+					found = true
+					if !strings.Contains(line, tt.want) {
+						t.Errorf("cursor line = %q, want it to show %q", line, tt.want)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("no line with the text in view:\n%s", view)
+			}
+			if strings.Contains(view, ghostMark) {
+				t.Errorf("view still holds the cursor mark")
+			}
+			if got := m.writingModel.Value(); got != "I woke" {
+				t.Errorf("value = %q; the ghost text was inserted", got)
+			}
+		})
+	}
+}
+
+func TestSuggestionSpinner(t *testing.T) {
+	m := typeText(newTestModel(t, nil, fakeClient{reply: "onward"}), "I woke")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlAt})
+	m = updated.(model)
+
+	if !m.writingModel.suggesting {
+		t.Fatal("suggestion not marked in flight")
+	}
+	if view := m.writingModel.View(); !strings.Contains(view, "thinking...") {
+		t.Errorf("view doesn't show the spinner while waiting:\n%s", view)
+	}
+
+	// The request and the spinner's first tick go out together
+	var reply tea.Msg
+	var ticked bool
+	for _, msg := range runCmd(cmd) {
+		switch msg := msg.(type) {
+		case suggestionMsg:
+			reply = msg
+		case spinner.TickMsg:
+			ticked = true
+			updated, next := m.Update(msg)
+			m = updated.(model)
+			if next == nil {
+				t.Error("spinner stopped while still waiting")
+			}
+		}
+	}
+	if reply == nil || !ticked {
+		t.Fatalf("got reply %v, spinner tick %v; want both", reply, ticked)
+	}
+
+	updated, _ = m.Update(reply)
+	m = updated.(model)
+	if m.writingModel.suggesting {
+		t.Error("still waiting after the reply arrived")
+	}
+	if view := m.writingModel.View(); strings.Contains(view, "thinking...") || !strings.Contains(view, "onward") {
+		t.Errorf("view after the reply:\n%s", view)
+	}
+
+	// A late tick doesn't restart the spinner
+	if _, next := m.Update(spinner.TickMsg{ID: m.writingModel.spinner.ID()}); next != nil {
+		t.Error("spinner kept ticking after the reply")
+	}
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// summaryContextTokens is the context window assumed for a summary request.
+// It is sized for small local models; the reply's MaxTokens come out of it.
+const summaryContextTokens = 8192
+
+// minTranscriptTokens keeps some transcript even when MaxTokens is set
+// close to the whole context.
+const minTranscriptTokens = 1024
+
+// charsPerToken is a rough estimate of characters per token for English.
+const charsPerToken = 4
+
+// summaryInstruction opens the summary prompt.
+const summaryInstruction = "Summarize this conversation in a short TL;DR: the main points, and anything the writer decided or wants to follow up on."
+
+// summaryRequest is shown in the conversation as the user's turn.
+const summaryRequest = "Summarize this conversation"
+
+// buildSummaryPrompt puts the transcript after the summary instruction. If
+// the transcript doesn't fit in budget tokens, the oldest messages are left
+// out, since the end of a conversation is usually where it landed.
+func buildSummaryPrompt(history []journal.Message, budget int) string {
+	maxChars := budget * charsPerToken
+	var kept []string
+	used := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		turn := roleLabel(history[i].Role) + ": " + history[i].Content
+		if used+len(turn) > maxChars && len(kept) > 0 {
+			kept = append(kept, fmt.Sprintf("(%d earlier messages left out)", i+1))
+			break
+		}
+		kept = append(kept, turn)
+		used += len(turn)
+	}
+
+	var b strings.Builder
+	b.WriteString(summaryInstruction)
+	b.WriteString("\n\nConversation:\n")
+	for i := len(kept) - 1; i >= 0; i-- {
+		b.WriteString(kept[i])
+		b.WriteString("\n\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// transcriptBudget returns the tokens left for the transcript once the
+// reply and the instruction are accounted for.
+func transcriptBudget(cfg *config.Config) int {
+	budget := summaryContextTokens - cfg.LLM.MaxTokens - len(summaryInstruction)/charsPerToken
+	return max(budget, minTranscriptTokens)
+}
+
+// summarizeConversation asks the client for a summary of the conversation.
+// The reply arrives like any other answer, so it is shown in the
+// conversation pane and saved with the entry.
+func summarizeConversation(history []journal.Message, client llm.Client, cfg *config.Config) tea.Cmd {
+	return requestAnswer(client, buildSummaryPrompt(history, transcriptBudget(cfg)))
+}
+
+// startSummary adds the summary request to the conversation and sends it.
+func (m *model) startSummary() tea.Cmd {
+	switch {
+	case m.llmClient == nil:
+		m.notify("AI is unavailable: no LLM provider could be set up.")
+		return nil
+	case m.convoModel.waiting:
+		return nil
+	case len(m.convoModel.Messages()) == 0:
+		m.notify("Nothing to summarize yet: the conversation is empty.")
+		return nil
+	}
+
+	history := m.convoModel.Messages()
+	m.convoModel.AddMessage(journal.Message{
+		Role:    journal.RoleUser,
+		Content: summaryRequest,
+		Time:    time.Now(),
+	})
+	m.convoModel.StartWaiting()
+	m.noteThrottle()
+	return summarizeConversation(history, m.llmClient, m.cfg)
+}
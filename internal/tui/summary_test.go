@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// summaryHistory is a short conversation to summarize.
+var summaryHistory = []journal.Message{
+	{Role: journal.RoleUser, Content: "Should I move to the city?"},
+	{Role: journal.RoleAssistant, Content: "What draws you there?"},
+	{Role: journal.RoleUser, Content: "Friends, and the job."},
+}
+
+func TestBuildSummaryPrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget int
+		want   string
+	}{
+		{
+			name:   "whole conversation",
+			budget: 1000,
+			want: summaryInstruction + "\n\nConversation:\n" +
+				"You: Should I move to the city?\n\nAI: What draws you there?\n\nYou: Friends, and the job.",
+		},
+		{
+			name:   "oldest left out",
+			budget: 13, // 52 characters: room for the last two messages
+			want: summaryInstruction + "\n\nConversation:\n" +
+				"(1 earlier messages left out)\n\nAI: What draws you there?\n\nYou: Friends, and the job.",
+		},
+		{
+			name:   "newest kept over budget",
+			budget: 1,
+			want: summaryInstruction + "\n\nConversation:\n" +
+				"(2 earlier messages left out)\n\nYou: Friends, and the job.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildSummaryPrompt(summaryHistory, tt.budget); got != tt.want {
+				t.Errorf("buildSummaryPrompt =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranscriptBudget(t *testing.T) {
+	tests := []struct {
+		maxTokens int
+		want      int
+	}{
+		{maxTokens: 2048, want: summaryContextTokens - 2048 - len(summaryInstruction)/charsPerToken},
+		{maxTokens: 0, want: summaryContextTokens - len(summaryInstruction)/charsPerToken},
+		{maxTokens: summaryContextTokens, want: minTranscriptTokens},
+	}
+
+	for _, tt := range tests {
+		cfg := config.DefaultConfig()
+		cfg.LLM.MaxTokens = tt.maxTokens
+		if got := transcriptBudget(cfg); got != tt.want {
+			t.Errorf("transcriptBudget with max_tokens %d = %d, want %d", tt.maxTokens, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeConversation(t *testing.T) {
+	var asked string
+	client := promptClient{
+		fakeClient: fakeClient{reply: "You are weighing a move for friends and work."},
+		onStream:   func(prompt string) { asked = prompt },
+	}
+	m := newTestModel(t, nil, client)
+	for _, msg := range summaryHistory {
+		m.convoModel.AddMessage(msg)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m = updated.(model)
+	for cmd != nil {
+		updated, cmd = m.Update(cmd())
+		m = updated.(model)
+	}
+
+	if want := buildSummaryPrompt(summaryHistory, transcriptBudget(m.cfg)); asked != want {
+		t.Errorf("asked %q, want %q", asked, want)
+	}
+	messages := m.convoModel.Messages()
+	if len(messages) != len(summaryHistory)+2 {
+		t.Fatalf("conversation has %d messages, want the request and the summary added", len(messages))
+	}
+	if got := messages[len(messages)-2]; got.Role != journal.RoleUser || got.Content != summaryRequest {
+		t.Errorf("request message = %+v", got)
+	}
+	if got := messages[len(messages)-1]; got.Role != journal.RoleAssistant || got.Content != client.reply {
+		t.Errorf("summary message = %+v", got)
+	}
+	if strings.Contains(asked, summaryRequest+"\n") {
+		t.Error("the summary request was summarized with the conversation")
+	}
+}
+
+func TestSummarizeNothing(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		wantNotice string
+	}{
+		{name: "empty conversation", enabled: true, wantNotice: "Nothing to summarize yet: the conversation is empty."},
+		{name: "llm disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.LLM.Enabled = tt.enabled
+			}, fakeClient{reply: "unused"})
+
+			updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+			m = updated.(model)
+			if cmd != nil {
+				t.Error("a summary was requested")
+			}
+			if got := len(m.convoModel.Messages()); got != 0 {
+				t.Errorf("conversation has %d messages", got)
+			}
+			if m.statusBarModel.prompt != tt.wantNotice {
+				t.Errorf("notice = %q, want %q", m.statusBarModel.prompt, tt.wantNotice)
+			}
+		})
+	}
+}
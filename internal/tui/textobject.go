@@ -0,0 +1,38 @@
+package tui
+
+// innerWord returns the range of vim's "iw" text object at off: the run of
+// runes of the same class as the one under the cursor, so a word, a run of
+// punctuation, or the blanks between words. The run stops at line breaks,
+// and an empty line selects nothing.
+func innerWord(text []rune, off int) (start, end int) {
+	// The textarea lets the cursor sit past the end of a line; vim would
+	// be on its last rune
+	if (off >= len(text) || text[off] == '\n') && off > 0 && text[off-1] != '\n' {
+		off--
+	}
+	if off >= len(text) || text[off] == '\n' {
+		return off, off
+	}
+
+	class := classOf(text[off])
+	start, end = off, off+1
+	for start > 0 && text[start-1] != '\n' && classOf(text[start-1]) == class {
+		start--
+	}
+	for end < len(text) && text[end] != '\n' && classOf(text[end]) == class {
+		end++
+	}
+	return start, end
+}
+
+// applyChange deletes the range that op selects from the cursor and
+// switches to insert mode in its place, like vim's c operator.
+func (m *writingModel) applyChange(op func(text []rune, off int) (start, end int)) {
+	text := []rune(m.textarea.Value())
+	start, end := op(text, m.cursorOffset())
+	m.deleteRange(start, end)
+	m.moveCursorToOffset(start) // Not the line start deleteRange picks at the end
+	m.mode = modeInsert
+	m.textarea.Focus()
+	m.refreshView()
+}
@@ -0,0 +1,37 @@
+package tui
+
+import "testing"
+
+func TestInnerWord(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		off       int
+		wantStart int
+		wantEnd   int
+	}{
+		{name: "word", text: "foo bar", off: 1, wantStart: 0, wantEnd: 3},
+		{name: "last word", text: "foo bar", off: 4, wantStart: 4, wantEnd: 7},
+		{name: "single blank", text: "foo bar", off: 3, wantStart: 3, wantEnd: 4},
+		{name: "run of blanks", text: "foo   bar", off: 4, wantStart: 3, wantEnd: 6},
+		{name: "leading blanks", text: "  foo", off: 0, wantStart: 0, wantEnd: 2},
+		{name: "punctuation run", text: "foo.,bar", off: 3, wantStart: 3, wantEnd: 5},
+		{name: "word beside punctuation", text: "foo.,bar", off: 6, wantStart: 5, wantEnd: 8},
+		{name: "underscores and digits", text: "a snake_case2 b", off: 5, wantStart: 2, wantEnd: 13},
+		{name: "stops at line break", text: "foo\nbar", off: 5, wantStart: 4, wantEnd: 7},
+		{name: "blanks stop at line break", text: "foo  \n  bar", off: 4, wantStart: 3, wantEnd: 5},
+		{name: "past line end is last rune", text: "foo\nbar", off: 3, wantStart: 0, wantEnd: 3},
+		{name: "end of buffer is last rune", text: "foo bar", off: 7, wantStart: 4, wantEnd: 7},
+		{name: "empty line", text: "foo\n\nbar", off: 4, wantStart: 4, wantEnd: 4},
+		{name: "empty buffer", text: "", off: 0, wantStart: 0, wantEnd: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := innerWord([]rune(tt.text), tt.off)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("innerWord(%q, %d) = %d, %d, want %d, %d", tt.text, tt.off, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
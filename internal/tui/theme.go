@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// theme holds the colors used to render the TUI.
+type theme struct {
+	border        lipgloss.TerminalColor // Border of unfocused panes
+	focusedBorder lipgloss.TerminalColor // Border of the focused pane
+	highlight     lipgloss.TerminalColor // Background of the current line
+	dimBorder     lipgloss.TerminalColor // Borders while the UI is dimmed after idling
+}
+
+// themes maps the UI.Theme config value to its colors.
+var themes = map[string]theme{
+	"dark": {
+		border:        lipgloss.Color("62"),  // Dimmed border
+		focusedBorder: lipgloss.Color("205"), // Highlighted border
+		highlight:     lipgloss.Color("236"),
+		dimBorder:     lipgloss.Color("238"),
+	},
+	"light": {
+		border:        lipgloss.Color("250"),
+		focusedBorder: lipgloss.Color("162"),
+		highlight:     lipgloss.Color("254"),
+		dimBorder:     lipgloss.Color("253"),
+	},
+}
+
+// plainTheme leaves everything uncolored, for terminals that shouldn't get
+// color whatever theme is configured.
+var plainTheme = theme{
+	border:        lipgloss.NoColor{},
+	focusedBorder: lipgloss.NoColor{},
+	highlight:     lipgloss.NoColor{},
+	dimBorder:     lipgloss.NoColor{},
+}
+
+// colorDisabled reports whether the environment asks for no color: NO_COLOR
+// set (see https://no-color.org) or a dumb terminal.
+func colorDisabled() bool {
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb"
+}
+
+// disableColor strips color from everything lipgloss renders, including the
+// styles bubbles components bring with them.
+func disableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// themeFor returns the named theme, falling back to dark for unknown names.
+// Without color it returns plainTheme.
+func themeFor(name string) theme {
+	if colorDisabled() {
+		return plainTheme
+	}
+	if t, ok := themes[name]; ok {
+		return t
+	}
+	return themes["dark"]
+}
+
+// borders maps the UI.Border and UI.FocusedBorder config values to lipgloss borders.
+var borders = map[string]lipgloss.Border{
+	"rounded": lipgloss.RoundedBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"normal":  lipgloss.NormalBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+}
+
+// plainFocusedBorder marks the focused pane in ASCII when there is no color
+// to pick it out; other panes get lipgloss.ASCIIBorder.
+var plainFocusedBorder = lipgloss.Border{
+	Top: "=", Bottom: "=", Left: "#", Right: "#",
+	TopLeft: "#", TopRight: "#", BottomLeft: "#", BottomRight: "#",
+}
+
+// borderFor returns the named border, falling back to the given default.
+// Unknown names are rejected when the config is loaded.
+func borderFor(name string, fallback lipgloss.Border) lipgloss.Border {
+	if b, ok := borders[name]; ok {
+		return b
+	}
+	return fallback
+}
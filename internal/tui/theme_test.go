@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// withColor makes the environment allow color, whatever the test runner's.
+func withColor(t *testing.T) {
+	t.Helper()
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+}
+
+func TestCurrentLineHighlight(t *testing.T) {
+	withColor(t)
+	tests := []struct {
+		name      string
+		theme     string
+		highlight bool
+		want      lipgloss.TerminalColor
+	}{
+		{name: "dark", theme: "dark", highlight: true, want: themes["dark"].highlight},
+		{name: "light", theme: "light", highlight: true, want: themes["light"].highlight},
+		{name: "unknown theme is dark", theme: "neon", highlight: true, want: themes["dark"].highlight},
+		{name: "off", theme: "dark", highlight: false, want: lipgloss.NoColor{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.UI.Theme = tt.theme
+				cfg.UI.HighlightCurrentLine = tt.highlight
+			}, nil)
+
+			// Insert mode focuses the textarea and normal mode blurs it
+			for _, mode := range []string{"insert", "normal"} {
+				ta := m.writingModel.textarea
+				style := ta.FocusedStyle.CursorLine
+				if !ta.Focused() {
+					style = ta.BlurredStyle.CursorLine
+				}
+				if got := style.GetBackground(); got != tt.want {
+					t.Errorf("%s mode: current line background = %v, want %v", mode, got, tt.want)
+				}
+				m = press(m, "esc")
+			}
+		})
+	}
+}
+
+func TestCurrentLineHighlightWithoutColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.UI.HighlightCurrentLine = true
+	}, nil)
+	if got := m.writingModel.textarea.FocusedStyle.CursorLine.GetBackground(); got != (lipgloss.NoColor{}) {
+		t.Errorf("current line background = %v, want none with NO_COLOR", got)
+	}
+}
+
+func TestBorderFor(t *testing.T) {
+	fallback := lipgloss.BlockBorder()
+	tests := []struct {
+		name string
+		want lipgloss.Border
+	}{
+		{name: "rounded", want: lipgloss.RoundedBorder()},
+		{name: "thick", want: lipgloss.ThickBorder()},
+		{name: "double", want: lipgloss.DoubleBorder()},
+		{name: "normal", want: lipgloss.NormalBorder()},
+		{name: "hidden", want: lipgloss.HiddenBorder()},
+		{name: "dotted", want: fallback},
+		{name: "", want: fallback},
+	}
+	for _, tt := range tests {
+		if got := borderFor(tt.name, fallback); got != tt.want {
+			t.Errorf("borderFor(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	// Every accepted config value has a border
+	for _, name := range config.BorderStyles {
+		if _, ok := borders[name]; !ok {
+			t.Errorf("config border style %q has no lipgloss border", name)
+		}
+	}
+}
+
+func TestPaneBorders(t *testing.T) {
+	withColor(t)
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.UI.Border = "double"
+		cfg.UI.FocusedBorder = "normal"
+	}, nil)
+	if got := m.paneStyle.GetBorderStyle(); got != lipgloss.DoubleBorder() {
+		t.Errorf("pane border = %+v, want double", got)
+	}
+	if got := m.focusedStyle.GetBorderStyle(); got != lipgloss.NormalBorder() {
+		t.Errorf("focused pane border = %+v, want normal", got)
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		noColor   string
+		term      string
+		wantColor bool
+	}{
+		{name: "NO_COLOR set", noColor: "1", term: "xterm-256color"},
+		{name: "dumb terminal", term: "dumb"},
+		{name: "color allowed", term: "xterm-256color", wantColor: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Render as a true color terminal would unless color is turned off
+			profile := lipgloss.ColorProfile()
+			lipgloss.SetColorProfile(termenv.TrueColor)
+			t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("TERM", tt.term)
+
+			if got := colorDisabled(); got == tt.wantColor {
+				t.Errorf("colorDisabled = %v", got)
+			}
+			if got := themeFor("dark") == plainTheme; got == tt.wantColor {
+				t.Errorf("themeFor returned the plain theme = %v", got)
+			}
+
+			m := typeText(newTestModel(t, nil, &fakeClient{}), "Morning")
+			view := m.View()
+			if got := strings.Contains(view, "\x1b["); got != tt.wantColor {
+				t.Errorf("view has ANSI codes = %v, want %v", got, tt.wantColor)
+			}
+			if !tt.wantColor {
+				// The writing pane has focus, so it gets the marked border
+				for _, want := range []string{"#===", "+---"} {
+					if !strings.Contains(view, want) {
+						t.Errorf("view lacks the plain border %q:\n%s", want, view)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+)
+
+// noteThrottle warns in the status bar when a request about to be sent
+// will be held back by llm.requests_per_minute, so the wait doesn't look
+// like a hang.
+func (m *model) noteThrottle() {
+	t, ok := m.llmClient.(llm.Throttler)
+	if !ok {
+		return
+	}
+	if delay := t.Delay().Round(time.Second); delay > 0 {
+		m.notify(fmt.Sprintf("Rate limited: waiting %s before sending...", delay))
+	}
+}
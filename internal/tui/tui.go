@@ -1,8 +1,19 @@
 package tui
 
 import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
 	// log "github.com/sirupsen/logrus" // TBD: Add logging if needed
 )
 
@@ -13,6 +24,7 @@ type focusState int
 const (
 	writingPane focusState = iota
 	conversationPane
+	sidebarPane
 )
 
 // --- Sub-model Placeholders --- //
@@ -31,34 +43,88 @@ func (m *writingModel) SetSize(w, h int) { m.width, m.height = w, h }
 func (m writingModel) View() string     { return "Writing Pane Placeholder" }
 */
 
-// TBD: Implement convoModel fully in Step 2.4
-type convoModel struct {
+// statusBarModel shows metrics and key hints for the focused pane, unless a
+// prompt or alert takes its place.
+type statusBarModel struct {
 	width  int
-	height int
+	prompt string // Confirmation prompt that replaces the regular status
+	alert  string // Problem shown in red until it is resolved
+
+	focused focusState // Pane whose status and hints are shown
+	hints   bool       // Show key hints after the status
+	twoPane bool       // The conversation pane is shown, so Tab switches panes
+	mode    string     // Writing pane mode, e.g. "INSERT"
+	words   string     // Progress toward the goal, e.g. "412/750 words"
+	ai      string     // Which AI is connected, or that none is
+	pacing  string     // Pacing dot after the progress, if pacing is on
 }
 
-func newConvoModel() convoModel {
-	return convoModel{}
+func newStatusBarModel() statusBarModel {
+	return statusBarModel{hints: true}
 }
-func (m *convoModel) SetSize(w, h int) { m.width, m.height = w, h }
-func (m convoModel) View() string      { return "Conversation Pane Placeholder" }
+func (m *statusBarModel) SetSize(w int)         { m.width = w }
+func (m *statusBarModel) SetPrompt(text string) { m.prompt = text }
+func (m *statusBarModel) SetAlert(text string)  { m.alert = text }
 
-// TBD: Implement statusBarModel fully later
-type statusBarModel struct {
-	width int
-}
+// SetFocus switches the status and hints to those of the given pane.
+func (m *statusBarModel) SetFocus(pane focusState) { m.focused = pane }
 
-func newStatusBarModel() statusBarModel {
-	return statusBarModel{}
+// SetWriting updates the writing pane's mode and progress.
+func (m *statusBarModel) SetWriting(mode, words string) {
+	m.mode = mode
+	m.words = words
 }
-func (m *statusBarModel) SetSize(w int) { m.width = w }
+
+// SetAI updates the AI connection status shown for the conversation pane.
+func (m *statusBarModel) SetAI(status string) { m.ai = status }
+
 func (m statusBarModel) View() string {
-	// Simple placeholder status
+	if m.prompt != "" {
+		return lipgloss.NewStyle().
+			Bold(true).
+			Width(m.width).
+			Render(m.prompt)
+	}
+	if m.alert != "" {
+		return lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("9")).
+			Width(m.width).
+			MaxHeight(1).
+			Render(m.alert)
+	}
+
 	return lipgloss.NewStyle().
-		// Background(lipgloss.Color("7")). // Example styling
-		// Foreground(lipgloss.Color("0")).
 		Width(m.width).
-		Render("Status: Word Count 0/750 | [Tab] to switch panes | [q] to quit")
+		MaxHeight(1).
+		Render(strings.Join(m.status(), " | "))
+}
+
+// status lists the parts of the regular status line for the focused pane.
+func (m statusBarModel) status() []string {
+	var parts, hints []string
+	switch m.focused {
+	case conversationPane:
+		parts = []string{"AI: " + m.ai}
+		hints = []string{"[Ctrl+O] prompts", "[Ctrl+T] summarize", "[Tab] writing"}
+	case sidebarPane:
+		parts = []string{"ENTRIES"}
+		hints = []string{"[/] filter", "[Enter] open", "[Esc] writing", "[Ctrl+B] hide"}
+	default:
+		parts = []string{"-- " + m.mode + " --", m.words}
+		if m.pacing != "" {
+			parts = append(parts, m.pacing)
+		}
+		if m.twoPane {
+			hints = []string{"[Tab] chat"}
+		}
+		hints = append(hints, "[Ctrl+B] entries")
+	}
+	if m.hints {
+		parts = append(parts, hints...)
+		parts = append(parts, "[q] quit")
+	}
+	return parts
 }
 
 // --- Main Model --- //
@@ -71,6 +137,51 @@ type model struct {
 	writingModel   writingModel
 	convoModel     convoModel
 	statusBarModel statusBarModel
+	sidebar        sidebarModel // Entry list on the left, toggled with Ctrl+B
+
+	// llmClient is used for AI assistance; nil when no provider is available
+	llmClient llm.Client
+	picker    promptPicker // Prompt library, opened with Ctrl+O
+
+	attachInput textinput.Model // Path of a file to attach, opened with Ctrl+R
+	attaching   bool            // The attach field has the keyboard
+
+	showDiff bool // Writing pane shows changes since the last save (Ctrl+G)
+
+	// Journal state for the entry being written
+	cfg         *config.Config
+	manager     *journal.Manager
+	entry       *journal.JournalEntry
+	lastSaved   string // Content as of the last successful save
+	lastDrafted string // Content as of the last draft written, with a draft directory
+	saveErr     error  // Error from the most recent save, if any
+	saveFails   int    // Saves failed in a row, shown in the status bar alert
+
+	// External change detection for the entry file
+	watcher         *fsnotify.Watcher     // nil if watching isn't available
+	pendingReload   *journal.JournalEntry // Disk version awaiting reload confirmation
+	pendingDraft    *string               // Earlier session's draft awaiting recovery confirmation
+	transientPrompt bool                  // Status prompt is a notice cleared by the next key
+
+	// Esc double-tap discard handling
+	lastEsc        time.Time // Time of the previous Esc in normal mode
+	confirmDiscard bool      // Waiting for the user to confirm discarding
+	confirmFinish  bool      // Waiting for the user to answer the goal reached prompt
+	confirmQuit    bool      // Waiting for the user to say whether to save before quitting
+	goalOffered    bool      // The goal reached prompt was already shown this session
+	autoQuitLeft   int       // Seconds left before quitting once the goal is reached; 0 if not counting down
+	autoQuitID     int       // Identifies the current countdown's ticks
+	discarded      bool      // Quit without saving
+
+	// Writing session totals, for the summary printed on exit
+	sessionStart   time.Time // When the TUI opened
+	pacingAt       time.Time // Time of the latest pacing tick
+	startWords     int       // Word count of the current entry when it was opened
+	wordsElsewhere int       // Words written in entries left with gf
+
+	// Idle dimming
+	lastInput time.Time // Time of the most recent key press
+	dimmed    bool      // UI is dimmed after DimAfter seconds without input
 
 	// Styles (can be customized later)
 	paneStyle     lipgloss.Style
@@ -80,18 +191,47 @@ type model struct {
 	quitting bool
 }
 
+// Option configures the model created by InitialModel.
+type Option func(*model)
+
+// WithReadOnly opens the entry for reading only: it can be scrolled but not
+// edited, and is never saved.
+func WithReadOnly(readonly bool) Option {
+	return func(m *model) {
+		m.writingModel.SetReadOnly(readonly)
+	}
+}
+
+// WithNotice shows text in the status bar when the session opens, until the
+// first key press. An empty notice shows nothing.
+func WithNotice(text string) Option {
+	return func(m *model) {
+		if text != "" {
+			m.notify(text)
+		}
+	}
+}
+
 // InitialModel creates the starting state for the Bubble Tea application.
-func InitialModel() model {
-	// Define base styles
-	// We can make these configurable later
+// The LLM client may be nil, in which case AI assistance is disabled.
+func InitialModel(cfg *config.Config, manager *journal.Manager, entry *journal.JournalEntry, client llm.Client, opts ...Option) model {
+	// Define base styles from the configured theme
+	th := themeFor(cfg.UI.Theme)
+	border := borderFor(cfg.UI.Border, lipgloss.RoundedBorder())
+	focusedBorder := borderFor(cfg.UI.FocusedBorder, lipgloss.ThickBorder())
+	if colorDisabled() {
+		// Plain characters only, and the focused pane picked out by shape
+		disableColor()
+		border, focusedBorder = lipgloss.ASCIIBorder(), plainFocusedBorder
+	}
 	paneStyle := lipgloss.NewStyle().
 		Padding(1, 2).
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")) // Dimmed border
+		Border(border).
+		BorderForeground(th.border)
 
 	focusedStyle := paneStyle.Copy().
-		Border(lipgloss.ThickBorder()).
-		BorderForeground(lipgloss.Color("205")) // Highlighted border
+		Border(focusedBorder).
+		BorderForeground(th.focusedBorder)
 
 	statusBarSyle := lipgloss.NewStyle()
 	// statusBarSyle := lipgloss.NewStyle().
@@ -99,14 +239,43 @@ func InitialModel() model {
 	// 	Foreground(lipgloss.Color("0"))
 
 	m := model{
-		writingModel:   NewWritingModel(),
-		convoModel:     newConvoModel(),
+		writingModel:   NewWritingModel(cfg),
 		statusBarModel: newStatusBarModel(),
+		sidebar:        newSidebarModel(),
+		llmClient:      client,
+		picker:         newPromptPicker(cfg.LLM.PromptLibrary),
+		attachInput:    newAttachInput(),
+		cfg:            cfg,
+		manager:        manager,
+		entry:          entry,
+		lastSaved:      entry.Content,
+		lastDrafted:    entry.Content,
 		focusedPane:    writingPane, // Start focus in writing pane
+		lastInput:      time.Now(),
 		paneStyle:      paneStyle,
 		focusedStyle:   focusedStyle,
 		statusBarSyle:  statusBarSyle,
 	}
+	// Show the conversation from earlier sessions; start fresh if it can't
+	// be read. Without AI there is no conversation pane at all.
+	if cfg.LLM.Enabled {
+		messages, _ := manager.LoadConversation(entry)
+		m.convoModel = newConvoModel(messages, cfg.UI.RenderAIMarkdown, cfg.UI.Theme)
+	}
+
+	m.writingModel.SetHeader(entry.Header)
+	m.writingModel.SetValue(entry.Content)
+	for _, opt := range opts {
+		opt(&m)
+	}
+	m.writingModel.Focus() // Focus starts in the writing pane
+	m.startSession(time.Now())
+	m.offerDraft()
+
+	// Watch for external edits; the TUI works fine without it
+	if w, err := newEntryWatcher(entry.FilePath); err == nil {
+		m.watcher = w
+	}
 	return m
 }
 
@@ -114,7 +283,13 @@ func InitialModel() model {
 func (m model) Init() tea.Cmd {
 	// Initialize sub-models and gather their initial commands
 	// For now, only writingModel might have an initial command (like Blink)
-	return m.writingModel.Init()
+	return tea.Batch(
+		m.writingModel.Init(),
+		m.autosaveTick(),
+		m.idleTick(m.dimAfter()),
+		m.pacingTick(),
+		waitForFileEvent(m.watcher),
+	)
 }
 
 // Update handles incoming messages and updates the model's state.
@@ -122,6 +297,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	// Any key press wakes a dimmed UI and is then handled as usual.
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.registerInput(time.Now())
+	}
+
 	switch msg := msg.(type) {
 	// Handle window resize events.
 	case tea.WindowSizeMsg:
@@ -131,17 +311,198 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateSizes()
 		// TBD: We might need to return update commands from sub-models if they react to resize
 
+	// Periodically save the entry and sample its word count.
+	case autosaveTickMsg:
+		before, _ := m.progress()
+		saveCmd := m.handleAutosave(time.Time(msg))
+		goalCmd := m.checkGoalReached(before) // Time-based goals move with the clock
+		return m, tea.Batch(m.autosaveTick(), saveCmd, goalCmd)
+
+	// An autosave failed; alert until a save succeeds.
+	case AutosaveErrorMsg:
+		m.noteSave(msg.Err)
+		return m, nil
+
+	// Pulse the pacing dot.
+	case pacingTickMsg:
+		m.pacingAt = time.Time(msg)
+		return m, m.pacingTick()
+
+	// Count down to quitting after the goal was reached.
+	case autoQuitTickMsg:
+		return m, m.handleAutoQuitTick(msg)
+
+	// The entry file was touched; check whether someone else changed it.
+	case fileEventMsg:
+		if filepath.Clean(msg.path) != filepath.Clean(m.entry.FilePath) {
+			return m, waitForFileEvent(m.watcher)
+		}
+		return m, tea.Batch(m.checkFileChange, waitForFileEvent(m.watcher))
+
+	// Open the entry a [[link]] points at.
+	case followLinkMsg:
+		m.handleFollowLink(msg)
+
+	case FileChangedMsg:
+		m.handleFileChanged(msg)
+
+	// Dim the UI after a stretch without input.
+	case idleTickMsg:
+		return m, m.handleIdleTick(time.Time(msg))
+
+	// Handle the result of an AI continuation request.
+	case suggestionMsg:
+		if msg.err == nil && strings.TrimSpace(msg.text) == "" && m.cfg.LLM.EmptyReplyNotice != "" {
+			msg.text, msg.err = "", errors.New(m.cfg.LLM.EmptyReplyNotice)
+		}
+		m.writingModel.SetSuggestion(msg.text, msg.err)
+
+	// Handle the AI's reply to a library prompt.
+	case answerMsg:
+		m.handleAnswer(msg)
+
+	// Show the reply to a library prompt as it streams in.
+	case answerChunkMsg:
+		return m, m.handleAnswerChunk(msg)
+
+	// Catch up the word count after a burst of typing in low-power mode.
+	case recountMsg:
+		before, _ := m.progress()
+		m.writingModel.recount()
+		cmds = append(cmds, m.checkGoalReached(before))
+
+	// Animate the suggestion spinner.
+	case spinner.TickMsg:
+		m.writingModel, cmd = m.writingModel.Update(msg)
+		cmds = append(cmds, cmd)
+
+	// Scroll the pane under the pointer with the mouse wheel.
+	case tea.MouseMsg:
+		m.handleMouse(msg)
+
 	// Handle keyboard events.
 	case tea.KeyMsg:
+		// Ctrl+L clears the screen and redraws everything, whatever else
+		// is going on, in case stray output corrupted the display.
+		if msg.String() == "ctrl+l" {
+			m.updateSizes()
+			return m, tea.ClearScreen
+		}
+
+		// Any key stops the countdown to quitting after the goal, and is
+		// then handled as usual so no typing is lost.
+		if m.autoQuitLeft > 0 {
+			m.cancelAutoQuit()
+		}
+
+		// A pending suggestion is accepted with Tab; any other key dismisses
+		// it and is then handled as usual.
+		if m.writingModel.HasSuggestion() {
+			if msg.String() == "tab" {
+				before, _ := m.progress()
+				m.writingModel.AcceptSuggestion()
+				return m, m.checkGoalReached(before)
+			}
+			m.writingModel.DismissSuggestion()
+		}
+
+		// The discard confirmation takes over the keyboard until answered.
+		if m.confirmDiscard {
+			return m.handleDiscardConfirm(msg)
+		}
+
+		// As does the offer to finish once a hard goal is reached.
+		if m.confirmFinish {
+			return m.handleFinishConfirm(msg)
+		}
+
+		// And the save-before-quitting question.
+		if m.confirmQuit {
+			return m.handleQuitConfirm(msg)
+		}
+
+		// So does the reload prompt after an external change.
+		if m.pendingReload != nil {
+			return m.handleReloadConfirm(msg)
+		}
+
+		// And the offer to recover a draft left by an earlier session.
+		if m.pendingDraft != nil {
+			return m.handleDraftConfirm(msg)
+		}
+
+		// Ctrl+G toggles the changes view; any other key closes it and is
+		// then handled as usual.
+		if msg.String() == "ctrl+g" {
+			m.showDiff = !m.showDiff
+			return m, nil
+		}
+		m.showDiff = false
+
+		// The prompt library takes over the keyboard while open.
+		if m.picker.open {
+			return m.handlePickerKey(msg)
+		}
+
+		// As does the attach field.
+		if m.attaching {
+			return m.handleAttachKey(msg)
+		}
+
+		// Notices stay up until the next key press.
+		if m.transientPrompt {
+			m.transientPrompt = false
+			m.statusBarModel.SetPrompt("")
+		}
+
+		// The entry sidebar has the keyboard while focused.
+		if m.focusedPane == sidebarPane {
+			return m.handleSidebarKey(msg)
+		}
+
+		// Esc Esc in normal mode offers to abandon the session, unless focus
+		// lock holds the writer until the goal.
+		if msg.Type == tea.KeyEsc && m.focusedPane == writingPane && m.writingModel.mode == modeNormal {
+			if m.registerEsc(time.Now()) {
+				if m.focusLocked() {
+					m.remindFocusLock()
+					return m, nil
+				}
+				m.confirmDiscard = true
+				m.statusBarModel.SetPrompt(discardPrompt)
+				return m, nil
+			}
+		}
+
 		switch msg.String() {
 		// Quit the application.
-		case "ctrl+c", "q":
-			m.quitting = true
-			return m, tea.Quit
+		case "ctrl+c", "q", "ctrl+q":
+			// q is text while writing and finishes a pending command such
+			// as gq, so it only quits from normal mode with nothing pending
+			// or when another pane has focus
+			if msg.String() == "q" && m.focusedPane == writingPane &&
+				(m.writingModel.mode != modeNormal || m.writingModel.pendingKey != "") {
+				return m, m.updateWriting(msg)
+			}
+			// Ctrl+Q gets past focus lock
+			if msg.String() != "ctrl+q" && m.focusLocked() {
+				m.remindFocusLock()
+				return m, nil
+			}
+			return m.requestQuit()
 
 		// Switch focus between panes.
 		case "tab":
+			// There is only one pane without AI
+			if !m.cfg.LLM.Enabled {
+				return m, nil
+			}
+			if m.focusedPane == writingPane && m.focusLocked() {
+				m.remindFocusLock()
+				return m, nil
+			}
 			if m.focusedPane == writingPane {
+				m.saveOnBlur()
 				m.focusedPane = conversationPane
 				m.writingModel.Blur() // Blur the writing pane
 				// cmd = m.convoModel.Focus() // TBD: Focus convo pane when implemented
@@ -153,14 +514,50 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 			return m, tea.Batch(cmds...) // Consume the message and return focus command
 
+		// Ask the AI to continue the text up to the cursor (ctrl+space).
+		case "ctrl+@":
+			if m.focusedPane != writingPane || m.llmClient == nil || m.writingModel.ReadOnly() {
+				return m, nil
+			}
+			m.noteThrottle()
+			return m, tea.Batch(
+				m.writingModel.StartSuggestion(),
+				requestSuggestion(m.llmClient, m.aiContext(m.writingModel.TextBeforeCursor())),
+			)
+
+		// Show the entry sidebar, or hide it.
+		case "ctrl+b":
+			return m, m.toggleSidebar()
+
+		// Pick a prompt from the library to ask the AI about the entry.
+		case "ctrl+o":
+			if !m.cfg.LLM.Enabled {
+				return m, nil
+			}
+			m.openPicker()
+			return m, nil
+
+		// Ask the AI for a TL;DR of the conversation so far.
+		case "ctrl+t":
+			if !m.cfg.LLM.Enabled {
+				return m, nil
+			}
+			return m, m.startSummary()
+
+		// Attach a file to the entry and link it at the cursor.
+		case "ctrl+r":
+			if m.focusedPane != writingPane || m.writingModel.ReadOnly() {
+				return m, nil
+			}
+			return m, m.openAttach()
+
 		// TBD: Handle Ctrl+W + h/l for switching focus as an alternative
 
 		default:
 			// Delegate other key presses to the focused pane
 			switch m.focusedPane {
 			case writingPane:
-				m.writingModel, cmd = m.writingModel.Update(msg)
-				cmds = append(cmds, cmd)
+				cmds = append(cmds, m.updateWriting(msg))
 			case conversationPane:
 				// TBD: Delegate to convoModel when implemented
 				// m.convoModel, cmd = m.convoModel.Update(msg)
@@ -180,26 +577,57 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// withSidebar puts the entry sidebar, when shown, to the left of the
+// panes.
+func (m model) withSidebar(panes string) string {
+	width, _ := splitSidebar(m.sidebar.shown, m.width)
+	if width == 0 {
+		return panes
+	}
+	paneStyle, focusedStyle := m.paneStyles()
+	style := paneStyle
+	if m.focusedPane == sidebarPane {
+		style = focusedStyle
+	}
+	frameWidth, frameHeight := m.paneStyle.GetFrameSize()
+	view := lipgloss.NewStyle().
+		Width(width - frameWidth).
+		Height(lipgloss.Height(panes) - frameHeight).
+		MaxHeight(lipgloss.Height(panes) - frameHeight).
+		Render(m.sidebar.View())
+	return lipgloss.JoinHorizontal(lipgloss.Top, style.Render(view), panes)
+}
+
+// updateWriting passes a key to the writing pane and checks whether the
+// edit reached the goal.
+func (m *model) updateWriting(msg tea.KeyMsg) tea.Cmd {
+	before, _ := m.progress()
+	var cmd tea.Cmd
+	m.writingModel, cmd = m.writingModel.Update(msg)
+	return tea.Batch(cmd, m.checkGoalReached(before))
+}
+
 // updateSizes calculates and sets the dimensions for the sub-models based on the main model's width and height.
 func (m *model) updateSizes() {
 	statusBarHeight := lipgloss.Height(m.statusBarModel.View()) // Calculate actual height
 	mainHeight := m.height - statusBarHeight
 
-	// Simple 65/35 split, adjust as needed
-	writingWidth := int(float64(m.width) * 0.65)
-	// Ensure minimum width or handle edge cases if necessary
-	if writingWidth < 10 {
-		writingWidth = 10
-	}
-	convoWidth := m.width - writingWidth
-	if convoWidth < 10 {
-		convoWidth = 10
-		// Adjust writing width if convo width hits minimum
-		writingWidth = m.width - convoWidth
+	// The sidebar, when shown, takes its width off the left
+	sidebarWidth, width := splitSidebar(m.sidebar.shown, m.width)
+	m.sidebar.SetSize(sidebarWidth-m.paneStyle.GetHorizontalFrameSize(), mainHeight-m.paneStyle.GetVerticalFrameSize())
+
+	// Without AI the writing pane gets the whole area
+	if !m.cfg.LLM.Enabled {
+		m.writingModel.SetSize(width-m.paneStyle.GetHorizontalFrameSize(), mainHeight-m.paneStyle.GetVerticalFrameSize())
+		m.statusBarModel.SetSize(m.width)
+		return
 	}
 
-	m.writingModel.SetSize(writingWidth-m.paneStyle.GetHorizontalBorderSize(), mainHeight-m.paneStyle.GetVerticalBorderSize())
-	m.convoModel.SetSize(convoWidth-m.paneStyle.GetHorizontalBorderSize(), mainHeight-m.paneStyle.GetVerticalBorderSize())
+	writing, convo := computeLayout(m.cfg.UI.ConvoPosition, width, mainHeight)
+
+	// Subtract the full frame (border and padding) so the rendered panes fit the layout
+	m.writingModel.SetSize(writing.width-m.paneStyle.GetHorizontalFrameSize(), writing.height-m.paneStyle.GetVerticalFrameSize())
+	m.convoModel.SetSize(convo.width-m.paneStyle.GetHorizontalFrameSize(), convo.height-m.paneStyle.GetVerticalFrameSize())
 	m.statusBarModel.SetSize(m.width)
 }
 
@@ -207,6 +635,9 @@ func (m *model) updateSizes() {
 func (m model) View() string {
 	// If quitting, show a final message.
 	if m.quitting {
+		if m.discarded {
+			return "Discarding changes and quitting Momentum Journal...\n"
+		}
 		return "Saving and quitting Momentum Journal...\n"
 	}
 
@@ -216,17 +647,42 @@ func (m model) View() string {
 
 	// Get views from sub-models
 	writingView := m.writingModel.View()
-	convoView := m.convoModel.View()
+	if m.showDiff {
+		writingView = renderDiffView(m.lastSaved, m.writingModel.Value(), m.writingModel.width, m.writingModel.height)
+	}
+	m.syncStatus()
 	statusBarView := m.statusBarModel.View()
 
+	// Pure writing: just the writing pane above the status bar
+	if !m.cfg.LLM.Enabled {
+		_, focusedStyle := m.paneStyles()
+		styledWritingView := lipgloss.NewStyle().Width(m.writingModel.width + m.paneStyle.GetHorizontalFrameSize()).Height(m.writingModel.height + m.paneStyle.GetVerticalFrameSize()).Render(focusedStyle.Render(writingView))
+		return lipgloss.JoinVertical(lipgloss.Left,
+			m.withSidebar(styledWritingView),
+			m.statusBarSyle.Width(m.width).Render(statusBarView),
+		)
+	}
+
+	convoView := m.convoModel.View()
+	if m.picker.open {
+		convoView = m.picker.View(m.convoModel.width)
+	}
+	// Fill the pane so its border doesn't shrink to fit the content
+	convoView = lipgloss.NewStyle().
+		Width(m.convoModel.width).
+		Height(m.convoModel.height).
+		MaxHeight(m.convoModel.height).
+		Render(convoView)
+
 	// Apply focus styling
+	paneStyle, focusedStyle := m.paneStyles()
 	var styledWritingView, styledConvoView string
 	if m.focusedPane == writingPane {
-		styledWritingView = m.focusedStyle.Render(writingView)
-		styledConvoView = m.paneStyle.Render(convoView)
+		styledWritingView = focusedStyle.Render(writingView)
+		styledConvoView = paneStyle.Render(convoView)
 	} else {
-		styledWritingView = m.paneStyle.Render(writingView)
-		styledConvoView = m.focusedStyle.Render(convoView)
+		styledWritingView = paneStyle.Render(writingView)
+		styledConvoView = focusedStyle.Render(convoView)
 	}
 
 	// Set dimensions on the styled views before joining
@@ -234,17 +690,21 @@ func (m model) View() string {
 	styledWritingView = lipgloss.NewStyle().Width(m.writingModel.width + m.paneStyle.GetHorizontalFrameSize()).Height(m.writingModel.height + m.paneStyle.GetVerticalFrameSize()).Render(styledWritingView)
 	styledConvoView = lipgloss.NewStyle().Width(m.convoModel.width + m.paneStyle.GetHorizontalFrameSize()).Height(m.convoModel.height + m.paneStyle.GetVerticalFrameSize()).Render(styledConvoView)
 
-	// Join the panes horizontally
-	mainPane := lipgloss.JoinHorizontal(
-		lipgloss.Top, // Align panes to the top
-		styledWritingView,
-		styledConvoView,
-	)
+	// Arrange the panes according to the configured conversation position
+	var mainPane string
+	switch m.cfg.UI.ConvoPosition {
+	case convoLeft:
+		mainPane = lipgloss.JoinHorizontal(lipgloss.Top, styledConvoView, styledWritingView)
+	case convoBottom:
+		mainPane = lipgloss.JoinVertical(lipgloss.Left, styledWritingView, styledConvoView)
+	default:
+		mainPane = lipgloss.JoinHorizontal(lipgloss.Top, styledWritingView, styledConvoView)
+	}
 
 	// Join the main pane and status bar vertically
 	fullView := lipgloss.JoinVertical(
 		lipgloss.Left, // Align items to the left
-		mainPane,
+		m.withSidebar(mainPane),
 		m.statusBarSyle.Width(m.width).Render(statusBarView), // Ensure status bar takes full width
 	)
 
@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// newTestModel opens a new entry, in a journal kept in memory, with the
+// default config but for any changes made by edit.
+func newTestModel(t *testing.T, edit func(*config.Config), client llm.Client) model {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Journal.StorageDir = "/journal"
+	cfg.LLM.Enabled = client != nil
+	if edit != nil {
+		edit(cfg)
+	}
+
+	manager, err := journal.NewManager(cfg, zap.NewNop(), journal.WithStore(journal.NewMemoryStore()))
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	entry, err := manager.CreateEntry()
+	if err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+
+	m := InitialModel(cfg, manager, entry, client)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return updated.(model)
+}
+
+// keyMsg returns the key press for a key name as tea.KeyMsg.String()
+// gives it, e.g. "esc" or "tab", or for a single character.
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "ctrl+c":
+		return tea.KeyMsg{Type: tea.KeyCtrlC}
+	case "ctrl+q":
+		return tea.KeyMsg{Type: tea.KeyCtrlQ}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// press sends the keys to m one after another, dropping the commands they
+// return.
+func press(m model, keys ...string) model {
+	for _, key := range keys {
+		updated, _ := m.Update(keyMsg(key))
+		m = updated.(model)
+	}
+	return m
+}
+
+// typeText sends each character of text as a key press.
+func typeText(m model, text string) model {
+	for _, r := range text {
+		m = press(m, string(r))
+	}
+	return m
+}
+
+func TestQuitKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		wantQuit  bool
+		wantValue string
+	}{
+		{name: "q is text in insert mode", keys: []string{"a", " ", "q", "u", "i", "e", "t"}, wantValue: "a quiet"},
+		{name: "q quits from normal mode", keys: []string{"a", "esc", "q"}, wantQuit: true, wantValue: "a"},
+		{name: "gq is a pending command", keys: []string{"a", "esc", "g", "q"}, wantValue: "a"},
+		{name: "gqq rewraps", keys: []string{"a", "esc", "g", "q", "q"}, wantValue: "a"},
+		{name: "q quits after gqq", keys: []string{"a", "esc", "g", "q", "q", "q"}, wantQuit: true, wantValue: "a"},
+		{name: "ctrl+c quits from insert mode", keys: []string{"a", "ctrl+c"}, wantQuit: true, wantValue: "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := press(newTestModel(t, nil, nil), tt.keys...)
+			if m.quitting != tt.wantQuit {
+				t.Errorf("quitting = %v, want %v", m.quitting, tt.wantQuit)
+			}
+			if got := m.writingModel.Value(); got != tt.wantValue {
+				t.Errorf("value = %q, want %q", got, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestConfirmQuit(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		wantQuit  bool
+		wantSaved string
+	}{
+		{name: "y saves and quits", key: "y", wantQuit: true, wantSaved: "a"},
+		{name: "n quits without saving", key: "n", wantQuit: true, wantSaved: ""},
+		{name: "c goes back to writing", key: "c", wantSaved: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, func(cfg *config.Config) {
+				cfg.UI.ConfirmQuit = true
+			}, nil)
+			m = press(m, "a", "esc", "q")
+			if !m.confirmQuit || m.quitting {
+				t.Fatalf("confirmQuit = %v, quitting = %v; want the prompt first", m.confirmQuit, m.quitting)
+			}
+
+			m = press(m, tt.key)
+			if m.confirmQuit {
+				t.Errorf("prompt still open")
+			}
+			if m.quitting != tt.wantQuit {
+				t.Errorf("quitting = %v, want %v", m.quitting, tt.wantQuit)
+			}
+			if m.discarded != (tt.key == "n") {
+				t.Errorf("discarded = %v", m.discarded)
+			}
+			saved, err := m.manager.ReadEntry(m.entry.FilePath)
+			if err != nil {
+				t.Fatalf("ReadEntry: %v", err)
+			}
+			if saved.Content != tt.wantSaved {
+				t.Errorf("saved content = %q, want %q", saved.Content, tt.wantSaved)
+			}
+			if got := m.writingModel.Value(); got != "a" {
+				t.Errorf("value = %q, want the text kept", got)
+			}
+		})
+	}
+}
+
+func TestConfirmQuitSkippedWhenSaved(t *testing.T) {
+	m := newTestModel(t, func(cfg *config.Config) {
+		cfg.UI.ConfirmQuit = true
+	}, nil)
+	m = press(m, "esc", "q")
+	if m.confirmQuit || !m.quitting {
+		t.Errorf("confirmQuit = %v, quitting = %v; want to quit without asking", m.confirmQuit, m.quitting)
+	}
+}
+
+func TestLLMDisabled(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		cfg := config.DefaultConfig()
+		cfg.Journal.StorageDir = "/journal"
+		cfg.LLM.Enabled = enabled
+		manager, err := journal.NewManager(cfg, zap.NewNop(), journal.WithStore(journal.NewMemoryStore()))
+		if err != nil {
+			t.Fatalf("NewManager: %v", err)
+		}
+		entry, err := manager.CreateEntry()
+		if err != nil {
+			t.Fatalf("CreateEntry: %v", err)
+		}
+		earlier := []journal.Message{{Role: journal.RoleUser, Content: "an earlier question"}}
+		if err := manager.SaveConversation(entry, earlier); err != nil {
+			t.Fatalf("SaveConversation: %v", err)
+		}
+
+		var client llm.Client
+		if enabled {
+			client = fakeClient{}
+		}
+		updated, _ := InitialModel(cfg, manager, entry, client).Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		m := updated.(model)
+
+		loaded := len(m.convoModel.Messages()) > 0
+		shown := strings.Contains(m.View(), "an earlier question")
+		if loaded != enabled || shown != enabled {
+			t.Errorf("llm.enabled %v: conversation loaded %v, shown %v", enabled, loaded, shown)
+		}
+		if full := m.writingModel.width == 120-m.paneStyle.GetHorizontalFrameSize(); full == enabled {
+			t.Errorf("llm.enabled %v: writing pane %d wide of 120", enabled, m.writingModel.width)
+		}
+		if !enabled && m.convoModel.width != 0 {
+			t.Errorf("llm.enabled false: conversation pane laid out %d wide", m.convoModel.width)
+		}
+
+		// The AI keys do nothing without AI
+		if enabled {
+			continue
+		}
+		m = press(m, "esc", "tab")
+		if m.focusedPane != writingPane {
+			t.Error("tab moved to a conversation pane that isn't there")
+		}
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+		if updated.(model).picker.open {
+			t.Error("ctrl+o opened the prompt picker")
+		}
+		if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlAt}); cmd != nil {
+			t.Error("ctrl+space asked for a suggestion")
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"path/filepath"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	reloadPrompt    = "Entry changed on disk. Reload it? (y/n)"
+	conflictWarning = "Entry changed on disk, but you have unsaved changes (conflict). Saving will overwrite it."
+)
+
+// FileChangedMsg reports that the entry file was written by something else.
+type FileChangedMsg struct {
+	entry *journal.JournalEntry // The entry as it is now on disk
+}
+
+// fileEventMsg is a raw watcher event for a file next to the entry.
+type fileEventMsg struct {
+	path string
+}
+
+// newEntryWatcher watches the directory holding the entry, since many
+// editors save by replacing the file rather than writing to it.
+func newEntryWatcher(entryPath string) (*fsnotify.Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(filepath.Dir(entryPath)); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// watchEntryDir moves the watcher from the directory of the entry being
+// left to that of the entry being opened, when they are filed apart.
+func (m *model) watchEntryDir(oldPath, newPath string) {
+	oldDir, newDir := filepath.Dir(oldPath), filepath.Dir(newPath)
+	if m.watcher == nil || oldDir == newDir {
+		return
+	}
+	m.watcher.Remove(oldDir)
+	m.watcher.Add(newDir) // Without it external edits go unnoticed, as with no watcher
+}
+
+// waitForFileEvent blocks until a file in the entry's directory is written
+// or created. It returns nil once the watcher is closed. Events are matched
+// to the entry in Update, since following a link changes the entry.
+func waitForFileEvent(w *fsnotify.Watcher) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return nil
+				}
+				if event.Has(fsnotify.Write | fsnotify.Create) {
+					return fileEventMsg{path: event.Name}
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// isExternalChange reports whether the file on disk holds content we didn't
+// write. Mod times can't be compared since ReadEntry prefers the modified
+// time recorded in the entry's frontmatter.
+func isExternalChange(diskContent, lastSaved string) bool {
+	return diskContent != lastSaved
+}
+
+// checkFileChange re-reads the entry after a watcher event and decides
+// whether it was changed externally.
+func (m model) checkFileChange() tea.Msg {
+	disk, err := m.manager.ReadEntry(m.entry.FilePath)
+	if err != nil {
+		return nil
+	}
+	if !isExternalChange(disk.Content, m.manager.SavedContent(m.entry.Header, m.lastSaved)) {
+		return nil
+	}
+	return FileChangedMsg{entry: disk}
+}
+
+// handleFileChanged prompts to reload, or warns when local edits would conflict.
+func (m *model) handleFileChanged(msg FileChangedMsg) {
+	if m.dirty() {
+		m.statusBarModel.SetPrompt(conflictWarning)
+		m.transientPrompt = true
+		return
+	}
+	m.pendingReload = msg.entry
+	m.statusBarModel.SetPrompt(reloadPrompt)
+}
+
+// handleReloadConfirm resolves the reload prompt: "y" loads the disk
+// version into the writing pane, anything else keeps the current text.
+func (m model) handleReloadConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	disk := m.pendingReload
+	m.pendingReload = nil
+	m.statusBarModel.SetPrompt("")
+
+	if msg.String() == "y" {
+		m.entry.Content = disk.Content
+		m.entry.WordCount = disk.WordCount
+		m.entry.IsCompleted = disk.IsCompleted
+		m.writingModel.SetValue(disk.Content)
+		m.lastSaved = disk.Content
+		m.lastDrafted = disk.Content
+	}
+	return m, nil
+}
+
+// closeWatcher stops watching the entry file.
+func (m *model) closeWatcher() {
+	if m.watcher != nil {
+		m.watcher.Close()
+	}
+}
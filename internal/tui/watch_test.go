@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"go.uber.org/zap"
+)
+
+func TestSwitchEntryMovesWatcher(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Journal.StorageDir = t.TempDir()
+	cfg.Journal.FolderLayout = config.FolderLayoutByMonth
+	cfg.LLM.Enabled = false
+	manager, err := journal.NewManager(cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	march, _, err := manager.CreateEntryAt(time.Date(2024, 3, 5, 9, 30, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+	april, _, err := manager.CreateEntryAt(time.Date(2024, 4, 5, 9, 30, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("CreateEntryAt: %v", err)
+	}
+
+	m := InitialModel(cfg, manager, march, nil)
+	if m.watcher == nil {
+		t.Fatal("no watcher for the entry directory")
+	}
+	defer m.closeWatcher()
+
+	m.switchEntry(april)
+	watched := m.watcher.WatchList()
+	if !slices.Contains(watched, filepath.Dir(april.FilePath)) {
+		t.Errorf("watching %v, want the new entry's directory", watched)
+	}
+	if slices.Contains(watched, filepath.Dir(march.FilePath)) {
+		t.Errorf("watching %v, still with the old entry's directory", watched)
+	}
+}
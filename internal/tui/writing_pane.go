@@ -1,7 +1,15 @@
 package tui
 
 import (
+	"strings"
+	"time"
+
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/config"
+	"github.com/ZachBeta/momentum_journal_nvim_go/internal/journal"
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -14,30 +22,93 @@ const (
 	modeInsert
 )
 
+// writingMaxLines caps the buffer length. The textarea defaults to 99
+// lines, which is far too short for morning pages and truncates pastes.
+const writingMaxLines = 9999
+
 // writingModel holds the state for the text editing pane.
 type writingModel struct {
 	textarea textarea.Model
 	mode     writingMode
 	width    int
 	height   int
-	// TBD: Yank buffer, word count, etc.
+
+	// Pending AI continuation, shown as ghost text until accepted or dismissed
+	suggestion    string
+	suggestionErr error
+	suggesting    bool
+	spinner       spinner.Model // Shown while waiting for a suggestion
+
+	scrollOff  int    // Lines of context kept visible around the cursor
+	pendingKey string // Keys so far of a multi-key normal mode command, e.g. "g" or "dg"
+	register   string // Text removed by the last delete
+
+	cursorShapes bool // Switch between block and bar cursors with the mode
+	cursorBlink  bool // Blink the cursor in insert mode
+
+	continueLists bool             // o and O continue markdown lists as well as the indent
+	dateFormat    string           // Go time layout inserted by ctrl+d; empty leaves ctrl+d to the textarea
+	now           func() time.Time // Replaceable clock for ctrl+d
+	reflowTo      int              // Width gq wraps to; 0 uses the pane width
+
+	lowPower       bool      // No animations, and words are recounted at most once a second while typing
+	lastCount      time.Time // When words were last recounted while typing in low-power mode
+	recountPending bool      // A recountMsg is on its way
+
+	wordCount  int              // Words in the buffer as of the last edit
+	countWords func(string) int // Counter matching the journal's configuration
+	header     string           // Entry header, left out of the word count
+
+	lineCounts  *lineCountGutter  // Running word count per line; nil when off
+	lineNumbers *lineNumberGutter // Relative line numbers; nil unless UI.LineNumbers is relative
+
+	readonly bool           // Show the text for reading only
+	viewport viewport.Model // Scrollable view of the text in read-only mode
+	// TBD: Yank buffer, etc.
 }
 
 // NewWritingModel creates a new instance of the writing pane model.
-func NewWritingModel() writingModel {
+func NewWritingModel(cfg *config.Config) writingModel {
 	ta := textarea.New()
 	ta.Placeholder = "Start your morning pages..."
-	ta.ShowLineNumbers = true // Let's enable line numbers
-	ta.Focus()                // Start focused so the cursor initially shows
+	ta.MaxHeight = writingMaxLines
+	if !cfg.UI.CursorBlink || cfg.UI.LowPower {
+		ta.Cursor.SetMode(cursor.CursorStatic)
+	}
+	ta.Focus() // Start focused so the cursor initially shows
 
-	// Configure styles (optional, can be customized later)
-	// ta.FocusedStyle.CursorLine = lipgloss.NewStyle().Background(lipgloss.Color("62"))
-	// ta.BlurredStyle.CursorLine = lipgloss.NewStyle()
+	// Highlight the current line in both modes; normal mode blurs the
+	// textarea, so the blurred style needs the same tint.
+	cursorLine := lipgloss.NewStyle()
+	if cfg.UI.HighlightCurrentLine {
+		cursorLine = cursorLine.Background(themeFor(cfg.UI.Theme).highlight)
+	}
+	ta.FocusedStyle.CursorLine = cursorLine
+	ta.BlurredStyle.CursorLine = cursorLine
 
 	m := writingModel{
 		textarea: ta,
 		mode:     modeInsert, // Start in Insert mode for immediate typing
+		spinner:  spinner.New(spinner.WithSpinner(spinner.MiniDot)),
+
+		scrollOff:  cfg.UI.ScrollOff,
+		countWords: journal.WordCounter(cfg),
+
+		cursorShapes: cfg.UI.CursorShapes && cursorShapesSupported(),
+		cursorBlink:  cfg.UI.CursorBlink && !cfg.UI.LowPower,
+
+		continueLists: cfg.UI.ContinueLists,
+		dateFormat:    cfg.UI.DateInsertFormat,
+		now:           time.Now,
+		reflowTo:      cfg.UI.ReflowWidth,
+
+		lowPower: cfg.UI.LowPower,
 	}
+	m.setLineNumbers(cfg.UI.LineNumbers)
+	if cfg.UI.LineWordCounts {
+		m.enableLineCounts()
+	}
+	m.installGutter()
 	// Initially blur it, the main model will focus it based on state
 	m.textarea.Blur()
 	return m
@@ -50,15 +121,26 @@ func (m *writingModel) SetSize(w, h int) {
 	indicatorHeight := lipgloss.Height(m.renderModeIndicator())
 	m.textarea.SetWidth(w)
 	m.textarea.SetHeight(h - indicatorHeight)
+	m.updateViewport()
+	m.updateLineCounts()
+	m.updateLineNumbers()
 }
 
 // Init initializes the writing model, returning an initial command.
 func (m writingModel) Init() tea.Cmd {
 	// If starting in Insert mode, start blinking the cursor.
 	if m.mode == modeInsert {
-		return textarea.Blink
+		return tea.Batch(m.blinkCmd(), m.cursorShapeCmd())
 	}
-	return nil
+	return m.cursorShapeCmd()
+}
+
+// blinkCmd starts the cursor blinking, or returns nil when blinking is off.
+func (m writingModel) blinkCmd() tea.Cmd {
+	if !m.cursorBlink {
+		return nil
+	}
+	return textarea.Blink
 }
 
 // Update handles messages for the writing pane.
@@ -67,36 +149,123 @@ func (m writingModel) Update(msg tea.Msg) (writingModel, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep the spinner moving only while a suggestion is in flight
+		if m.suggesting {
+			m.spinner, cmd = m.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+
 	case tea.KeyMsg:
+		if m.readonly {
+			return m.updateReadOnly(msg)
+		}
 		if m.mode == modeInsert {
-			switch msg.Type {
-			case tea.KeyEsc:
+			switch {
+			case msg.Type == tea.KeyEsc:
 				m.mode = modeNormal
-				m.textarea.Blur() // Show static cursor in normal mode
-				return m, nil     // Consume Esc
+				m.textarea.Blur()            // Show static cursor in normal mode
+				return m, m.cursorShapeCmd() // Consume Esc
+			case msg.String() == "ctrl+d" && m.dateFormat != "":
+				m.InsertText(m.now().Format(m.dateFormat)) // Timestamp at the cursor
+			case msg.Paste:
+				// Bracketed paste arrives as one message; insert it in one
+				// go and count once, rather than treating it as typing
+				m.textarea.InsertString(string(msg.Runes))
+				m.updateWordCount()
 			default:
 				// Default textarea behavior for input
 				m.textarea, cmd = m.textarea.Update(msg)
-				cmds = append(cmds, cmd)
-				// TBD: Trigger word count update message
+				cmds = append(cmds, cmd, m.countTyping())
 			}
 		} else { // modeNormal
+			// Complete a multi-key command started by the previous key
+			if pending := m.pendingKey; pending != "" {
+				m.pendingKey = ""
+				switch pending + msg.String() {
+				case "ge": // End of previous word
+					m.applyMotion(prevEndOfWord)
+				case "gg": // Start of buffer
+					m.applyMotion(bufferStart)
+				case "gf": // Follow the [[link]] under the cursor
+					cmd = followLink(m.linkAtCursor())
+				case "gq", "gqa", "gqi", "gqg": // Wait for the rest of a reflow
+					m.pendingKey = pending + msg.String()
+				case "gqq": // Reflow the line
+					m.reflowMotion(func(text []rune, off int) (int, int) { return off, off })
+				case "gqap", "gqip": // Reflow the paragraph
+					m.reflowMotion(paragraphAround)
+				case "gqG": // Reflow to the end of the buffer
+					m.reflowMotion(func(text []rune, off int) (int, int) { return off, len(text) })
+				case "gqgg": // Reflow to the start of the buffer
+					m.reflowMotion(func(text []rune, off int) (int, int) { return 0, off })
+				case "dd": // Delete the line
+					m.applyDelete(func(text []rune, off int) (int, int) {
+						return wholeLines(text, off, off)
+					})
+				case "d$": // Delete to end of line
+					m.applyDelete(toLineEnd)
+				case "d0": // Delete to start of line
+					m.applyDelete(toLineStart)
+				case "d}": // Delete to the end of the paragraph
+					m.applyDelete(func(text []rune, off int) (int, int) {
+						return off, nextParagraph(text, off)
+					})
+				case "d{": // Delete back to the start of the paragraph
+					m.applyDelete(func(text []rune, off int) (int, int) {
+						return prevParagraph(text, off), off
+					})
+				case "dG": // Delete to end of buffer, linewise
+					m.applyDelete(func(text []rune, off int) (int, int) {
+						return wholeLines(text, off, len(text))
+					})
+				case "di", "ci": // Wait for the text object
+					m.pendingKey = pending + msg.String()
+				case "diw": // Delete the word under the cursor
+					m.applyDelete(innerWord)
+				case "ciw": // Change the word under the cursor
+					m.applyChange(innerWord)
+					cmd = tea.Batch(m.blinkCmd(), m.cursorShapeCmd())
+				case "dg": // Wait for the second g of dgg
+					m.pendingKey = "dg"
+				case "dgg": // Delete to start of buffer, linewise
+					m.applyDelete(func(text []rune, off int) (int, int) {
+						return wholeLines(text, 0, off)
+					})
+				}
+				m.applyScrollOff()
+				return m, cmd
+			}
+
 			switch msg.String() {
 			case "i":
 				m.mode = modeInsert
 				m.textarea.Focus()
-				cmds = append(cmds, textarea.Blink)
+				cmds = append(cmds, m.blinkCmd(), m.cursorShapeCmd())
 			case "a": // TBD: Insert after cursor
-			case "o": // TBD: Insert new line below
+			case "o", "O": // Open a line below or above, keeping the indent
+				m.openLine(msg.String() == "o")
+				cmds = append(cmds, m.blinkCmd(), m.cursorShapeCmd())
 			case "h", "j", "k", "l", "up", "down", "left", "right": // Basic movement
 				// Pass movement keys to the textarea in normal mode too
 				m.textarea, cmd = m.textarea.Update(msg)
 				cmds = append(cmds, cmd)
 			case "w", "b": // TBD: Word movement
-			case "g": // TBD: Handle gg
-			case "G": // TBD: Go to end
+			case "e": // End of word
+				m.applyMotion(endOfWord)
+			case "g": // Prefix for ge, gg, gf, and gq
+				m.pendingKey = "g"
+			case "G": // Start of the last line
+				m.applyMotion(lastLineStart)
+			case "}": // Next paragraph
+				m.applyMotion(nextParagraph)
+			case "{": // Previous paragraph
+				m.applyMotion(prevParagraph)
 			case "x": // TBD: Delete character
-			case "d": // TBD: Handle dd
+			case "d": // Delete operator: dd, d$, d0, d}, d{, dG, dgg, diw
+				m.pendingKey = "d"
+			case "c": // Change operator: ciw
+				m.pendingKey = "c"
 			case "y": // TBD: Handle yy
 			case "p": // TBD: Paste
 			default:
@@ -105,6 +274,7 @@ func (m writingModel) Update(msg tea.Msg) (writingModel, tea.Cmd) {
 				cmds = append(cmds, cmd)
 			}
 		}
+		m.applyScrollOff()
 	}
 
 	return m, tea.Batch(cmds...)
@@ -112,25 +282,125 @@ func (m writingModel) Update(msg tea.Msg) (writingModel, tea.Cmd) {
 
 // View renders the writing pane UI.
 func (m writingModel) View() string {
+	if m.lineNumbers != nil {
+		m.lineNumbers.cursor = m.textarea.Line()
+	}
+	body := m.textareaView()
+	if m.readonly {
+		body = m.viewport.View()
+	}
 	return lipgloss.JoinVertical(lipgloss.Left,
 		m.renderModeIndicator(),
-		m.textarea.View(),
+		body,
 	)
 }
 
 // renderModeIndicator returns the visual indicator for the current mode.
 func (m writingModel) renderModeIndicator() string {
 	indicator := "[NORMAL]"
-	if m.mode == modeInsert {
+	switch {
+	case m.readonly:
+		indicator = "[READ-ONLY]"
+	case m.mode == modeInsert:
 		indicator = "[INSERT]"
 	}
 	// TBD: Style the indicator (e.g., different colors)
-	return lipgloss.NewStyle().Padding(0, 1).Render(indicator)
+	indicator = lipgloss.NewStyle().Padding(0, 1).Render(indicator)
+
+	// Show the status of a suggestion next to the indicator so the pane
+	// height stays fixed; the suggestion itself shows at the cursor.
+	ghost := ""
+	switch {
+	case m.suggesting && m.lowPower:
+		ghost = "thinking..."
+	case m.suggesting:
+		ghost = m.spinner.View() + " thinking..."
+	case m.suggestionErr != nil:
+		ghost = "suggestion failed: " + m.suggestionErr.Error()
+	case m.suggestion != "":
+		ghost = "[Tab] accept"
+	}
+	if ghost == "" {
+		return indicator
+	}
+	ghostWidth := m.width - lipgloss.Width(indicator)
+	if ghostWidth <= 0 {
+		return indicator
+	}
+	ghost = lipgloss.NewStyle().
+		Faint(true).
+		MaxWidth(ghostWidth).
+		Render(ghost)
+	return lipgloss.JoinHorizontal(lipgloss.Top, indicator, ghost)
+}
+
+// TextBeforeCursor returns the buffer content up to the cursor position.
+func (m writingModel) TextBeforeCursor() string {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	row, col := m.cursorPos()
+	if row >= len(lines) {
+		return m.textarea.Value()
+	}
+	current := []rune(lines[row])
+	if col > len(current) {
+		col = len(current)
+	}
+	before := append(lines[:row:row], string(current[:col]))
+	return strings.Join(before, "\n")
+}
+
+// StartSuggestion marks a continuation request as in flight and starts the
+// spinner, which stays still in low-power mode.
+func (m *writingModel) StartSuggestion() tea.Cmd {
+	m.DismissSuggestion()
+	m.suggesting = true
+	if m.lowPower {
+		return nil
+	}
+	return m.spinner.Tick
+}
+
+// SetSuggestion stores the result of a continuation request.
+func (m *writingModel) SetSuggestion(text string, err error) {
+	m.suggesting = false
+	m.suggestion = text
+	m.suggestionErr = err
+}
+
+// HasSuggestion reports whether ghost text (or a suggestion error) is showing.
+func (m writingModel) HasSuggestion() bool {
+	return m.suggestion != "" || m.suggestionErr != nil
+}
+
+// AcceptSuggestion inserts the pending suggestion at the cursor.
+func (m *writingModel) AcceptSuggestion() {
+	if m.suggestion != "" && !m.readonly {
+		m.textarea.InsertString(m.suggestion)
+		m.updateWordCount()
+	}
+	m.DismissSuggestion()
+}
+
+// InsertText inserts text at the cursor.
+func (m *writingModel) InsertText(text string) {
+	if m.readonly {
+		return
+	}
+	m.textarea.InsertString(text)
+	m.updateWordCount()
+	m.refreshView()
+}
+
+// DismissSuggestion discards the pending suggestion without inserting it.
+func (m *writingModel) DismissSuggestion() {
+	m.suggestion = ""
+	m.suggestionErr = nil
+	m.suggesting = false
 }
 
 // Focus sets the writing pane to be focused.
 func (m *writingModel) Focus() tea.Cmd {
-	if m.mode == modeInsert {
+	if m.mode == modeInsert && !m.readonly {
 		return m.textarea.Focus()
 	}
 	return nil
@@ -141,9 +411,32 @@ func (m *writingModel) Blur() {
 	m.textarea.Blur()
 }
 
-// WordCount calculates the number of words in the textarea.
+// Value returns the current content of the writing pane.
+func (m writingModel) Value() string {
+	return m.textarea.Value()
+}
+
+// SetValue replaces the content of the writing pane.
+func (m *writingModel) SetValue(s string) {
+	m.textarea.SetValue(s)
+	m.updateWordCount()
+	m.updateViewport()
+}
+
+// SetHeader sets the entry header that is left out of the word count.
+func (m *writingModel) SetHeader(header string) {
+	m.header = header
+	m.updateWordCount()
+}
+
+// WordCount returns the number of words in the textarea as of the last edit.
 func (m writingModel) WordCount() int {
-	// TBD: Implement more accurate word count logic (from Phase 1 helper)
-	// For now, a simple split by space approximation
-	return len(m.textarea.Value()) // Replace with actual count
+	return m.wordCount
+}
+
+// updateWordCount recounts the words in the textarea after an edit.
+func (m *writingModel) updateWordCount() {
+	m.wordCount = m.countWords(journal.StripHeader(m.header, m.textarea.Value()))
+	m.updateLineCounts()
+	m.updateLineNumbers()
 }